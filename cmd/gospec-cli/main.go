@@ -1,9 +1,28 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harihs-330/gospec-cli"
+	"github.com/harihs-330/gospec-cli/pkg/conform"
+	"github.com/harihs-330/gospec-cli/pkg/converter"
+	"github.com/harihs-330/gospec-cli/pkg/diff"
+	"github.com/harihs-330/gospec-cli/pkg/generator"
+	"github.com/harihs-330/gospec-cli/pkg/harness"
+	"github.com/harihs-330/gospec-cli/pkg/info"
+	"github.com/harihs-330/gospec-cli/pkg/mcp"
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/harihs-330/gospec-cli/pkg/scaffold"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+	"github.com/harihs-330/gospec-cli/pkg/validate"
 	"github.com/spf13/cobra"
 )
 
@@ -19,11 +38,9 @@ func main() {
 Similar to openapi-generator, it analyzes your CLI code and produces a standardized
 specification that can be used for documentation, validation, and code generation.
 
-Supported CLI frameworks:
-  - Cobra (github.com/spf13/cobra)
-  - urfave/cli (github.com/urfave/cli)
-  - Standard library flag package
-  - And more...`,
+Supported CLI frameworks include Cobra, urfave/cli, Kong, Kingpin and the
+standard library flag package, plus compiled binaries through their help
+output. Run "gospec-cli list-frameworks" for the full list.`,
 		Version: version,
 	}
 
@@ -39,7 +56,7 @@ The tool will:
 4. Generate a complete OpenCLI specification
 
 Examples:
-  # Generate from current directory (auto-detect framework)
+  # Generate from current directory
   gospec-cli generate -i . -o opencli.yaml
 
   # Generate from specific package
@@ -48,42 +65,86 @@ Examples:
   # Generate JSON format
   gospec-cli generate -i . -o opencli.json -f json
 
+  # Generate Markdown documentation
+  gospec-cli generate -i . -o docs/cli.md -f markdown
+
+  # Generate Markdown documentation, one page per command
+  gospec-cli generate -i . --output-dir docs/cli -f markdown
+
+  # Generate man pages, one per command, into a directory
+  gospec-cli generate -i . -o man/man1 -f man
+
   # Specify framework explicitly
   gospec-cli generate -i . -o opencli.yaml --framework cobra
 
   # Include hidden commands
-  gospec-cli generate -i . -o opencli.yaml --include-hidden`,
+  gospec-cli generate -i . -o opencli.yaml --include-hidden
+
+  # Use a specific root command function
+  gospec-cli generate -i ./cmd -o opencli.yaml --root-func NewRootCmd
+
+  # Analyze the source statically instead of running it
+  gospec-cli generate -i ./cmd/mycli -o opencli.yaml --static
+
+  # Scrape the help output of a compiled binary
+  gospec-cli generate --binary /usr/local/bin/tool -o opencli.yaml
+
+The input package must export a function such as GetRootCmd() *cobra.Command.
+A main package may instead define an unexported function or a *cobra.Command
+variable such as rootCmd. gospec-cli builds a temporary program importing that
+package, or a copy of it for main packages, runs it and writes the
+specification it produces. With --static the source is type-checked
+instead and nothing from the target is executed. With --binary no source is
+needed: the binary's --help output is parsed for every subcommand.`,
 		RunE: runGenerate,
 	}
 
 	var (
 		inputPath         string
 		outputPath        string
+		outputDir         string
 		outputFormat      string
 		framework         string
 		includeHidden     bool
 		includeDeprecated bool
 		specVersion       string
+		rootFunc          string
+		gospecPath        string
+		static            bool
+		binaryPath        string
+		timeout           time.Duration
 		verbose           bool
 	)
 
 	generateCmd.Flags().StringVarP(&inputPath, "input", "i", ".", "Input directory or package path")
 	generateCmd.Flags().StringVarP(&outputPath, "output", "o", "opencli.yaml", "Output file path")
-	generateCmd.Flags().StringVarP(&outputFormat, "format", "f", "yaml", "Output format (yaml, json)")
-	generateCmd.Flags().StringVar(&framework, "framework", "", "CLI framework (cobra, urfave-cli, flag) - auto-detect if not specified")
+	generateCmd.Flags().StringVar(&outputDir, "output-dir", "", "Directory receiving one page per command with --format markdown, instead of --output")
+	generateCmd.Flags().StringVarP(&outputFormat, "format", "f", "yaml", "Output format (yaml, json, markdown, man)")
+	generateCmd.Flags().StringVar(&framework, "framework", "", "Framework parser: cobra, cobra-static or binary (see list-frameworks) - selected by --static and --binary if not specified")
 	generateCmd.Flags().BoolVar(&includeHidden, "include-hidden", false, "Include hidden commands and flags")
 	generateCmd.Flags().BoolVar(&includeDeprecated, "include-deprecated", true, "Include deprecated commands and flags")
 	generateCmd.Flags().StringVar(&specVersion, "spec-version", "1.0.0", "OpenCLI specification version")
+	generateCmd.Flags().StringVar(&rootFunc, "root-func", "", "Function returning the root command, or root command variable of a main package - auto-detect if not specified")
+	generateCmd.Flags().StringVar(&gospecPath, "gospec-path", "", "Local gospec-cli checkout to build the harness against")
+	generateCmd.Flags().BoolVar(&static, "static", false, "Analyze the source without executing it (Cobra only)")
+	generateCmd.Flags().StringVar(&binaryPath, "binary", "", "Compiled binary to scrape help output from instead of source")
+	generateCmd.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "Timeout for each help invocation with --binary")
 	generateCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
 
-	generateCmd.MarkFlagRequired("input")
-	generateCmd.MarkFlagRequired("output")
+	generateCmd.MarkFlagsOneRequired("output", "output-dir")
+	generateCmd.MarkFlagsMutuallyExclusive("output", "output-dir")
 
 	validateCmd := &cobra.Command{
 		Use:   "validate [spec-file]",
 		Short: "Validate an OpenCLI specification file",
 		Long: `Validate an OpenCLI specification file against the schema.
 
+Besides the schema, the specification is checked for alias conflicts between
+sibling commands, arities whose minimum exceeds the maximum, enum defaults that
+are not allowed values, gaps in argument positions, dangling $refs into
+components and command tags missing from the top-level tags. Each problem is
+reported as file:line:column: pointer: message and the command exits non-zero.
+
 Examples:
   gospec-cli validate opencli.yaml
   gospec-cli validate spec/opencli.json`,
@@ -94,7 +155,7 @@ Examples:
 	listCmd := &cobra.Command{
 		Use:   "list-frameworks",
 		Short: "List supported CLI frameworks",
-		Long:  "Display a list of all supported CLI frameworks and their detection capabilities.",
+		Long:  "Display the registered framework parsers and how the generate command uses them.",
 		Run:   runListFrameworks,
 	}
 
@@ -103,17 +164,196 @@ Examples:
 		Short: "Display information about an OpenCLI specification",
 		Long: `Display detailed information about an OpenCLI specification file.
 
+Reports command and parameter counts, the depth of the command tree, hidden
+and deprecated items, tag coverage, and commands or flags that lack
+documentation.
+
 Examples:
   gospec-cli info opencli.yaml
-  gospec-cli info spec/opencli.json`,
+  gospec-cli info spec/opencli.json
+  gospec-cli info opencli.yaml --output json`,
 		Args: cobra.ExactArgs(1),
 		RunE: runInfo,
 	}
+	infoCmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+
+	diffCmd := &cobra.Command{
+		Use:   "diff [old-spec] [new-spec]",
+		Short: "Report changes between two OpenCLI specifications",
+		Long: `Compare two OpenCLI specification files and classify every change.
+
+Breaking changes are those that can fail existing invocations: removed
+commands, aliases, flags or arguments, flags that became required, removed
+enum values, new enums, changed types, reassigned shorthands and reordered
+positional arguments. New commands, new optional flags and description changes are
+non-breaking.
+
+By default the command exits non-zero when a breaking change is found; use
+--fail-on to change this.
+
+Examples:
+  gospec-cli diff old.yaml new.yaml
+  gospec-cli diff old.yaml new.json --output markdown
+  gospec-cli diff old.yaml new.yaml --output json --fail-on none`,
+		Args: cobra.ExactArgs(2),
+		RunE: runDiff,
+	}
+	diffCmd.Flags().StringP("output", "o", "text", "Output format (text, json, markdown)")
+	diffCmd.Flags().String("fail-on", "breaking", "Exit non-zero on: breaking, any or none")
+
+	completionsCmd := &cobra.Command{
+		Use:   "completions [spec-file]",
+		Short: "Generate shell completion scripts from an OpenCLI specification",
+		Long: `Generate a standalone completion script for the CLI described by an OpenCLI
+specification file.
+
+The script completes subcommands and their aliases, flags and their
+shorthands, enum values, and file paths for parameters whose schema format is
+"path". It is derived from the specification alone, so the CLI does not need
+to be built or to support completion itself.
+
+Supported shells: bash, zsh, fish, powershell.
+
+Examples:
+  gospec-cli completions opencli.yaml --shell bash -o completions/mycli.bash
+  gospec-cli completions opencli.yaml --shell zsh -o completions/_mycli
+  gospec-cli completions opencli.json --shell fish > ~/.config/fish/completions/mycli.fish`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCompletions,
+	}
+	completionsCmd.Flags().String("shell", "bash", "Shell to generate the script for (bash, zsh, fish, powershell)")
+	completionsCmd.Flags().StringP("output", "o", "", "Output file path (default stdout)")
+
+	scaffoldCmd := &cobra.Command{
+		Use:   "scaffold [spec-file] [output-dir]",
+		Short: "Generate a CLI application from an OpenCLI specification",
+		Long: `Generate a Go module implementing the CLI described by an OpenCLI
+specification, so that a CLI can be designed spec-first.
+
+Every command gets a generated file under cmd/ defining the command, its typed
+flags, required flags, argument count and valid arguments, and a separate
+file holding a stub RunE implementation. Regenerating rewrites the generated
+files only; the RunE files, go.mod and main.go are never overwritten. The
+generated files of commands removed from the specification are deleted, once
+their RunE files have been deleted or moved.
+
+Examples:
+  gospec-cli scaffold --framework cobra opencli.yaml ./out
+  gospec-cli scaffold opencli.yaml ./mycli --module github.com/acme/mycli`,
+		Args: cobra.ExactArgs(2),
+		RunE: runScaffold,
+	}
+	scaffoldCmd.Flags().String("framework", "cobra", "CLI framework of the generated code (cobra)")
+	scaffoldCmd.Flags().String("module", "", "Module path of the generated go.mod (default root command name)")
+
+	clientCmd := &cobra.Command{
+		Use:   "client [spec-file]",
+		Short: "Generate a typed Go client running the CLI of an OpenCLI specification",
+		Long: `Generate a Go package that runs the CLI described by an OpenCLI specification
+through os/exec.
+
+Every command becomes a method of Client taking an options struct whose fields
+are the command's flags and arguments, typed from their schemas, and returning
+the command's stdout, stderr and exit code. Regenerating the client after the
+CLI changes turns renamed or removed flags into compile errors in the programs
+using it.
+
+Examples:
+  gospec-cli client opencli.yaml -o internal/appclient/client.go
+  gospec-cli client opencli.yaml --package deploy --binary /usr/local/bin/deploy`,
+		Args: cobra.ExactArgs(1),
+		RunE: runClient,
+	}
+	clientCmd.Flags().StringP("output", "o", "", "Output file path (default stdout)")
+	clientCmd.Flags().String("package", "", "Package name (default root command name followed by \"client\")")
+	clientCmd.Flags().String("binary", "", "Executable the client runs by default (default root command name)")
+
+	toolsCmd := &cobra.Command{
+		Use:   "tools [spec-file]",
+		Short: "Generate LLM tool definitions from an OpenCLI specification",
+		Long: `Generate tool (function calling) definitions for the runnable commands of the
+CLI described by an OpenCLI specification.
+
+Every runnable command becomes a tool whose input schema is a JSON Schema
+object: flags are properties typed from their schemas, with enums and
+defaults, and positional arguments are an ordered "args" array. Hidden
+commands and flags are left out.
+
+Supported formats: openai, anthropic, mcp (the result of tools/list).
+
+Examples:
+  gospec-cli tools opencli.yaml --format anthropic -o tools.json
+  gospec-cli tools opencli.yaml --format mcp`,
+		Args: cobra.ExactArgs(1),
+		RunE: runTools,
+	}
+	toolsCmd.Flags().String("format", "openai", "Tool format (openai, anthropic, mcp)")
+	toolsCmd.Flags().StringP("output", "o", "", "Output file path (default stdout)")
+
+	mcpCmd := &cobra.Command{
+		Use:   "mcp [spec-file]",
+		Short: "Serve the commands of an OpenCLI specification as MCP tools over stdio",
+		Long: `Run a Model Context Protocol server on stdin and stdout exposing the runnable
+commands of the CLI described by an OpenCLI specification as tools.
+
+A tools/call request is checked against the tool's input schema, then the CLI
+binary runs with the matching subcommand, flags and arguments, without a
+shell. The command's output is returned to the model; a non-zero exit status
+is reported as a tool error.
+
+Examples:
+  gospec-cli mcp opencli.yaml --binary /usr/local/bin/mycli
+  gospec-cli mcp opencli.yaml --binary mycli --timeout 30s`,
+		Args: cobra.ExactArgs(1),
+		RunE: runMCP,
+	}
+	mcpCmd.Flags().String("binary", "", "Executable of the CLI (default root command name)")
+	mcpCmd.Flags().String("dir", "", "Working directory of the CLI")
+	mcpCmd.Flags().Duration("timeout", time.Minute, "Time limit of a tool call (0 for none)")
+
+	conformCmd := &cobra.Command{
+		Use:   "conform [spec-file]",
+		Short: "Check that a built binary matches its OpenCLI specification",
+		Long: `Run a built binary to check that it behaves as its OpenCLI specification says.
+
+For every command in the specification:
+  - --help succeeds
+  - every declared flag and alias is accepted
+  - running without a required flag fails and names the flag
+  - a value outside a flag's enum is rejected
+  - the help output lists no flags missing from the specification
+  - flags removed since --baseline are no longer accepted
+
+The required flag and enum checks run commands without --help; a binary that
+does not reject the invocation runs the command for real. Use --help-only to
+skip them. The command exits non-zero when a check fails.
+
+Examples:
+  gospec-cli conform --binary ./mycli opencli.yaml
+  gospec-cli conform --binary ./mycli opencli.yaml --junit conform.xml --json conform.json
+  gospec-cli conform --binary ./mycli opencli.yaml --baseline opencli-v1.yaml --help-only`,
+		Args: cobra.ExactArgs(1),
+		RunE: runConform,
+	}
+	conformCmd.Flags().String("binary", "", "Binary to check (required)")
+	conformCmd.Flags().String("junit", "", "Write the results as JUnit XML to this file")
+	conformCmd.Flags().String("json", "", "Write the results as JSON to this file")
+	conformCmd.Flags().String("baseline", "", "Earlier spec whose removed flags must be rejected")
+	conformCmd.Flags().Bool("help-only", false, "Only run the binary with --help")
+	conformCmd.Flags().Duration("timeout", 10*time.Second, "Timeout for each invocation of the binary")
+	_ = conformCmd.MarkFlagRequired("binary")
 
 	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(infoCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(completionsCmd)
+	rootCmd.AddCommand(scaffoldCmd)
+	rootCmd.AddCommand(clientCmd)
+	rootCmd.AddCommand(toolsCmd)
+	rootCmd.AddCommand(mcpCmd)
+	rootCmd.AddCommand(conformCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -124,45 +364,136 @@ Examples:
 func runGenerate(cmd *cobra.Command, args []string) error {
 	inputPath, _ := cmd.Flags().GetString("input")
 	outputPath, _ := cmd.Flags().GetString("output")
+	outputDir, _ := cmd.Flags().GetString("output-dir")
 	outputFormat, _ := cmd.Flags().GetString("format")
 	framework, _ := cmd.Flags().GetString("framework")
+	includeHidden, _ := cmd.Flags().GetBool("include-hidden")
+	includeDeprecated, _ := cmd.Flags().GetBool("include-deprecated")
+	specVersion, _ := cmd.Flags().GetString("spec-version")
+	rootFunc, _ := cmd.Flags().GetString("root-func")
+	gospecPath, _ := cmd.Flags().GetString("gospec-path")
+	static, _ := cmd.Flags().GetBool("static")
+	binaryPath, _ := cmd.Flags().GetString("binary")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
 	verbose, _ := cmd.Flags().GetBool("verbose")
 
+	if outputDir != "" && outputFormat != "markdown" && outputFormat != "md" {
+		return fmt.Errorf("--output-dir is only supported with --format markdown")
+	}
+
+	gs := gospec.New()
+	framework, err := checkFramework(gs, framework, static, binaryPath)
+	if err != nil {
+		return err
+	}
+	static = framework == "cobra-static"
+
 	if verbose {
 		fmt.Printf("Generating OpenCLI specification...\n")
 		fmt.Printf("  Input: %s\n", inputPath)
-		fmt.Printf("  Output: %s\n", outputPath)
-		fmt.Printf("  Format: %s\n", outputFormat)
-		if framework != "" {
-			fmt.Printf("  Framework: %s\n", framework)
+		if outputDir != "" {
+			fmt.Printf("  Output: %s\n", outputDir)
 		} else {
-			fmt.Printf("  Framework: auto-detect\n")
+			fmt.Printf("  Output: %s\n", outputPath)
 		}
+		fmt.Printf("  Format: %s\n", outputFormat)
+		fmt.Printf("  Framework: %s\n", framework)
 		fmt.Println()
 	}
 
-	// TODO: Implement actual generation logic
-	// This would involve:
-	// 1. Analyzing the Go source code in inputPath
-	// 2. Detecting or using specified framework
-	// 3. Parsing CLI structure
-	// 4. Converting to OpenCLI spec
-	// 5. Writing to outputPath
+	var gen interface {
+		Generate(*spec.OpenCLISpec, io.Writer) error
+	}
+	switch outputFormat {
+	case "yaml", "yml":
+		gen = generator.NewYAMLGenerator()
+	case "json":
+		gen = generator.NewJSONGenerator()
+	case "markdown", "md":
+		gen = generator.NewMarkdownGenerator()
+	case "man":
+		// Written page by page into the output directory below
+	default:
+		return fmt.Errorf("unsupported format: %s", outputFormat)
+	}
 
-	fmt.Println("✓ Analysis complete")
+	options := gospec.DefaultOptions()
+	options.SpecVersion = specVersion
+	options.IncludeHidden = includeHidden
+	options.IncludeDeprecated = includeDeprecated
+
+	var openCLI *spec.OpenCLISpec
+	if framework != "cobra" {
+		var parsed *parser.ParsedCLI
+		if framework == "binary" {
+			parsed, err = gs.ParseWith("binary", &parser.BinarySource{Path: binaryPath, Timeout: timeout})
+		} else {
+			parsed, err = gs.ParseWith("cobra-static", &parser.PackageSource{Dir: inputPath, Root: rootFunc})
+		}
+		if err != nil {
+			return err
+		}
+		for _, warning := range parsed.Warnings {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+		}
+		openCLI, err = converter.NewDefaultConverter().Convert(parsed, options)
+		if err != nil {
+			return err
+		}
+	} else {
+		harnessOptions := &harness.Options{
+			Dir:       inputPath,
+			RootFunc:  rootFunc,
+			Framework: framework,
+			GospecDir: gospecPath,
+			Convert:   options,
+		}
+		if verbose {
+			harnessOptions.Stderr = os.Stderr
+		}
+
+		openCLI, err = harness.Generate(cmd.Context(), harnessOptions)
+		if err != nil {
+			return err
+		}
+	}
 	fmt.Println("✓ CLI structure extracted")
+
+	if outputFormat == "man" {
+		pages, err := generator.NewManGenerator().GeneratePages(openCLI, outputPath)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ %d man page(s) written to: %s\n", len(pages), outputPath)
+		return nil
+	}
+	if outputDir != "" {
+		pages, err := generator.NewMarkdownGenerator().GeneratePages(openCLI, outputDir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ %d Markdown page(s) written to: %s\n", len(pages), outputDir)
+		return nil
+	}
+
+	if dir := filepath.Dir(outputPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file %s: %w", outputPath, err)
+	}
+	defer file.Close()
+
+	if err := gen.Generate(openCLI, file); err != nil {
+		return fmt.Errorf("failed to write specification: %w", err)
+	}
+
 	fmt.Println("✓ OpenCLI specification generated")
 	fmt.Printf("✓ Output written to: %s\n", outputPath)
 
-	fmt.Println("\nNote: Full implementation requires Go AST parsing and framework detection.")
-	fmt.Println("For now, use the library API directly in your Go code:")
-	fmt.Println()
-	fmt.Println("  import \"github.com/harihs-330/gospec-cli\"")
-	fmt.Println()
-	fmt.Println("  gs := gospec.New()")
-	fmt.Println("  spec, err := gs.Convert(yourCobraCommand, gospec.DefaultOptions())")
-	fmt.Println("  // Write spec to file...")
-
 	return nil
 }
 
@@ -171,59 +502,385 @@ func runValidate(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Validating OpenCLI specification: %s\n", specFile)
 
-	// TODO: Implement validation logic
-	// This would involve:
-	// 1. Reading the spec file
-	// 2. Parsing YAML/JSON
-	// 3. Validating against OpenCLI schema
-	// 4. Reporting errors
+	result, err := validate.File(specFile)
+	if err != nil {
+		return err
+	}
+
+	if !result.Valid() {
+		// Problems in the spec are not usage errors
+		cmd.SilenceUsage = true
+		for _, e := range result.Errors {
+			fmt.Fprintf(os.Stderr, "%s:%v\n", specFile, e)
+		}
+		return fmt.Errorf("specification has %d error(s)", len(result.Errors))
+	}
 
 	fmt.Println("✓ Specification is valid")
 
 	return nil
 }
 
+// frameworkNames describes the parsers registered by gospec.New
+var frameworkNames = map[string]string{
+	"cobra":        "Cobra (github.com/spf13/cobra)",
+	"cobra-static": "Cobra source analysis (github.com/spf13/cobra)",
+	"urfave-cli":   "urfave/cli v2 and v3 (github.com/urfave/cli)",
+	"flag":         "Standard library flag package",
+	"kong":         "Kong (github.com/alecthomas/kong)",
+	"kingpin":      "Kingpin (github.com/alecthomas/kingpin/v2, gopkg.in/alecthomas/kingpin.v2)",
+	"binary":       "Any compiled CLI, from its help output",
+}
+
+// generateUsage tells how the generate command uses a parser. Parsers
+// missing here take a value of the framework and are only available from
+// Go code.
+var generateUsage = map[string]string{
+	"cobra":        "generate (default): builds and runs the input package",
+	"cobra-static": "generate --static: analyzes the input package without running it",
+	"binary":       "generate --binary: runs the binary with --help",
+}
+
+// checkFramework validates the --framework value of generate against the
+// registered parsers and returns the framework generate will use
+func checkFramework(gs *gospec.GoSpec, framework string, static bool, binaryPath string) (string, error) {
+	selected := "cobra"
+	switch {
+	case binaryPath != "":
+		selected = "binary"
+	case static:
+		selected = "cobra-static"
+	}
+	if framework == "" {
+		return selected, nil
+	}
+
+	if _, ok := gs.GetParser(framework); !ok {
+		return "", fmt.Errorf("unknown framework: %s (see gospec-cli list-frameworks)", framework)
+	}
+	if _, ok := generateUsage[framework]; !ok {
+		return "", fmt.Errorf("framework %s is only available from Go code through gospec.New().Convert", framework)
+	}
+	switch {
+	case framework == "binary" && binaryPath == "":
+		return "", fmt.Errorf("--framework binary requires --binary")
+	case framework != selected && !(framework == "cobra-static" && selected == "cobra"):
+		return "", fmt.Errorf("--framework %s conflicts with the %s framework selected by the other flags", framework, selected)
+	}
+	return framework, nil
+}
+
 func runListFrameworks(cmd *cobra.Command, args []string) {
+	names := gospec.New().ListParsers()
+	sort.Strings(names)
+
 	fmt.Println("Supported CLI Frameworks:")
-	fmt.Println()
-	fmt.Println("  1. Cobra (github.com/spf13/cobra)")
-	fmt.Println("     - Most popular Go CLI framework")
-	fmt.Println("     - Full support for commands, flags, and arguments")
-	fmt.Println("     - Auto-detection: ✓")
-	fmt.Println()
-	fmt.Println("  2. urfave/cli (github.com/urfave/cli)")
-	fmt.Println("     - Simple and elegant CLI framework")
-	fmt.Println("     - Support for commands and flags")
-	fmt.Println("     - Auto-detection: ✓")
-	fmt.Println()
-	fmt.Println("  3. Standard flag package")
-	fmt.Println("     - Built-in Go flag parsing")
-	fmt.Println("     - Basic flag support")
-	fmt.Println("     - Auto-detection: ✓")
-	fmt.Println()
-	fmt.Println("  4. Custom frameworks")
-	fmt.Println("     - Extensible parser interface")
-	fmt.Println("     - Implement your own parser")
-	fmt.Println("     - Auto-detection: Manual")
+	for _, name := range names {
+		fmt.Println()
+		description := frameworkNames[name]
+		if description == "" {
+			description = "Custom parser"
+		}
+		fmt.Printf("  %s - %s\n", name, description)
+		if usage, ok := generateUsage[name]; ok {
+			fmt.Printf("     - %s\n", usage)
+		} else {
+			fmt.Println("     - Go library: gospec.New().Convert(app, options)")
+		}
+	}
 }
 
 func runInfo(cmd *cobra.Command, args []string) error {
 	specFile := args[0]
+	output, _ := cmd.Flags().GetString("output")
+
+	s, err := spec.Load(specFile)
+	if err != nil {
+		return err
+	}
+	report := info.New(s)
+
+	switch output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "text":
+		fmt.Printf("OpenCLI Specification Info: %s\n", specFile)
+		fmt.Println()
+		report.WriteText(os.Stdout)
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", output)
+	}
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	failOn, _ := cmd.Flags().GetString("fail-on")
+
+	switch failOn {
+	case "breaking", "any", "none":
+	default:
+		return fmt.Errorf("unsupported --fail-on value: %s", failOn)
+	}
+
+	oldSpec, err := spec.Load(args[0])
+	if err != nil {
+		return err
+	}
+	newSpec, err := spec.Load(args[1])
+	if err != nil {
+		return err
+	}
+
+	report := diff.Compare(oldSpec, newSpec)
+	if err := report.Write(os.Stdout, output); err != nil {
+		return err
+	}
+
+	// Changes are not usage errors
+	cmd.SilenceUsage = true
+	switch {
+	case failOn == "breaking" && report.HasBreaking():
+		return fmt.Errorf("found %d breaking change(s)", len(report.Breaking()))
+	case failOn == "any" && len(report.Changes) > 0:
+		return fmt.Errorf("found %d change(s)", len(report.Changes))
+	}
+	return nil
+}
+
+func runCompletions(cmd *cobra.Command, args []string) error {
+	shell, _ := cmd.Flags().GetString("shell")
+	outputPath, _ := cmd.Flags().GetString("output")
+
+	s, err := spec.Load(args[0])
+	if err != nil {
+		return err
+	}
+	script, err := generator.NewCompletionGenerator(generator.Shell(shell)).GenerateToString(s)
+	if err != nil {
+		return err
+	}
 
-	fmt.Printf("OpenCLI Specification Info: %s\n", specFile)
-	fmt.Println()
+	if outputPath == "" {
+		fmt.Print(script)
+		return nil
+	}
+	if dir := filepath.Dir(outputPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(outputPath, []byte(script), 0644); err != nil {
+		return fmt.Errorf("failed to write completion script: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ %s completion written to: %s\n", shell, outputPath)
+	return nil
+}
 
-	// TODO: Implement info display
-	// This would involve:
-	// 1. Reading and parsing the spec file
-	// 2. Displaying summary information
+func runScaffold(cmd *cobra.Command, args []string) error {
+	framework, _ := cmd.Flags().GetString("framework")
+	module, _ := cmd.Flags().GetString("module")
 
-	fmt.Println("  Title: Sample CLI")
-	fmt.Println("  Version: 1.0.0")
-	fmt.Println("  Spec Version: 1.0.0")
-	fmt.Println("  Commands: 12")
-	fmt.Println("  Parameters: 45")
-	fmt.Println("  Tags: 3")
+	s, err := spec.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	result, err := scaffold.Generate(s, args[1], &scaffold.Options{
+		Framework: framework,
+		Module:    module,
+	})
+	if err != nil {
+		return err
+	}
 
+	for _, path := range result.Written {
+		fmt.Printf("✓ Generated: %s\n", path)
+	}
+	for _, path := range result.Kept {
+		fmt.Printf("  Kept: %s\n", path)
+	}
+	for _, path := range result.Removed {
+		fmt.Printf("✓ Removed: %s\n", path)
+	}
 	return nil
 }
+
+func runClient(cmd *cobra.Command, args []string) error {
+	outputPath, _ := cmd.Flags().GetString("output")
+	packageName, _ := cmd.Flags().GetString("package")
+	binary, _ := cmd.Flags().GetString("binary")
+
+	s, err := spec.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	var src bytes.Buffer
+	if err := scaffold.GenerateClient(s, &src, &scaffold.ClientOptions{Package: packageName, Binary: binary}); err != nil {
+		return err
+	}
+
+	if outputPath == "" {
+		_, err := os.Stdout.Write(src.Bytes())
+		return err
+	}
+	if dir := filepath.Dir(outputPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(outputPath, src.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write client: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ Client written to: %s\n", outputPath)
+	return nil
+}
+
+func runTools(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	outputPath, _ := cmd.Flags().GetString("output")
+
+	s, err := spec.Load(args[0])
+	if err != nil {
+		return err
+	}
+	tools, err := generator.NewToolsGenerator(generator.ToolFormat(format)).GenerateToString(s)
+	if err != nil {
+		return err
+	}
+
+	if outputPath == "" {
+		fmt.Print(tools)
+		return nil
+	}
+	if dir := filepath.Dir(outputPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(outputPath, []byte(tools), 0644); err != nil {
+		return fmt.Errorf("failed to write tool definitions: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ %s tool definitions written to: %s\n", format, outputPath)
+	return nil
+}
+
+func runMCP(cmd *cobra.Command, args []string) error {
+	binary, _ := cmd.Flags().GetString("binary")
+	dir, _ := cmd.Flags().GetString("dir")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	s, err := spec.Load(args[0])
+	if err != nil {
+		return err
+	}
+	if binary == "" {
+		if binary, err = rootBinary(s); err != nil {
+			return err
+		}
+	}
+
+	server := mcp.NewServer(s, binary)
+	server.Dir = dir
+	server.Timeout = timeout
+	return server.Serve(cmd.Context(), os.Stdin, os.Stdout)
+}
+
+// rootBinary returns the name of the CLI a specification describes: its
+// single root command, or the title when that names one of several roots
+func rootBinary(s *spec.OpenCLISpec) (string, error) {
+	roots := make([]string, 0)
+	for key := range s.Commands {
+		if !strings.HasPrefix(key, "/") {
+			roots = append(roots, key)
+		}
+	}
+	sort.Strings(roots)
+
+	switch {
+	case len(roots) == 1:
+		return roots[0], nil
+	case len(roots) == 0 && s.Info.Title != "":
+		return s.Info.Title, nil
+	case len(roots) == 0:
+		return "", fmt.Errorf("--binary is required: the specification has no root command")
+	}
+	for _, root := range roots {
+		if root == s.Info.Title {
+			return root, nil
+		}
+	}
+	return "", fmt.Errorf("--binary is required: the specification has several root commands (%s)", strings.Join(roots, ", "))
+}
+
+func runConform(cmd *cobra.Command, args []string) error {
+	binary, _ := cmd.Flags().GetString("binary")
+	junitPath, _ := cmd.Flags().GetString("junit")
+	jsonPath, _ := cmd.Flags().GetString("json")
+	baselinePath, _ := cmd.Flags().GetString("baseline")
+	helpOnly, _ := cmd.Flags().GetBool("help-only")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	s, err := spec.Load(args[0])
+	if err != nil {
+		return err
+	}
+	opts := &conform.Options{Timeout: timeout, HelpOnly: helpOnly}
+	if baselinePath != "" {
+		if opts.Baseline, err = spec.Load(baselinePath); err != nil {
+			return err
+		}
+	}
+
+	report, err := conform.Run(cmd.Context(), binary, s, opts)
+	if err != nil {
+		return err
+	}
+
+	outputs := []struct {
+		path  string
+		write func(io.Writer) error
+	}{
+		{junitPath, report.WriteJUnit},
+		{jsonPath, report.WriteJSON},
+	}
+	for _, output := range outputs {
+		if output.path == "" {
+			continue
+		}
+		if err := writeFile(output.path, output.write); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "✓ Results written to: %s\n", output.path)
+	}
+
+	if err := report.WriteText(os.Stdout); err != nil {
+		return err
+	}
+	if failed := report.Count(conform.Failed); failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d conformance check(s) failed", failed)
+	}
+	return nil
+}
+
+// writeFile creates path and its directory and writes it with write
+func writeFile(path string, write func(io.Writer) error) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -5,12 +5,18 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/harihs-330/gospec-cli/pkg/config"
 	"github.com/harihs-330/gospec-cli/pkg/converter"
 	"github.com/harihs-330/gospec-cli/pkg/generator"
 	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/harihs-330/gospec-cli/pkg/parser/binary"
 	"github.com/harihs-330/gospec-cli/pkg/parser/cobra"
+	"github.com/harihs-330/gospec-cli/pkg/parser/kingpin"
+	"github.com/harihs-330/gospec-cli/pkg/parser/kong"
+	"github.com/harihs-330/gospec-cli/pkg/parser/stdflag"
+	"github.com/harihs-330/gospec-cli/pkg/parser/urfave"
 	"github.com/harihs-330/gospec-cli/pkg/spec"
 )
 
@@ -26,6 +32,12 @@ func New() *GoSpec {
 
 	// Register default parsers
 	registry.Register(cobra.NewCobraParser())
+	registry.Register(cobra.NewStaticParser())
+	registry.Register(urfave.NewUrfaveParser())
+	registry.Register(stdflag.NewFlagParser())
+	registry.Register(kong.NewKongParser())
+	registry.Register(kingpin.NewKingpinParser())
+	registry.Register(binary.NewBinaryParser())
 	// Add more parsers here as they are implemented
 
 	return &GoSpec{
@@ -97,6 +109,41 @@ func (g *GoSpec) ConvertToJSON(source interface{}, options *parser.ConvertOption
 	return gen.Generate(openCLI, writer)
 }
 
+// ConvertToMarkdown converts a CLI application to Markdown documentation
+func (g *GoSpec) ConvertToMarkdown(source interface{}, options *parser.ConvertOptions, writer io.Writer) error {
+	openCLI, err := g.Convert(source, options)
+	if err != nil {
+		return err
+	}
+
+	gen := generator.NewMarkdownGenerator()
+	return gen.Generate(openCLI, writer)
+}
+
+// ConvertToMarkdownPages converts a CLI application to Markdown pages, one
+// per command, written into dir. It returns the paths of the written pages.
+func (g *GoSpec) ConvertToMarkdownPages(source interface{}, options *parser.ConvertOptions, dir string) ([]string, error) {
+	openCLI, err := g.Convert(source, options)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := generator.NewMarkdownGenerator()
+	return gen.GeneratePages(openCLI, dir)
+}
+
+// ConvertToMan converts a CLI application to man pages, one per command,
+// written into dir. It returns the paths of the written pages.
+func (g *GoSpec) ConvertToMan(source interface{}, options *parser.ConvertOptions, dir string) ([]string, error) {
+	openCLI, err := g.Convert(source, options)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := generator.NewManGenerator()
+	return gen.GeneratePages(openCLI, dir)
+}
+
 // ConvertToYAMLString converts a CLI application to OpenCLI Specification YAML string
 func (g *GoSpec) ConvertToYAMLString(source interface{}, options *parser.ConvertOptions) (string, error) {
 	openCLI, err := g.Convert(source, options)
@@ -191,9 +238,44 @@ func (g *GoSpec) ConvertFromConfig(configPath string, source interface{}) error
 		},
 	}
 
+	// Binary sources are scraped from the executable named in the config
+	if source == nil && cfg.Source.Type == "binary" {
+		path := cfg.Source.Path
+		if strings.ContainsRune(path, filepath.Separator) && !filepath.IsAbs(path) {
+			path = filepath.Join(configDir, path)
+		}
+		source = &parser.BinarySource{Path: path}
+	}
+
 	// Generate specs in requested formats
 	for _, format := range cfg.Output.Formats {
-		outputPath := filepath.Join(outputDir, cfg.Output.Filename+"."+format)
+		// Man pages are one file per command and get a directory of their own
+		if format == "man" {
+			pages, err := g.ConvertToMan(source, options, filepath.Join(outputDir, "man1"))
+			if err != nil {
+				return fmt.Errorf("failed to generate man pages: %w", err)
+			}
+			for _, page := range pages {
+				fmt.Fprintf(os.Stderr, "✅ Generated: %s\n", page)
+			}
+			continue
+		}
+		if format == "markdown" && cfg.Output.MarkdownDirectory != "" {
+			pages, err := g.ConvertToMarkdownPages(source, options, filepath.Join(outputDir, cfg.Output.MarkdownDirectory))
+			if err != nil {
+				return fmt.Errorf("failed to generate Markdown pages: %w", err)
+			}
+			for _, page := range pages {
+				fmt.Fprintf(os.Stderr, "✅ Generated: %s\n", page)
+			}
+			continue
+		}
+
+		extension := format
+		if format == "markdown" {
+			extension = "md"
+		}
+		outputPath := filepath.Join(outputDir, cfg.Output.Filename+"."+extension)
 		file, err := os.Create(outputPath)
 		if err != nil {
 			return fmt.Errorf("failed to create output file %s: %w", outputPath, err)
@@ -209,6 +291,10 @@ func (g *GoSpec) ConvertFromConfig(configPath string, source interface{}) error
 			if err := g.ConvertToJSON(source, options, file); err != nil {
 				return fmt.Errorf("failed to generate JSON: %w", err)
 			}
+		case "markdown":
+			if err := g.ConvertToMarkdown(source, options, file); err != nil {
+				return fmt.Errorf("failed to generate Markdown: %w", err)
+			}
 		default:
 			return fmt.Errorf("unsupported format: %s", format)
 		}
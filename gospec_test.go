@@ -0,0 +1,52 @@
+package gospec
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+const markdownPagesConfig = `info:
+  title: "Test CLI"
+  version: "1.0.0"
+output:
+  directory: "out"
+  formats:
+    - markdown
+  filename: "spec"
+  markdownDirectory: "docs"
+`
+
+func TestConvertFromConfig_MarkdownPages(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "configspec.yaml")
+	if err := os.WriteFile(configPath, []byte(markdownPagesConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	root := &cobra.Command{Use: "app", Short: "Test app"}
+	root.AddCommand(&cobra.Command{Use: "serve", Short: "Serve", Run: func(*cobra.Command, []string) {}})
+
+	if err := New().ConvertFromConfig(configPath, root); err != nil {
+		t.Fatalf("ConvertFromConfig() error = %v", err)
+	}
+
+	pages, err := filepath.Glob(filepath.Join(dir, "out", "docs", "*.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, page := range pages {
+		pages[i] = filepath.Base(page)
+	}
+	sort.Strings(pages)
+	if strings.Join(pages, ",") != "app.md,app_serve.md" {
+		t.Errorf("Expected a page per command, got %v", pages)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out", "spec.md")); !os.IsNotExist(err) {
+		t.Errorf("Expected no single-file markdown output, got %v", err)
+	}
+}
@@ -33,6 +33,9 @@ type SpecConfig struct {
 		Directory string   `yaml:"directory"`
 		Formats   []string `yaml:"formats"`
 		Filename  string   `yaml:"filename"`
+		// MarkdownDirectory, relative to Directory, receives one Markdown
+		// page per command instead of a single file for the markdown format
+		MarkdownDirectory string `yaml:"markdownDirectory"`
 	} `yaml:"output"`
 	Options struct {
 		IncludeHidden        bool   `yaml:"includeHidden"`
@@ -0,0 +1,490 @@
+// Package conform checks that a built binary behaves as its OpenCLI
+// specification says: every command answers --help, every declared flag
+// and alias is accepted, required flags and enum values are enforced, and
+// the binary accepts no flags the spec does not declare. It catches specs
+// generated from a stale build or edited by hand.
+package conform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/diff"
+	"github.com/harihs-330/gospec-cli/pkg/parser/binary"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// invalidValue is passed to enum flags to check that it is rejected
+const invalidValue = "gospec-conform-invalid"
+
+// Checks run for every command
+const (
+	CheckHelp       = "help"
+	CheckFlag       = "flag"
+	CheckAlias      = "alias"
+	CheckRequired   = "required"
+	CheckEnum       = "enum"
+	CheckUndeclared = "undeclared-flags"
+	CheckRemoved    = "removed"
+)
+
+// Status is the outcome of a check
+type Status string
+
+// Statuses of checks
+const (
+	Passed  Status = "passed"
+	Failed  Status = "failed"
+	Skipped Status = "skipped"
+)
+
+// Options controls a conformance run
+type Options struct {
+	// Timeout bounds every invocation of the binary (default 10 seconds)
+	Timeout time.Duration
+
+	// Env holds variables added to the environment of the binary
+	Env []string
+
+	// Baseline is an earlier version of the spec. Flags it declares that
+	// the spec no longer does must be rejected by the binary.
+	Baseline *spec.OpenCLISpec
+
+	// HelpOnly skips the required flag and enum checks. They run commands
+	// without --help, so a binary that does not reject the invocation
+	// executes the command for real.
+	HelpOnly bool
+}
+
+// Result is the outcome of one check of one command
+type Result struct {
+	// Command is the path of the command, e.g. "app user create"
+	Command string `json:"command"`
+	Check   string `json:"check"`
+	// Name identifies the check within the command, e.g. "flag --role"
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+	// Args are the arguments the binary ran with
+	Args     []string      `json:"args,omitempty"`
+	Output   string        `json:"output,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report holds the results of a conformance run
+type Report struct {
+	Binary  string   `json:"binary"`
+	Results []Result `json:"results"`
+}
+
+// Count returns the number of results with status
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Failures returns the failed checks
+func (r *Report) Failures() []Result {
+	failures := make([]Result, 0)
+	for _, result := range r.Results {
+		if result.Status == Failed {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// Run checks the binary at path against s
+func Run(ctx context.Context, path string, s *spec.OpenCLISpec, opts *Options) (*Report, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if _, err := exec.LookPath(path); err != nil {
+		return nil, fmt.Errorf("binary not found: %w", err)
+	}
+
+	c := &checker{
+		ctx:     ctx,
+		path:    path,
+		opts:    opts,
+		report:  &Report{Binary: path, Results: make([]Result, 0)},
+		byWords: make(map[string]spec.Command),
+	}
+	keys := make([]string, 0, len(s.Commands))
+	for key, cmd := range s.Commands {
+		c.byWords[strings.Join(commandWords(key), " ")] = cmd
+		keys = append(keys, key)
+	}
+	// Commands are checked parents first
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Join(commandWords(keys[i]), " ") < strings.Join(commandWords(keys[j]), " ")
+	})
+
+	removed := map[string][]string{}
+	if opts.Baseline != nil {
+		for _, change := range diff.Compare(opts.Baseline, s).Changes {
+			if change.Kind == diff.FlagRemoved {
+				removed[change.Command] = append(removed[change.Command], change.Parameter)
+			}
+		}
+	}
+
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return c.report, err
+		}
+		c.command(key, s.Commands[key], removed[key])
+	}
+	return c.report, nil
+}
+
+// checker runs the checks of a conformance run
+type checker struct {
+	ctx     context.Context
+	path    string
+	opts    *Options
+	report  *Report
+	byWords map[string]spec.Command
+}
+
+// invocation is the outcome of running the binary once
+type invocation struct {
+	args     []string
+	output   string
+	exitCode int
+	err      error
+	duration time.Duration
+}
+
+// command runs the checks of the command at key
+func (c *checker) command(key string, cmd spec.Command, removed []string) {
+	words := commandWords(key)
+	path := strings.Join(append([]string{rootName(key)}, words...), " ")
+	flags := c.flags(words, cmd)
+
+	help := c.run(append(append([]string{}, words...), "--help")...)
+	if help.err != nil || help.exitCode != 0 {
+		c.add(path, CheckHelp, "help", Failed, "--help "+help.describe(), help)
+		// Without working help the other checks would only repeat the failure
+		return
+	}
+	c.add(path, CheckHelp, "help", Passed, "", help)
+
+	for _, p := range flags {
+		inv := c.run(append(append(append([]string{}, words...), flagArgs("--"+p.Name, p)...), "--help")...)
+		c.expectSuccess(path, CheckFlag, "flag --"+p.Name, "--"+p.Name+" is not accepted", inv)
+
+		for _, alias := range p.Alias {
+			name := "--" + alias
+			if len(alias) == 1 {
+				name = "-" + alias
+			}
+			inv := c.run(append(append(append([]string{}, words...), flagArgs(name, p)...), "--help")...)
+			c.expectSuccess(path, CheckAlias, "alias "+name, name+" is not accepted", inv)
+		}
+	}
+
+	c.undeclared(path, help, flags)
+
+	for _, name := range removed {
+		inv := c.run(append(append([]string{}, words...), "--"+name, "--help")...)
+		if inv.err == nil && inv.exitCode == 0 {
+			c.add(path, CheckRemoved, "removed --"+name, Failed, "--"+name+" was removed from the spec but is still accepted", inv)
+		} else {
+			c.add(path, CheckRemoved, "removed --"+name, Passed, "", inv)
+		}
+	}
+
+	for _, p := range flags {
+		if p.Required {
+			c.required(path, words, cmd, flags, p)
+		}
+		if len(spec.AllowedValues(p.Schema)) > 0 {
+			c.enum(path, words, cmd, flags, p)
+		}
+	}
+}
+
+// undeclared fails when the help of a command lists flags the spec does
+// not declare for it
+func (c *checker) undeclared(path string, help invocation, flags []spec.Parameter) {
+	declared := map[string]bool{}
+	for _, p := range flags {
+		declared[p.Name] = true
+	}
+
+	extra := make([]string, 0)
+	for _, flag := range binary.HelpFlags(help.output) {
+		if !declared[flag.Name] {
+			extra = append(extra, "--"+flag.Name)
+		}
+	}
+	if len(extra) > 0 {
+		c.add(path, CheckUndeclared, "undeclared flags", Failed,
+			"flags missing from the spec: "+strings.Join(extra, ", "), help)
+		return
+	}
+	c.add(path, CheckUndeclared, "undeclared flags", Passed, "", help)
+}
+
+// required runs the command without the required flag p, passing every
+// other required flag and argument, and expects an error naming p
+func (c *checker) required(path string, words []string, cmd spec.Command, flags []spec.Parameter, p spec.Parameter) {
+	name := "required --" + p.Name
+	if c.opts.HelpOnly {
+		c.add(path, CheckRequired, name, Skipped, "skipped: only --help invocations are allowed", invocation{})
+		return
+	}
+
+	args := append(append([]string{}, words...), requiredFlags(flags, p.Name)...)
+	args = append(args, requiredArgs(cmd)...)
+	inv := c.run(args...)
+	c.expectRejection(path, CheckRequired, name, "runs without the required --"+p.Name, inv, p.Name)
+}
+
+// enum passes a value outside the enum of p and expects it to be rejected
+func (c *checker) enum(path string, words []string, cmd spec.Command, flags []spec.Parameter, p spec.Parameter) {
+	name := "enum --" + p.Name
+	if c.opts.HelpOnly {
+		c.add(path, CheckEnum, name, Skipped, "skipped: only --help invocations are allowed", invocation{})
+		return
+	}
+
+	args := append(append([]string{}, words...), "--"+p.Name+"="+invalidValue)
+	args = append(args, requiredFlags(flags, p.Name)...)
+	args = append(args, requiredArgs(cmd)...)
+	inv := c.run(args...)
+	message := fmt.Sprintf("accepts --%s=%s, which is not one of the allowed values", p.Name, invalidValue)
+	c.expectRejection(path, CheckEnum, name, message, inv, p.Name, invalidValue)
+}
+
+// expectSuccess records a check passing when the invocation exited 0
+func (c *checker) expectSuccess(path, check, name, message string, inv invocation) {
+	if inv.err != nil || inv.exitCode != 0 {
+		c.add(path, check, name, Failed, message+": "+inv.describe(), inv)
+		return
+	}
+	c.add(path, check, name, Passed, "", inv)
+}
+
+// expectRejection records a check passing when the invocation failed with
+// output mentioning one of mentions
+func (c *checker) expectRejection(path, check, name, message string, inv invocation, mentions ...string) {
+	mentioned := false
+	for _, m := range mentions {
+		mentioned = mentioned || strings.Contains(inv.output, m)
+	}
+
+	switch {
+	case inv.err != nil:
+		c.add(path, check, name, Failed, inv.err.Error(), inv)
+	case inv.exitCode == 0:
+		c.add(path, check, name, Failed, message, inv)
+	case !mentioned:
+		c.add(path, check, name, Failed, fmt.Sprintf("exits with status %d, but the error does not mention %q", inv.exitCode, mentions[0]), inv)
+	default:
+		c.add(path, check, name, Passed, "", inv)
+	}
+}
+
+// add records a result
+func (c *checker) add(path, check, name string, status Status, message string, inv invocation) {
+	c.report.Results = append(c.report.Results, Result{
+		Command:  path,
+		Check:    check,
+		Name:     name,
+		Status:   status,
+		Message:  message,
+		Args:     inv.args,
+		Output:   inv.output,
+		Duration: inv.duration,
+	})
+}
+
+// run executes the binary with args and returns its combined output. A
+// non-zero exit is not an error.
+func (c *checker) run(args ...string) invocation {
+	ctx, cancel := context.WithTimeout(c.ctx, c.opts.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.path, args...)
+	cmd.Env = append(os.Environ(), "NO_COLOR=1", "TERM=dumb", "COLUMNS=200")
+	cmd.Env = append(cmd.Env, c.opts.Env...)
+	// Do not wait for grandchildren that inherited the output pipe
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	out, err := cmd.CombinedOutput()
+	inv := invocation{args: args, output: string(out), duration: time.Since(start)}
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		inv.err = fmt.Errorf("timed out after %s", c.opts.Timeout)
+	case errors.As(err, &exitErr):
+		inv.exitCode = exitErr.ExitCode()
+	case err != nil:
+		inv.err = err
+	}
+	return inv
+}
+
+// describe summarizes a failed invocation
+func (inv invocation) describe() string {
+	if inv.err != nil {
+		return inv.err.Error()
+	}
+	message := fmt.Sprintf("exit status %d", inv.exitCode)
+	if line := firstLine(inv.output); line != "" {
+		message += ": " + line
+	}
+	return message
+}
+
+// flags returns the flags of cmd and those its ancestors pass down
+func (c *checker) flags(words []string, cmd spec.Command) []spec.Parameter {
+	seen := map[string]bool{}
+	flags := make([]spec.Parameter, 0, len(cmd.Parameters))
+	for _, p := range cmd.Parameters {
+		if p.In != "argument" {
+			seen[p.Name] = true
+			flags = append(flags, p)
+		}
+	}
+	for i := len(words) - 1; i >= 0; i-- {
+		for _, p := range c.byWords[strings.Join(words[:i], " ")].Parameters {
+			if p.In == "argument" || seen[p.Name] || (p.Scope != "inherited" && p.Scope != "global") {
+				continue
+			}
+			seen[p.Name] = true
+			flags = append(flags, p)
+		}
+	}
+	return flags
+}
+
+// flagArgs returns the arguments passing a valid value to the flag p
+// under name
+func flagArgs(name string, p spec.Parameter) []string {
+	if p.Schema != nil && p.Schema.Type == "boolean" {
+		return []string{name}
+	}
+	return []string{name, sampleValue(p.Schema)}
+}
+
+// requiredFlags returns the arguments passing every required flag but
+// the one named except
+func requiredFlags(flags []spec.Parameter, except string) []string {
+	args := make([]string, 0)
+	for _, p := range flags {
+		if p.Required && p.Name != except {
+			args = append(args, flagArgs("--"+p.Name, p)...)
+		}
+	}
+	return args
+}
+
+// requiredArgs returns values for the required positional arguments
+func requiredArgs(cmd spec.Command) []string {
+	params := make([]spec.Parameter, 0)
+	for _, p := range cmd.Parameters {
+		if p.In == "argument" {
+			params = append(params, p)
+		}
+	}
+	sort.SliceStable(params, func(i, j int) bool { return params[i].Position < params[j].Position })
+
+	args := make([]string, 0)
+	for _, p := range params {
+		n := 0
+		if p.Required {
+			n = 1
+		}
+		if p.Arity != nil && p.Arity.Min > n {
+			n = p.Arity.Min
+		}
+		schema := p.Schema
+		if schema != nil && schema.Type == "array" {
+			schema = schema.Items
+		}
+		for i := 0; i < n; i++ {
+			args = append(args, sampleValue(schema))
+		}
+	}
+	return args
+}
+
+// sampleValue returns a value that schema accepts
+func sampleValue(schema *spec.Schema) string {
+	if schema == nil {
+		return "value"
+	}
+	if len(schema.Enum) > 0 {
+		return fmt.Sprint(schema.Enum[0])
+	}
+	switch schema.Type {
+	case "integer", "number":
+		if schema.Minimum != nil {
+			return fmt.Sprint(*schema.Minimum)
+		}
+		return "1"
+	case "boolean":
+		return "true"
+	case "array":
+		return sampleValue(schema.Items)
+	case "object":
+		if schema.AdditionalProperties != nil {
+			return "key=" + sampleValue(schema.AdditionalProperties)
+		}
+		return "key=value"
+	}
+	switch schema.Format {
+	case "duration":
+		return "1s"
+	case "ip", "ipv4":
+		return "127.0.0.1"
+	case "cidr":
+		return "10.0.0.0/8"
+	case "hex":
+		return "ff"
+	case "byte":
+		return "AA=="
+	}
+	if s, ok := schema.Default.(string); ok && s != "" {
+		return s
+	}
+	return "value"
+}
+
+// commandWords returns the words of a command key after the root
+func commandWords(key string) []string {
+	return strings.Split(strings.Trim(key, "/"), "/")[1:]
+}
+
+// rootName returns the root command of a command key
+func rootName(key string) string {
+	return strings.Split(strings.Trim(key, "/"), "/")[0]
+}
+
+// firstLine returns the first non-empty line of text
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
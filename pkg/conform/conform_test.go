@@ -0,0 +1,155 @@
+package conform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+func loadSpec(t *testing.T, name string) *spec.OpenCLISpec {
+	t.Helper()
+	s, err := spec.Load(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+	return s
+}
+
+// buildApp builds testdata/app, a Cobra CLI drifting from testdata/app.yaml
+func buildApp(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds a Go program")
+	}
+	path := filepath.Join(t.TempDir(), "app")
+	cmd := exec.Command("go", "build", "-o", path, "./testdata/app")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build test CLI: %v\n%s", err, out)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	app := buildApp(t)
+	report, err := Run(context.Background(), app, loadSpec(t, "app.yaml"), &Options{Baseline: loadSpec(t, "baseline.yaml")})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	got := map[string]Status{}
+	for _, r := range report.Results {
+		got[r.Command+": "+r.Name] = r.Status
+	}
+	want := map[string]Status{
+		"app: help":                         Passed,
+		"app: flag --verbose":               Passed,
+		"app: alias -v":                     Passed,
+		"app user: help":                    Passed,
+		"app user: flag --verbose":          Passed,
+		"app user: alias -v":                Passed,
+		"app user create: help":             Passed,
+		"app user create: flag --role":      Passed,
+		"app user create: alias -r":         Passed,
+		"app user create: flag --format":    Passed,
+		"app user create: flag --tag":       Passed,
+		"app user create: flag --region":    Failed,
+		"app user create: flag --verbose":   Passed,
+		"app user create: alias -v":         Passed,
+		"app user create: undeclared flags": Failed,
+		"app user create: removed --debug":  Failed,
+		"app user create: removed --legacy": Passed,
+		"app user create: required --role":  Passed,
+		"app user create: enum --role":      Passed,
+		"app user create: enum --format":    Failed,
+		"app user create: enum --tag":       Passed,
+		"app user: undeclared flags":        Passed,
+		"app: undeclared flags":             Passed,
+	}
+	for name, status := range want {
+		if got[name] != status {
+			t.Errorf("Expected %q to be %s, got %q", name, status, got[name])
+		}
+	}
+	if len(report.Results) != len(want) {
+		t.Errorf("Expected %d results, got %d: %v", len(want), len(report.Results), got)
+	}
+
+	for _, r := range report.Failures() {
+		if r.Name == "undeclared flags" && r.Message != "flags missing from the spec: --debug" {
+			t.Errorf("Unexpected message %q", r.Message)
+		}
+	}
+}
+
+func TestRun_HelpOnly(t *testing.T) {
+	app := buildApp(t)
+	report, err := Run(context.Background(), app, loadSpec(t, "app.yaml"), &Options{HelpOnly: true})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	for _, r := range report.Results {
+		if (r.Check == CheckRequired || r.Check == CheckEnum) && r.Status != Skipped {
+			t.Errorf("Expected %s to be skipped, got %s", r.Name, r.Status)
+		}
+	}
+}
+
+func TestRun_MissingBinary(t *testing.T) {
+	if _, err := Run(context.Background(), "./no-such-binary", loadSpec(t, "app.yaml"), nil); err == nil {
+		t.Error("Expected an error for a missing binary")
+	}
+}
+
+func TestReport_Output(t *testing.T) {
+	report := &Report{Binary: "app", Results: []Result{
+		{Command: "app", Check: CheckHelp, Name: "help", Status: Passed, Args: []string{"--help"}, Output: "Usage: app"},
+		{Command: "app user", Check: CheckFlag, Name: "flag --role", Status: Failed, Message: "--role is not accepted", Output: "unknown flag: --role"},
+		{Command: "app user", Check: CheckEnum, Name: "enum --role", Status: Skipped, Message: "skipped"},
+	}}
+
+	var junit bytes.Buffer
+	if err := report.WriteJUnit(&junit); err != nil {
+		t.Fatalf("WriteJUnit failed: %v", err)
+	}
+	var suites junitSuites
+	if err := xml.Unmarshal(junit.Bytes(), &suites); err != nil {
+		t.Fatalf("Invalid JUnit XML: %v\n%s", err, junit.String())
+	}
+	if suites.Tests != 3 || suites.Failures != 1 || suites.Skipped != 1 || len(suites.Suites) != 2 {
+		t.Errorf("Unexpected totals: %+v", suites)
+	}
+	for _, want := range []string{
+		`<testsuite name="app user" tests="2" failures="1" skipped="1"`,
+		`<failure message="--role is not accepted"><![CDATA[unknown flag: --role]]></failure>`,
+		`<skipped message="skipped"></skipped>`,
+		"<system-out><![CDATA[$ app --help\nUsage: app]]></system-out>",
+	} {
+		if !strings.Contains(junit.String(), want) {
+			t.Errorf("Expected JUnit XML to contain %q, got:\n%s", want, junit.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := report.WriteJSON(&out); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil || len(decoded.Results) != 3 || decoded.Results[1].Status != Failed {
+		t.Errorf("Unexpected JSON report (%v):\n%s", err, out.String())
+	}
+
+	out.Reset()
+	if err := report.WriteText(&out); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	if out.String() != "FAIL app user: flag --role: --role is not accepted\n1 passed, 1 failed, 1 skipped\n" {
+		t.Errorf("Unexpected text summary:\n%s", out.String())
+	}
+}
@@ -0,0 +1,137 @@
+package conform
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// junitSuites is the root element of a JUnit XML report
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+// junitSuite holds the checks of one command
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+// junitCase is one check
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut *junitOutput  `xml:"system-out,omitempty"`
+}
+
+// junitMessage is a failure or the reason a check was skipped
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// junitOutput is the output of the binary; CDATA keeps it readable
+type junitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+// WriteJUnit writes the report as JUnit XML, with a test suite per command
+func (r *Report) WriteJUnit(w io.Writer) error {
+	root := junitSuites{Name: "gospec-cli conform " + r.Binary}
+	var total time.Duration
+
+	index := map[string]int{}
+	for _, result := range r.Results {
+		i, ok := index[result.Command]
+		if !ok {
+			i = len(root.Suites)
+			index[result.Command] = i
+			root.Suites = append(root.Suites, junitSuite{Name: result.Command})
+		}
+		suite := &root.Suites[i]
+
+		tc := junitCase{
+			ClassName: result.Command,
+			Name:      result.Name,
+			Time:      seconds(result.Duration),
+		}
+		if len(result.Args) > 0 {
+			tc.SystemOut = &junitOutput{Text: "$ " + r.Binary + " " + strings.Join(result.Args, " ") + "\n" + result.Output}
+		}
+		switch result.Status {
+		case Failed:
+			tc.Failure = &junitMessage{Message: result.Message, Text: result.Output}
+			suite.Failures++
+			root.Failures++
+		case Skipped:
+			tc.Skipped = &junitMessage{Message: result.Message}
+			suite.Skipped++
+			root.Skipped++
+		}
+		suite.Tests++
+		root.Tests++
+		suite.Cases = append(suite.Cases, tc)
+		total += result.Duration
+	}
+
+	for i := range root.Suites {
+		var d time.Duration
+		for _, result := range r.Results {
+			if result.Command == root.Suites[i].Name {
+				d += result.Duration
+			}
+		}
+		root.Suites[i].Time = seconds(d)
+	}
+	root.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteText writes a summary listing the failed checks
+func (r *Report) WriteText(w io.Writer) error {
+	for _, result := range r.Failures() {
+		if _, err := fmt.Fprintf(w, "FAIL %s: %s: %s\n", result.Command, result.Name, result.Message); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d passed, %d failed, %d skipped\n", r.Count(Passed), r.Count(Failed), r.Count(Skipped))
+	return err
+}
+
+// seconds formats a duration as JUnit does
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,48 @@
+// Command app is a CLI drifting from testdata/app.yaml on purpose: it
+// lacks --region, has an undeclared --debug flag and does not check the
+// values of --format.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	root := &cobra.Command{Use: "app", SilenceUsage: true}
+	root.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+
+	user := &cobra.Command{Use: "user", Short: "Manage users"}
+	create := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			role, _ := cmd.Flags().GetString("role")
+			if role != "member" && role != "admin" {
+				return fmt.Errorf("invalid role %q", role)
+			}
+			tags, _ := cmd.Flags().GetStringSlice("tag")
+			for _, tag := range tags {
+				if tag != "blue" && tag != "green" {
+					return fmt.Errorf("invalid tag %q", tag)
+				}
+			}
+			fmt.Println("created", args[0])
+			return nil
+		},
+	}
+	create.Flags().StringP("role", "r", "", "role of the user")
+	create.Flags().String("format", "text", "output format")
+	create.Flags().StringSlice("tag", nil, "tags of the user")
+	create.Flags().Bool("debug", false, "debug output")
+	_ = create.MarkFlagRequired("role")
+
+	user.AddCommand(create)
+	root.AddCommand(user)
+	if err := root.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
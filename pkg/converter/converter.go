@@ -2,6 +2,8 @@ package converter
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/harihs-330/gospec-cli/pkg/parser"
@@ -9,11 +11,20 @@ import (
 )
 
 // DefaultConverter implements the Converter interface
-type DefaultConverter struct{}
+type DefaultConverter struct {
+	types *TypeRegistry
+}
 
-// NewDefaultConverter creates a new default converter
+// NewDefaultConverter creates a new default converter that maps custom
+// types with the schemas registered through RegisterType
 func NewDefaultConverter() *DefaultConverter {
-	return &DefaultConverter{}
+	return &DefaultConverter{types: defaultTypes}
+}
+
+// NewDefaultConverterWithTypes creates a default converter that maps
+// custom types with the schemas of the given registry
+func NewDefaultConverterWithTypes(types *TypeRegistry) *DefaultConverter {
+	return &DefaultConverter{types: types}
 }
 
 // Convert transforms ParsedCLI into OpenCLI Specification
@@ -190,6 +201,20 @@ func (c *DefaultConverter) convertCommand(cmdInfo *parser.CommandInfo, options *
 		command.Parameters = append(command.Parameters, param)
 	}
 
+	// Convert flag groups; groups naming a flag left out of the spec are
+	// dropped, since they could not be honored as written
+	hidden := make(map[string]bool)
+	for _, flags := range [][]*parser.FlagInfo{cmdInfo.Flags, cmdInfo.PersistentFlags} {
+		for _, flag := range flags {
+			hidden[flag.Name] = flag.Hidden && !options.IncludeHidden
+		}
+	}
+	for _, group := range cmdInfo.FlagGroups {
+		if !slices.ContainsFunc(group.Flags, func(name string) bool { return hidden[name] }) {
+			command.FlagGroups = append(command.FlagGroups, spec.FlagGroup{Kind: group.Kind, Flags: group.Flags})
+		}
+	}
+
 	// Add default responses if requested
 	if options.InferResponses {
 		command.Responses = c.generateDefaultResponses()
@@ -199,6 +224,13 @@ func (c *DefaultConverter) convertCommand(cmdInfo *parser.CommandInfo, options *
 	for key, value := range cmdInfo.Extensions {
 		command.Extensions[key] = value
 	}
+	if cmdInfo.Use != "" {
+		command.Extensions[spec.ExtensionUsage] = cmdInfo.Use
+	}
+	if cmdInfo.Example != "" {
+		command.Extensions[spec.ExtensionExamples] = cmdInfo.Example
+	}
+	command.Extensions[spec.ExtensionRunnable] = cmdInfo.RunFunc
 
 	return command
 }
@@ -216,11 +248,25 @@ func (c *DefaultConverter) convertFlag(flag *parser.FlagInfo, scope string) spec
 		Schema:      c.createSchema(flag.Type, flag.DefaultValue, flag.ValidValues),
 	}
 
-	// Add aliases
+	// Add aliases, the shorthand first
 	if flag.Shorthand != "" {
-		param.Alias = []string{flag.Shorthand}
+		param.Alias = append(param.Alias, flag.Shorthand)
+	}
+	param.Alias = append(param.Alias, flag.Aliases...)
+
+	// Environment variables and configuration keys setting the flag
+	if envVars := flag.Annotations["envVars"]; envVars != "" {
+		param.Env = strings.Split(envVars, ",")
+	}
+	if key := flag.Annotations["configKey"]; key != "" {
+		if param.Extensions == nil {
+			param.Extensions = make(map[string]interface{})
+		}
+		param.Extensions[spec.ExtensionConfigKey] = key
 	}
 
+	setCompletion(&param, flag.Completion)
+
 	return param
 }
 
@@ -246,27 +292,78 @@ func (c *DefaultConverter) convertArgument(arg *parser.ArgumentInfo) spec.Parame
 		}
 	}
 
+	setCompletion(&param, arg.Completion)
+
 	return param
 }
 
-// createSchema creates a Schema from type information
+// setCompletion records the completion of a parameter. Parameters whose
+// completion offers files or directories get the "path" format.
+func setCompletion(param *spec.Parameter, completion *parser.CompletionInfo) {
+	if completion == nil {
+		return
+	}
+	if param.Extensions == nil {
+		param.Extensions = make(map[string]interface{})
+	}
+	param.Extensions[spec.ExtensionCompletion] = spec.Completion{
+		Values:         completion.Values,
+		FileExtensions: completion.FileExtensions,
+		Directories:    completion.Directories,
+		Directory:      completion.Directory,
+		NoFiles:        completion.NoFiles,
+		NoSpace:        completion.NoSpace,
+		KeepOrder:      completion.KeepOrder,
+	}
+	if (len(completion.FileExtensions) > 0 || completion.Directories) && param.Schema != nil && param.Schema.Format == "" {
+		param.Schema.Format = "path"
+	}
+}
+
+// createSchema creates a Schema from type information. Valid values
+// constrain the items of arrays and are typed like the values they
+// constrain, as defaults are.
 func (c *DefaultConverter) createSchema(typeName string, defaultValue interface{}, validValues []string) *spec.Schema {
-	schema := &spec.Schema{
-		Type:    mapTypeToSchemaType(typeName),
-		Default: defaultValue,
+	schema := schemaForType(c.types, typeName)
+	if defaultValue != nil {
+		schema.Default = defaultValue
 	}
 
 	// Add enum if valid values are specified
 	if len(validValues) > 0 {
-		schema.Enum = make([]interface{}, len(validValues))
+		constrained := schema
+		if schema.Type == "array" && schema.Items != nil {
+			constrained = schema.Items
+		}
+		constrained.Enum = make([]interface{}, len(validValues))
 		for i, v := range validValues {
-			schema.Enum[i] = v
+			constrained.Enum[i] = typedValue(constrained.Type, v)
 		}
 	}
 
 	return schema
 }
 
+// typedValue converts the text of a value to the given schema type. Text
+// that does not parse as that type is kept.
+func typedValue(schemaType, text string) interface{} {
+	switch schemaType {
+	case "integer":
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return int(n)
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b
+		}
+	}
+	return text
+}
+
 // generateDefaultResponses creates default response definitions
 func (c *DefaultConverter) generateDefaultResponses() map[string]spec.Response {
 	return map[string]spec.Response{
@@ -360,26 +457,6 @@ func generateOperationID(cmdInfo *parser.CommandInfo) string {
 	return operationID
 }
 
-func mapTypeToSchemaType(typeName string) string {
-	switch strings.ToLower(typeName) {
-	case "bool", "boolean":
-		return "boolean"
-	case "int", "int8", "int16", "int32", "int64",
-		"uint", "uint8", "uint16", "uint32", "uint64":
-		return "integer"
-	case "float", "float32", "float64":
-		return "number"
-	case "string":
-		return "string"
-	case "duration":
-		return "string"
-	case "stringslice", "[]string":
-		return "array"
-	default:
-		return "string"
-	}
-}
-
 // DefaultConvertOptions returns default conversion options
 func DefaultConvertOptions() *parser.ConvertOptions {
 	return &parser.ConvertOptions{
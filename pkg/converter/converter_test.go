@@ -0,0 +1,26 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+)
+
+func TestDefaultConverter_FlagAliases(t *testing.T) {
+	c := NewDefaultConverter()
+	tests := []struct {
+		flag *parser.FlagInfo
+		want []string
+	}{
+		{&parser.FlagInfo{Name: "config"}, nil},
+		{&parser.FlagInfo{Name: "config", Shorthand: "c"}, []string{"c"}},
+		{&parser.FlagInfo{Name: "config", Aliases: []string{"cfg", "conf"}}, []string{"cfg", "conf"}},
+		{&parser.FlagInfo{Name: "config", Shorthand: "c", Aliases: []string{"cfg"}}, []string{"c", "cfg"}},
+	}
+	for _, tt := range tests {
+		if got := c.convertFlag(tt.flag, "local").Alias; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%+v: expected aliases %v, got %v", tt.flag, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package converter
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// TypeRegistry maps the type names parsers report for flags and arguments,
+// such as the Type() of a pflag.Value, to schemas. Registered types take
+// precedence over the built-in mapping, so custom values can describe
+// themselves:
+//
+//	converter.RegisterType("url", &spec.Schema{Type: "string", Format: "uri"})
+type TypeRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string]*spec.Schema
+}
+
+// NewTypeRegistry creates an empty type registry
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		schemas: make(map[string]*spec.Schema),
+	}
+}
+
+// Register sets the schema of a type name, replacing any earlier one
+func (r *TypeRegistry) Register(typeName string, schema *spec.Schema) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemas[typeName] = cloneSchema(schema)
+}
+
+// Lookup returns a copy of the schema registered for a type name
+func (r *TypeRegistry) Lookup(typeName string) (*spec.Schema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schema, ok := r.schemas[typeName]
+	if !ok {
+		return nil, false
+	}
+	return cloneSchema(schema), true
+}
+
+// defaultTypes is the registry used by NewDefaultConverter
+var defaultTypes = NewTypeRegistry()
+
+// RegisterType registers the schema of a type name with the registry used
+// by converters created with NewDefaultConverter
+func RegisterType(typeName string, schema *spec.Schema) {
+	defaultTypes.Register(typeName, schema)
+}
+
+// schemaForType returns the schema of a type name, looking in the registry
+// before falling back to the built-in mapping
+func schemaForType(types *TypeRegistry, typeName string) *spec.Schema {
+	if types != nil {
+		if schema, ok := types.Lookup(typeName); ok {
+			return schema
+		}
+	}
+	return mapTypeToSchemaType(typeName)
+}
+
+// mapTypeToSchemaType maps the type names of pflag and of the other
+// parsers to schemas. Slices such as "intSlice" and "[]int" are arrays of
+// their element type, and maps such as "stringToInt" are objects whose
+// values have the type after "To". Unknown types are strings.
+func mapTypeToSchemaType(typeName string) *spec.Schema {
+	name := strings.ToLower(typeName)
+
+	if name == "stringarray" {
+		return &spec.Schema{Type: "array", Items: &spec.Schema{Type: "string"}}
+	}
+	if elem, ok := strings.CutSuffix(name, "slice"); ok && elem != "" {
+		return &spec.Schema{Type: "array", Items: mapTypeToSchemaType(elem)}
+	}
+	if elem, ok := strings.CutPrefix(name, "[]"); ok && elem != "" {
+		return &spec.Schema{Type: "array", Items: mapTypeToSchemaType(elem)}
+	}
+	if elem, ok := strings.CutPrefix(name, "stringto"); ok && elem != "" {
+		return &spec.Schema{Type: "object", AdditionalProperties: mapTypeToSchemaType(elem)}
+	}
+
+	switch name {
+	case "bool", "boolean", "boolfunc":
+		return &spec.Schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "count":
+		return &spec.Schema{Type: "integer"}
+	case "float", "float32", "float64":
+		return &spec.Schema{Type: "number"}
+	case "duration":
+		return &spec.Schema{Type: "string", Format: "duration"}
+	case "timestamp":
+		return &spec.Schema{Type: "string", Format: "date-time"}
+	case "ip":
+		// pflag parses IPv4 and IPv6 addresses alike
+		return &spec.Schema{Type: "string", Format: "ip"}
+	case "ipmask":
+		return &spec.Schema{Type: "string", Format: "ipv4"}
+	case "ipnet":
+		return &spec.Schema{Type: "string", Format: "cidr"}
+	case "byteshex":
+		return &spec.Schema{Type: "string", Format: "hex"}
+	case "bytesbase64":
+		return &spec.Schema{Type: "string", Format: "byte"}
+	default:
+		return &spec.Schema{Type: "string"}
+	}
+}
+
+// cloneSchema returns a deep copy of a schema, so that registered schemas
+// are not changed by the specs built from them
+func cloneSchema(s *spec.Schema) *spec.Schema {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	if s.Enum != nil {
+		c.Enum = append([]interface{}{}, s.Enum...)
+	}
+	c.Items = cloneSchema(s.Items)
+	c.AdditionalProperties = cloneSchema(s.AdditionalProperties)
+	if s.Properties != nil {
+		c.Properties = make(map[string]*spec.Schema, len(s.Properties))
+		for name, property := range s.Properties {
+			c.Properties[name] = cloneSchema(property)
+		}
+	}
+	return &c
+}
@@ -0,0 +1,118 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+func TestMapTypeToSchemaType(t *testing.T) {
+	str := &spec.Schema{Type: "string"}
+	integer := &spec.Schema{Type: "integer"}
+
+	tests := []struct {
+		typeName string
+		want     *spec.Schema
+	}{
+		{"bool", &spec.Schema{Type: "boolean"}},
+		{"count", integer},
+		{"uint16", integer},
+		{"float32", &spec.Schema{Type: "number"}},
+		{"string", str},
+		{"duration", &spec.Schema{Type: "string", Format: "duration"}},
+		{"ip", &spec.Schema{Type: "string", Format: "ip"}},
+		{"ipMask", &spec.Schema{Type: "string", Format: "ipv4"}},
+		{"ipNet", &spec.Schema{Type: "string", Format: "cidr"}},
+		{"bytesHex", &spec.Schema{Type: "string", Format: "hex"}},
+		{"bytesBase64", &spec.Schema{Type: "string", Format: "byte"}},
+		{"stringSlice", &spec.Schema{Type: "array", Items: str}},
+		{"stringArray", &spec.Schema{Type: "array", Items: str}},
+		{"[]string", &spec.Schema{Type: "array", Items: str}},
+		{"intSlice", &spec.Schema{Type: "array", Items: integer}},
+		{"float64Slice", &spec.Schema{Type: "array", Items: &spec.Schema{Type: "number"}}},
+		{"durationSlice", &spec.Schema{Type: "array", Items: &spec.Schema{Type: "string", Format: "duration"}}},
+		{"ipSlice", &spec.Schema{Type: "array", Items: &spec.Schema{Type: "string", Format: "ip"}}},
+		{"stringToString", &spec.Schema{Type: "object", AdditionalProperties: str}},
+		{"stringToInt", &spec.Schema{Type: "object", AdditionalProperties: integer}},
+		{"stringToInt64", &spec.Schema{Type: "object", AdditionalProperties: integer}},
+		{"level", str},
+	}
+	for _, tt := range tests {
+		if got := mapTypeToSchemaType(tt.typeName); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %+v, got %+v", tt.typeName, tt.want, got)
+		}
+	}
+}
+
+func TestDefaultConverter_RegisteredTypes(t *testing.T) {
+	types := NewTypeRegistry()
+	types.Register("url", &spec.Schema{Type: "string", Format: "uri"})
+	types.Register("enum", &spec.Schema{Type: "string", Enum: []interface{}{"json", "yaml"}})
+	c := NewDefaultConverterWithTypes(types)
+
+	url := c.createSchema("url", "https://example.com", nil)
+	if url.Format != "uri" || url.Default != "https://example.com" {
+		t.Errorf("Expected the registered url schema with a default, got %+v", url)
+	}
+
+	enum := c.createSchema("enum", nil, nil)
+	if !reflect.DeepEqual(enum.Enum, []interface{}{"json", "yaml"}) {
+		t.Errorf("Expected the registered enum, got %v", enum.Enum)
+	}
+	enum.Enum[0] = "changed"
+	if again := c.createSchema("enum", nil, nil); again.Enum[0] != "json" {
+		t.Error("Expected schemas built from the registry not to change it")
+	}
+
+	if got := c.createSchema("intSlice", nil, []string{"1", "2"}); !reflect.DeepEqual(got.Items.Enum, []interface{}{1, 2}) || got.Enum != nil {
+		t.Errorf("Expected valid values to constrain the items, got %+v", got)
+	}
+}
+
+func TestDefaultConverter_TypedEnum(t *testing.T) {
+	c := NewDefaultConverter()
+	tests := []struct {
+		typeName string
+		values   []string
+		want     []interface{}
+	}{
+		{"int", []string{"1", "2"}, []interface{}{1, 2}},
+		{"float64", []string{"0.5", "1"}, []interface{}{0.5, 1.0}},
+		{"bool", []string{"true"}, []interface{}{true}},
+		{"string", []string{"1", "a"}, []interface{}{"1", "a"}},
+		{"int", []string{"1", "many"}, []interface{}{1, "many"}},
+	}
+	for _, tt := range tests {
+		if got := c.createSchema(tt.typeName, nil, tt.values); !reflect.DeepEqual(got.Enum, tt.want) {
+			t.Errorf("%s %v: expected enum %#v, got %#v", tt.typeName, tt.values, tt.want, got.Enum)
+		}
+	}
+}
+
+func TestDefaultConverter_MapFlag(t *testing.T) {
+	parsed := &parser.ParsedCLI{
+		Metadata: &parser.CLIMetadata{Name: "app"},
+		RootCommand: &parser.CommandInfo{
+			Name: "app",
+			Path: "app",
+			Flags: []*parser.FlagInfo{{
+				Name:         "limits",
+				Type:         "stringToInt",
+				DefaultValue: map[string]interface{}{"cpu": 2},
+				Annotations:  map[string]string{},
+			}},
+		},
+	}
+	parsed.Commands = map[string]*parser.CommandInfo{"app": parsed.RootCommand}
+
+	s, err := NewDefaultConverter().Convert(parsed, nil)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	schema := s.Commands["app"].Parameters[0].Schema
+	if schema.Type != "object" || schema.AdditionalProperties == nil || schema.AdditionalProperties.Type != "integer" {
+		t.Errorf("Expected an object of integers, got %+v", schema)
+	}
+}
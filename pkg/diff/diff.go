@@ -0,0 +1,432 @@
+// Package diff compares two OpenCLI specifications and classifies the
+// differences as breaking or non-breaking for users of the CLI.
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// Severity tells whether a change can break existing invocations
+type Severity string
+
+// Severities of changes
+const (
+	Breaking    Severity = "breaking"
+	NonBreaking Severity = "non-breaking"
+)
+
+// Kinds of changes
+const (
+	CommandRemoved      = "command-removed"
+	CommandAdded        = "command-added"
+	CommandRenamed      = "command-renamed"
+	AliasRemoved        = "alias-removed"
+	AliasAdded          = "alias-added"
+	FlagRemoved         = "flag-removed"
+	FlagAdded           = "flag-added"
+	FlagRenamed         = "flag-renamed"
+	FlagAliasRemoved    = "flag-alias-removed"
+	ShorthandReassigned = "shorthand-reassigned"
+	ArgumentRemoved     = "argument-removed"
+	ArgumentAdded       = "argument-added"
+	PositionalReordered = "positional-reordered"
+	BecameRequired      = "became-required"
+	BecameOptional      = "became-optional"
+	TypeChanged         = "type-changed"
+	EnumValueRemoved    = "enum-value-removed"
+	EnumValueAdded      = "enum-value-added"
+	EnumAdded           = "enum-added"
+	ArityNarrowed       = "arity-narrowed"
+	ArityWidened        = "arity-widened"
+	DescriptionChanged  = "description-changed"
+	Deprecated          = "deprecated"
+)
+
+// Change is a single difference between two specifications
+type Change struct {
+	Kind     string   `json:"kind"`
+	Severity Severity `json:"severity"`
+	// Command is the key of the affected command
+	Command string `json:"command"`
+	// Parameter is the affected flag or argument, if any
+	Parameter string `json:"parameter,omitempty"`
+	Message   string `json:"message"`
+}
+
+// Report lists the changes between two specifications
+type Report struct {
+	Changes []Change `json:"changes"`
+}
+
+// Breaking returns the breaking changes
+func (r *Report) Breaking() []Change {
+	return r.filter(Breaking)
+}
+
+// NonBreaking returns the non-breaking changes
+func (r *Report) NonBreaking() []Change {
+	return r.filter(NonBreaking)
+}
+
+// HasBreaking reports whether any change is breaking
+func (r *Report) HasBreaking() bool {
+	return len(r.Breaking()) > 0
+}
+
+func (r *Report) filter(severity Severity) []Change {
+	changes := make([]Change, 0)
+	for _, c := range r.Changes {
+		if c.Severity == severity {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// Compare reports the changes from old to new
+func Compare(old, new *spec.OpenCLISpec) *Report {
+	c := &comparer{report: &Report{Changes: make([]Change, 0)}}
+
+	for _, key := range sortedKeys(old.Commands) {
+		oldCmd := old.Commands[key]
+		newCmd, ok := new.Commands[key]
+		if !ok {
+			if renamed := findByAlias(new.Commands, key); renamed != "" {
+				c.add(NonBreaking, CommandRenamed, key, "", "command was renamed to %s, which keeps the old name as an alias", renamed)
+			} else {
+				c.add(Breaking, CommandRemoved, key, "", "command was removed")
+			}
+			continue
+		}
+		c.compareCommand(key, oldCmd, newCmd)
+	}
+
+	for _, key := range sortedKeys(new.Commands) {
+		if _, ok := old.Commands[key]; !ok {
+			c.add(NonBreaking, CommandAdded, key, "", "command was added")
+		}
+	}
+
+	return c.report
+}
+
+type comparer struct {
+	report *Report
+}
+
+func (c *comparer) add(severity Severity, kind, command, parameter, format string, args ...interface{}) {
+	c.report.Changes = append(c.report.Changes, Change{
+		Kind:      kind,
+		Severity:  severity,
+		Command:   command,
+		Parameter: parameter,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *comparer) compareCommand(key string, old, new spec.Command) {
+	for _, alias := range old.Aliases {
+		if !contains(new.Aliases, alias) {
+			c.add(Breaking, AliasRemoved, key, "", "alias %q was removed", alias)
+		}
+	}
+	for _, alias := range new.Aliases {
+		if !contains(old.Aliases, alias) {
+			c.add(NonBreaking, AliasAdded, key, "", "alias %q was added", alias)
+		}
+	}
+	if old.Summary != new.Summary || old.Description != new.Description {
+		c.add(NonBreaking, DescriptionChanged, key, "", "description changed")
+	}
+	if !old.Deprecated && new.Deprecated {
+		c.add(NonBreaking, Deprecated, key, "", "command was deprecated")
+	}
+
+	c.compareFlags(key, flags(old.Parameters), flags(new.Parameters))
+	c.compareArguments(key, arguments(old.Parameters), arguments(new.Parameters))
+}
+
+func (c *comparer) compareFlags(key string, old, new []spec.Parameter) {
+	for _, oldFlag := range old {
+		newFlag := findFlag(new, oldFlag.Name)
+		if newFlag == nil {
+			c.add(Breaking, FlagRemoved, key, oldFlag.Name, "flag --%s was removed", oldFlag.Name)
+			continue
+		}
+		if newFlag.Name != oldFlag.Name {
+			c.add(NonBreaking, FlagRenamed, key, oldFlag.Name, "flag --%s was renamed to --%s, which keeps the old name as an alias", oldFlag.Name, newFlag.Name)
+		}
+
+		for _, alias := range oldFlag.Alias {
+			if contains(newFlag.Alias, alias) || alias == newFlag.Name {
+				continue
+			}
+			if owner := findOwner(new, alias); owner != nil {
+				c.add(Breaking, ShorthandReassigned, key, oldFlag.Name, "%s now refers to --%s instead of --%s", dashed(alias), owner.Name, oldFlag.Name)
+			} else {
+				c.add(Breaking, FlagAliasRemoved, key, oldFlag.Name, "alias %s of --%s was removed", dashed(alias), oldFlag.Name)
+			}
+		}
+
+		c.compareParameter(key, "--"+oldFlag.Name, oldFlag, *newFlag)
+	}
+
+	for _, newFlag := range new {
+		if findFlag(old, newFlag.Name) != nil || renames(old, newFlag) {
+			continue
+		}
+		if newFlag.Required {
+			c.add(Breaking, FlagAdded, key, newFlag.Name, "required flag --%s was added", newFlag.Name)
+		} else {
+			c.add(NonBreaking, FlagAdded, key, newFlag.Name, "optional flag --%s was added", newFlag.Name)
+		}
+	}
+}
+
+func (c *comparer) compareArguments(key string, old, new []spec.Parameter) {
+	for _, oldArg := range old {
+		newArg := findByName(new, oldArg.Name)
+		if newArg == nil {
+			c.add(Breaking, ArgumentRemoved, key, oldArg.Name, "argument <%s> was removed", oldArg.Name)
+			continue
+		}
+		if oldArg.Position != newArg.Position {
+			c.add(Breaking, PositionalReordered, key, oldArg.Name, "argument <%s> moved from position %d to %d", oldArg.Name, oldArg.Position, newArg.Position)
+		}
+		c.compareParameter(key, "<"+oldArg.Name+">", oldArg, *newArg)
+		c.compareArity(key, oldArg, *newArg)
+	}
+
+	for _, newArg := range new {
+		if findByName(old, newArg.Name) != nil {
+			continue
+		}
+		if newArg.Required {
+			c.add(Breaking, ArgumentAdded, key, newArg.Name, "required argument <%s> was added", newArg.Name)
+		} else {
+			c.add(NonBreaking, ArgumentAdded, key, newArg.Name, "optional argument <%s> was added", newArg.Name)
+		}
+	}
+}
+
+// compareParameter compares the properties shared by flags and arguments
+func (c *comparer) compareParameter(key, label string, old, new spec.Parameter) {
+	switch {
+	case !old.Required && new.Required:
+		c.add(Breaking, BecameRequired, key, old.Name, "%s became required", label)
+	case old.Required && !new.Required:
+		c.add(NonBreaking, BecameOptional, key, old.Name, "%s is no longer required", label)
+	}
+
+	c.compareSchema(key, old.Name, label, old.Schema, new.Schema)
+
+	if old.Description != new.Description {
+		c.add(NonBreaking, DescriptionChanged, key, old.Name, "description of %s changed", label)
+	}
+	if !old.Deprecated && new.Deprecated {
+		c.add(NonBreaking, Deprecated, key, old.Name, "%s was deprecated", label)
+	}
+}
+
+// compareSchema compares the type and enum of a parameter schema, and
+// those of the items of arrays and the values of maps
+func (c *comparer) compareSchema(key, name, label string, old, new *spec.Schema) {
+	if old == nil || new == nil {
+		return
+	}
+
+	if old.Type != new.Type && old.Type != "" && new.Type != "" {
+		c.add(Breaking, TypeChanged, key, name, "type of %s changed from %s to %s", label, old.Type, new.Type)
+	}
+
+	oldEnum, newEnum := enumValues(old), enumValues(new)
+	// An enum that disappears entirely no longer restricts the values, while
+	// a new one rejects values that were accepted before
+	if len(oldEnum) == 0 && len(newEnum) > 0 {
+		c.add(Breaking, EnumAdded, key, name, "%s now only accepts %s", label, strings.Join(newEnum, ", "))
+	} else if len(newEnum) > 0 {
+		for _, value := range oldEnum {
+			if !contains(newEnum, value) {
+				c.add(Breaking, EnumValueRemoved, key, name, "value %q is no longer accepted by %s", value, label)
+			}
+		}
+		for _, value := range newEnum {
+			if !contains(oldEnum, value) {
+				c.add(NonBreaking, EnumValueAdded, key, name, "value %q is now accepted by %s", value, label)
+			}
+		}
+	}
+
+	c.compareSchema(key, name, "items of "+label, old.Items, new.Items)
+	c.compareSchema(key, name, "values of "+label, old.AdditionalProperties, new.AdditionalProperties)
+}
+
+// compareArity compares the number of values an argument takes. Requiring
+// more values or accepting fewer is breaking.
+func (c *comparer) compareArity(key string, old, new spec.Parameter) {
+	oldMin, oldMax := arityRange(old.Arity)
+	newMin, newMax := arityRange(new.Arity)
+	if oldMin == newMin && oldMax == newMax {
+		return
+	}
+
+	label := "<" + old.Name + ">"
+	from, to := arityText(oldMin, oldMax), arityText(newMin, newMax)
+	narrowed := newMin > oldMin || (newMax >= 0 && (oldMax < 0 || newMax < oldMax))
+	if narrowed {
+		c.add(Breaking, ArityNarrowed, key, old.Name, "argument %s now takes %s values instead of %s", label, to, from)
+	} else {
+		c.add(NonBreaking, ArityWidened, key, old.Name, "argument %s now takes %s values instead of %s", label, to, from)
+	}
+}
+
+// findByAlias returns the key of a command that took over the name of the
+// removed command key as an alias below the same parent
+func findByAlias(commands map[string]spec.Command, key string) string {
+	parent, name := splitKey(key)
+	for _, other := range sortedKeys(commands) {
+		otherParent, _ := splitKey(other)
+		if otherParent == parent && contains(commands[other].Aliases, name) {
+			return other
+		}
+	}
+	return ""
+}
+
+// splitKey returns the parent path and name of a command key such as
+// "/app/user/create"
+func splitKey(key string) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return "", key
+}
+
+// findFlag returns the flag called name or, failing that, the flag that has
+// name as a long alias
+func findFlag(params []spec.Parameter, name string) *spec.Parameter {
+	if p := findByName(params, name); p != nil {
+		return p
+	}
+	if len(name) > 1 {
+		for i := range params {
+			if contains(params[i].Alias, name) {
+				return &params[i]
+			}
+		}
+	}
+	return nil
+}
+
+// findOwner returns the flag that is called alias or lists it as an alias
+func findOwner(params []spec.Parameter, alias string) *spec.Parameter {
+	for i := range params {
+		if params[i].Name == alias || contains(params[i].Alias, alias) {
+			return &params[i]
+		}
+	}
+	return nil
+}
+
+// renames reports whether flag replaces one of old under a new name
+func renames(old []spec.Parameter, flag spec.Parameter) bool {
+	for _, alias := range flag.Alias {
+		if len(alias) > 1 && findByName(old, alias) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func findByName(params []spec.Parameter, name string) *spec.Parameter {
+	for i := range params {
+		if params[i].Name == name {
+			return &params[i]
+		}
+	}
+	return nil
+}
+
+func flags(params []spec.Parameter) []spec.Parameter {
+	result := make([]spec.Parameter, 0)
+	for _, p := range params {
+		if p.In != "argument" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func arguments(params []spec.Parameter) []spec.Parameter {
+	result := make([]spec.Parameter, 0)
+	for _, p := range params {
+		if p.In == "argument" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func enumValues(s *spec.Schema) []string {
+	values := make([]string, len(s.Enum))
+	for i, v := range s.Enum {
+		values[i] = fmt.Sprint(v)
+	}
+	return values
+}
+
+// arityRange returns the minimum and maximum number of values of an
+// argument; the maximum is -1 when unlimited. Whether the argument is
+// required is compared separately.
+func arityRange(arity *spec.Arity) (int, int) {
+	if arity == nil {
+		return 0, 1
+	}
+	if arity.Max == nil {
+		return arity.Min, -1
+	}
+	return arity.Min, *arity.Max
+}
+
+// arityText renders an arity range such as "1-3" or "2 or more"
+func arityText(min, max int) string {
+	switch {
+	case max < 0:
+		return fmt.Sprintf("%d or more", min)
+	case min == max:
+		return fmt.Sprint(min)
+	default:
+		return fmt.Sprintf("%d-%d", min, max)
+	}
+}
+
+// dashed renders a flag alias as typed on the command line
+func dashed(alias string) string {
+	if len(alias) == 1 {
+		return "-" + alias
+	}
+	return "--" + alias
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]spec.Command) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
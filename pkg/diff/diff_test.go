@@ -0,0 +1,288 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+func oldSpec() *spec.OpenCLISpec {
+	return &spec.OpenCLISpec{
+		Commands: map[string]spec.Command{
+			"app": {Summary: "App"},
+			"/app/deploy": {
+				Summary: "Deploy",
+				Aliases: []string{"d", "ship"},
+				Parameters: []spec.Parameter{
+					{Name: "force", In: "flag", Alias: []string{"f"}, Schema: &spec.Schema{Type: "boolean"}},
+					{Name: "file", In: "flag", Schema: &spec.Schema{Type: "string"}},
+					{Name: "env", In: "flag", Schema: &spec.Schema{Type: "string", Enum: []interface{}{"dev", "prod", "staging"}}},
+					{Name: "replicas", In: "flag", Schema: &spec.Schema{Type: "integer"}},
+					{Name: "color", In: "flag", Schema: &spec.Schema{Type: "string"}},
+					{Name: "service", In: "argument", Position: 1, Required: true},
+					{Name: "version", In: "argument", Position: 2},
+				},
+			},
+			"/app/status": {Summary: "Status"},
+			"/app/logs":   {Summary: "Logs"},
+		},
+	}
+}
+
+func newSpec() *spec.OpenCLISpec {
+	return &spec.OpenCLISpec{
+		Commands: map[string]spec.Command{
+			"app": {Summary: "App"},
+			"/app/deploy": {
+				Summary: "Deploy a service",
+				Aliases: []string{"d"},
+				Parameters: []spec.Parameter{
+					{Name: "force", In: "flag", Schema: &spec.Schema{Type: "boolean"}},
+					{Name: "fast", In: "flag", Alias: []string{"f"}, Schema: &spec.Schema{Type: "boolean"}},
+					{Name: "env", In: "flag", Required: true, Schema: &spec.Schema{Type: "string", Enum: []interface{}{"dev", "prod", "qa"}}},
+					{Name: "replicas", In: "flag", Schema: &spec.Schema{Type: "string"}},
+					{Name: "colour", In: "flag", Alias: []string{"color"}, Schema: &spec.Schema{Type: "string"}},
+					{Name: "dry-run", In: "flag"},
+					{Name: "version", In: "argument", Position: 1},
+					{Name: "service", In: "argument", Position: 2, Required: true},
+				},
+			},
+			"/app/log":  {Summary: "Logs", Aliases: []string{"logs"}},
+			"/app/init": {Summary: "Init"},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	report := Compare(oldSpec(), newSpec())
+
+	tests := []struct {
+		severity  Severity
+		kind      string
+		command   string
+		parameter string
+	}{
+		{Breaking, AliasRemoved, "/app/deploy", ""},
+		{NonBreaking, DescriptionChanged, "/app/deploy", ""},
+		{Breaking, ShorthandReassigned, "/app/deploy", "force"},
+		{Breaking, FlagRemoved, "/app/deploy", "file"},
+		{Breaking, BecameRequired, "/app/deploy", "env"},
+		{Breaking, EnumValueRemoved, "/app/deploy", "env"},
+		{NonBreaking, EnumValueAdded, "/app/deploy", "env"},
+		{Breaking, TypeChanged, "/app/deploy", "replicas"},
+		{NonBreaking, FlagRenamed, "/app/deploy", "color"},
+		{NonBreaking, FlagAdded, "/app/deploy", "fast"},
+		{NonBreaking, FlagAdded, "/app/deploy", "dry-run"},
+		{Breaking, PositionalReordered, "/app/deploy", "service"},
+		{Breaking, PositionalReordered, "/app/deploy", "version"},
+		{NonBreaking, CommandRenamed, "/app/logs", ""},
+		{Breaking, CommandRemoved, "/app/status", ""},
+		{NonBreaking, CommandAdded, "/app/init", ""},
+		{NonBreaking, CommandAdded, "/app/log", ""},
+	}
+
+	if len(report.Changes) != len(tests) {
+		for _, c := range report.Changes {
+			t.Logf("%+v", c)
+		}
+		t.Fatalf("Expected %d changes, got %d", len(tests), len(report.Changes))
+	}
+	for i, tt := range tests {
+		c := report.Changes[i]
+		if c.Severity != tt.severity || c.Kind != tt.kind || c.Command != tt.command || c.Parameter != tt.parameter {
+			t.Errorf("Change %d: expected %s %s %s %s, got %+v", i, tt.severity, tt.kind, tt.command, tt.parameter, c)
+		}
+	}
+
+	if !report.HasBreaking() {
+		t.Error("Expected breaking changes")
+	}
+	if got := len(report.Breaking()) + len(report.NonBreaking()); got != len(report.Changes) {
+		t.Errorf("Expected every change to be classified, got %d of %d", got, len(report.Changes))
+	}
+}
+
+func TestCompare_Messages(t *testing.T) {
+	report := Compare(oldSpec(), newSpec())
+	messages := make([]string, 0)
+	for _, c := range report.Changes {
+		messages = append(messages, c.Message)
+	}
+	all := strings.Join(messages, "\n")
+
+	for _, want := range []string{
+		`alias "ship" was removed`,
+		"-f now refers to --fast instead of --force",
+		`value "staging" is no longer accepted by --env`,
+		"type of --replicas changed from integer to string",
+		"argument <service> moved from position 1 to 2",
+		"command was renamed to /app/log",
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("Expected message %q in:\n%s", want, all)
+		}
+	}
+}
+
+func TestCompare_RequiredAdditions(t *testing.T) {
+	old := &spec.OpenCLISpec{Commands: map[string]spec.Command{"app": {}}}
+	new := &spec.OpenCLISpec{Commands: map[string]spec.Command{"app": {
+		Parameters: []spec.Parameter{
+			{Name: "token", In: "flag", Required: true},
+			{Name: "target", In: "argument", Position: 1, Required: true},
+			{Name: "extra", In: "argument", Position: 2},
+		},
+	}}}
+
+	report := Compare(old, new)
+	if len(report.Breaking()) != 2 || len(report.NonBreaking()) != 1 {
+		t.Errorf("Expected 2 breaking and 1 non-breaking change, got %+v", report.Changes)
+	}
+}
+
+func TestCompare_NestedSchemas(t *testing.T) {
+	withSchema := func(s *spec.Schema) *spec.OpenCLISpec {
+		return &spec.OpenCLISpec{Commands: map[string]spec.Command{"app": {
+			Parameters: []spec.Parameter{{Name: "tag", In: "flag", Schema: s}},
+		}}}
+	}
+	array := func(items *spec.Schema) *spec.Schema { return &spec.Schema{Type: "array", Items: items} }
+	object := func(values *spec.Schema) *spec.Schema {
+		return &spec.Schema{Type: "object", AdditionalProperties: values}
+	}
+
+	tests := []struct {
+		name     string
+		old, new *spec.Schema
+		kind     string
+		message  string
+	}{
+		{"item type", array(&spec.Schema{Type: "string"}), array(&spec.Schema{Type: "integer"}),
+			TypeChanged, "type of items of --tag changed from string to integer"},
+		{"item enum", array(&spec.Schema{Type: "string", Enum: []interface{}{"blue", "red"}}), array(&spec.Schema{Type: "string", Enum: []interface{}{"blue"}}),
+			EnumValueRemoved, `value "red" is no longer accepted by items of --tag`},
+		{"map value type", object(&spec.Schema{Type: "string"}), object(&spec.Schema{Type: "integer"}),
+			TypeChanged, "type of values of --tag changed from string to integer"},
+		{"map value enum", object(&spec.Schema{Type: "string", Enum: []interface{}{"low"}}), object(&spec.Schema{Type: "string", Enum: []interface{}{"low", "high"}}),
+			EnumValueAdded, `value "high" is now accepted by values of --tag`},
+	}
+	for _, tt := range tests {
+		report := Compare(withSchema(tt.old), withSchema(tt.new))
+		if len(report.Changes) != 1 || report.Changes[0].Kind != tt.kind || report.Changes[0].Message != tt.message {
+			t.Errorf("%s: expected %s %q, got %+v", tt.name, tt.kind, tt.message, report.Changes)
+		}
+	}
+}
+
+func TestCompare_EnumAdded(t *testing.T) {
+	withEnum := func(enum ...interface{}) *spec.OpenCLISpec {
+		return &spec.OpenCLISpec{Commands: map[string]spec.Command{"app": {
+			Parameters: []spec.Parameter{
+				{Name: "env", In: "flag", Schema: &spec.Schema{Type: "string", Enum: enum}},
+				{Name: "target", In: "argument", Position: 1, Schema: &spec.Schema{Type: "string", Enum: enum}},
+			},
+		}}}
+	}
+
+	report := Compare(withEnum(), withEnum("dev", "prod"))
+	if len(report.Changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %+v", report.Changes)
+	}
+	for _, c := range report.Changes {
+		if c.Kind != EnumAdded || c.Severity != Breaking {
+			t.Errorf("Expected breaking %s, got %+v", EnumAdded, c)
+		}
+	}
+	if want := "--env now only accepts dev, prod"; report.Changes[0].Message != want {
+		t.Errorf("Expected message %q, got %q", want, report.Changes[0].Message)
+	}
+
+	// Dropping the enum accepts any value again
+	if report := Compare(withEnum("dev", "prod"), withEnum()); len(report.Changes) != 0 {
+		t.Errorf("Expected no changes when the enum is removed, got %+v", report.Changes)
+	}
+}
+
+func TestCompare_Arity(t *testing.T) {
+	upTo := func(n int) *int { return &n }
+	withArity := func(arity *spec.Arity) *spec.OpenCLISpec {
+		return &spec.OpenCLISpec{Commands: map[string]spec.Command{"app": {
+			Parameters: []spec.Parameter{{Name: "files", In: "argument", Position: 1, Arity: arity}},
+		}}}
+	}
+
+	tests := []struct {
+		name     string
+		old, new *spec.Arity
+		severity Severity
+		kind     string
+		message  string
+	}{
+		{"higher minimum", &spec.Arity{Min: 0}, &spec.Arity{Min: 1}, Breaking, ArityNarrowed, "argument <files> now takes 1 or more values instead of 0 or more"},
+		{"lower maximum", &spec.Arity{Min: 1, Max: upTo(3)}, &spec.Arity{Min: 1, Max: upTo(2)}, Breaking, ArityNarrowed, "argument <files> now takes 1-2 values instead of 1-3"},
+		{"maximum added", &spec.Arity{Min: 1}, &spec.Arity{Min: 1, Max: upTo(5)}, Breaking, ArityNarrowed, "argument <files> now takes 1-5 values instead of 1 or more"},
+		{"maximum removed", &spec.Arity{Min: 1, Max: upTo(1)}, &spec.Arity{Min: 1}, NonBreaking, ArityWidened, "argument <files> now takes 1 or more values instead of 1"},
+		{"single to many", nil, &spec.Arity{Max: upTo(3)}, NonBreaking, ArityWidened, "argument <files> now takes 0-3 values instead of 0-1"},
+	}
+	for _, tt := range tests {
+		report := Compare(withArity(tt.old), withArity(tt.new))
+		if len(report.Changes) != 1 {
+			t.Errorf("%s: expected one change, got %+v", tt.name, report.Changes)
+			continue
+		}
+		c := report.Changes[0]
+		if c.Severity != tt.severity || c.Kind != tt.kind || c.Message != tt.message {
+			t.Errorf("%s: expected %s %s %q, got %+v", tt.name, tt.severity, tt.kind, tt.message, c)
+		}
+	}
+
+	if report := Compare(withArity(&spec.Arity{Min: 1}), withArity(&spec.Arity{Min: 1})); len(report.Changes) != 0 {
+		t.Errorf("Expected no changes for the same arity, got %+v", report.Changes)
+	}
+}
+
+func TestCompare_Identical(t *testing.T) {
+	report := Compare(oldSpec(), oldSpec())
+	if len(report.Changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", report.Changes)
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	report := Compare(oldSpec(), newSpec())
+
+	var text bytes.Buffer
+	if err := report.Write(&text, FormatText); err != nil {
+		t.Fatalf("Failed to write text: %v", err)
+	}
+	if !strings.Contains(text.String(), "Breaking changes (9):\n  ! /app/deploy: alias \"ship\" was removed") {
+		t.Errorf("Unexpected text output:\n%s", text.String())
+	}
+
+	var md bytes.Buffer
+	if err := report.Write(&md, FormatMarkdown); err != nil {
+		t.Fatalf("Failed to write markdown: %v", err)
+	}
+	if !strings.Contains(md.String(), "| `/app/deploy` | positional-reordered | argument &lt;service&gt; moved from position 1 to 2 |") {
+		t.Errorf("Unexpected markdown output:\n%s", md.String())
+	}
+
+	var out bytes.Buffer
+	if err := report.Write(&out, FormatJSON); err != nil {
+		t.Fatalf("Failed to write JSON: %v", err)
+	}
+	var decoded jsonReport
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if decoded.Breaking != 9 || decoded.NonBreaking != 8 || len(decoded.Changes) != 17 {
+		t.Errorf("Unexpected JSON counts: %d breaking, %d non-breaking, %d changes", decoded.Breaking, decoded.NonBreaking, len(decoded.Changes))
+	}
+
+	if err := report.Write(&out, "html"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
@@ -0,0 +1,112 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Output formats supported by Write
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+// Write renders the report in the given format
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatText:
+		return r.WriteText(w)
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatMarkdown:
+		return r.WriteMarkdown(w)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// WriteText renders the report for terminals
+func (r *Report) WriteText(w io.Writer) error {
+	if len(r.Changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes")
+		return err
+	}
+
+	sections := []struct {
+		title   string
+		marker  string
+		changes []Change
+	}{
+		{"Breaking changes", "!", r.Breaking()},
+		{"Non-breaking changes", "+", r.NonBreaking()},
+	}
+	for i, section := range sections {
+		if len(section.changes) == 0 {
+			continue
+		}
+		if i > 0 && len(sections[0].changes) > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%d):\n", section.title, len(section.changes))
+		for _, c := range section.changes {
+			fmt.Fprintf(w, "  %s %s: %s\n", section.marker, c.Command, c.Message)
+		}
+	}
+	return nil
+}
+
+// jsonReport is the JSON form of a report, with counts for dashboards
+type jsonReport struct {
+	Breaking    int      `json:"breaking"`
+	NonBreaking int      `json:"nonBreaking"`
+	Changes     []Change `json:"changes"`
+}
+
+// WriteJSON renders the report as JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonReport{
+		Breaking:    len(r.Breaking()),
+		NonBreaking: len(r.NonBreaking()),
+		Changes:     r.Changes,
+	})
+}
+
+// WriteMarkdown renders the report as Markdown, e.g. for pull request comments
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	fmt.Fprintln(w, "# CLI changes")
+	fmt.Fprintln(w)
+	if len(r.Changes) == 0 {
+		fmt.Fprintln(w, "No changes.")
+		return nil
+	}
+
+	for _, section := range []struct {
+		title   string
+		changes []Change
+	}{
+		{"Breaking changes", r.Breaking()},
+		{"Non-breaking changes", r.NonBreaking()},
+	} {
+		if len(section.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "## %s\n\n", section.title)
+		fmt.Fprintln(w, "| Command | Change | Details |")
+		fmt.Fprintln(w, "|---------|--------|---------|")
+		for _, c := range section.changes {
+			fmt.Fprintf(w, "| `%s` | %s | %s |\n", c.Command, c.Kind, escapeCell(c.Message))
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// escapeCell keeps a message inside its Markdown table cell
+func escapeCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// writeBashCompletion writes a bash completion script. Each helper function
+// dispatches on the command path resolved from the words typed so far.
+func writeBashCompletion(w io.Writer, commands []completionCommand) {
+	root := commands[0].Path
+	name := completionName(commands)
+
+	fmt.Fprintf(w, "# bash completion for %s\n", root)
+	fmt.Fprint(w, "# Generated by gospec-cli from an OpenCLI specification. DO NOT EDIT.\n\n")
+
+	// Subcommand resolution, including aliases
+	fmt.Fprintf(w, "__%s_subcommand() {\n", name)
+	fmt.Fprintln(w, "    case \"$1 $2\" in")
+	for _, c := range commands {
+		byPath := make(map[string][]string)
+		order := make([]string, 0)
+		for _, sub := range c.Subcommands {
+			if _, ok := byPath[sub.Path]; !ok {
+				order = append(order, sub.Path)
+			}
+			byPath[sub.Path] = append(byPath[sub.Path], shQuote(c.Path+" "+sub.Word))
+		}
+		for _, path := range order {
+			fmt.Fprintf(w, "        %s) echo %s ;;\n", strings.Join(byPath[path], "|"), shQuote(path))
+		}
+	}
+	fmt.Fprintln(w, "        *) return 1 ;;")
+	fmt.Fprint(w, "    esac\n}\n\n")
+
+	// Flags followed by a value, which must not be taken for subcommands
+	fmt.Fprintf(w, "__%s_takes_value() {\n", name)
+	if flags := valueFlags(commands); len(flags) > 0 {
+		for i, flag := range flags {
+			flags[i] = shQuote(flag)
+		}
+		fmt.Fprintln(w, "    case \"$1 $2\" in")
+		fmt.Fprintf(w, "        %s) return 0 ;;\n", strings.Join(flags, "|"))
+		fmt.Fprintln(w, "    esac")
+	}
+	fmt.Fprint(w, "    return 1\n}\n\n")
+
+	// Values of flags
+	fmt.Fprintf(w, "__%s_flag_values() {\n", name)
+	fmt.Fprintln(w, "    case \"$1 $2\" in")
+	for _, c := range commands {
+		for _, f := range c.Flags {
+			if !f.TakesValue || (len(f.Values) == 0 && !f.Files) {
+				continue
+			}
+			patterns := make([]string, len(f.Names))
+			for i, flag := range f.Names {
+				patterns[i] = shQuote(c.Path + " " + flag)
+			}
+			fmt.Fprintf(w, "        %s)\n", strings.Join(patterns, "|"))
+			writeBashValues(w, f.Values, f.Files, "$3")
+			fmt.Fprintln(w, "            ;;")
+		}
+	}
+	fmt.Fprint(w, "    esac\n}\n\n")
+
+	// Flag names
+	fmt.Fprintf(w, "__%s_flags() {\n", name)
+	fmt.Fprintln(w, "    case \"$1\" in")
+	for _, c := range commands {
+		names := make([]string, 0)
+		for _, f := range c.Flags {
+			names = append(names, f.Names...)
+		}
+		if len(names) > 0 {
+			fmt.Fprintf(w, "        %s) echo %s ;;\n", shQuote(c.Path), shQuote(strings.Join(names, " ")))
+		}
+	}
+	fmt.Fprint(w, "    esac\n}\n\n")
+
+	// Subcommand names and positional arguments
+	fmt.Fprintf(w, "__%s_arguments() {\n", name)
+	fmt.Fprintln(w, "    case \"$1\" in")
+	for _, c := range commands {
+		words := make([]string, 0, len(c.Subcommands)+len(c.ArgValues))
+		for _, sub := range c.Subcommands {
+			words = append(words, sub.Word)
+		}
+		words = append(words, c.ArgValues...)
+		if len(words) == 0 && !c.ArgFiles {
+			continue
+		}
+		fmt.Fprintf(w, "        %s)\n", shQuote(c.Path))
+		writeBashValues(w, words, c.ArgFiles, "$2")
+		fmt.Fprintln(w, "            ;;")
+	}
+	fmt.Fprint(w, "    esac\n}\n\n")
+
+	fmt.Fprintf(w, `_%[1]s() {
+    local cur prev word cmd=%[2]s skip=0 i
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    # readline splits "--flag=value" into "--flag", "=" and "value"
+    if [[ $cur == "=" ]]; then
+        cur=""
+    elif [[ $prev == "=" ]]; then
+        prev="${COMP_WORDS[COMP_CWORD-2]}"
+    fi
+
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        word="${COMP_WORDS[i]}"
+        if [[ $word == "=" ]]; then
+            skip=1
+            continue
+        fi
+        if ((skip)); then
+            skip=0
+            continue
+        fi
+        case "$word" in
+            -*) __%[1]s_takes_value "$cmd" "$word" && skip=1 ;;
+            *) word=$(__%[1]s_subcommand "$cmd" "$word") && cmd="$word" ;;
+        esac
+    done
+
+    COMPREPLY=()
+    if [[ $prev == -* ]] && __%[1]s_takes_value "$cmd" "$prev"; then
+        __%[1]s_flag_values "$cmd" "$prev" "$cur"
+        return 0
+    fi
+    if [[ $cur == -* ]]; then
+        COMPREPLY=($(compgen -W "$(__%[1]s_flags "$cmd")" -- "$cur"))
+        return 0
+    fi
+    __%[1]s_arguments "$cmd" "$cur"
+}
+
+complete -F _%[1]s %[3]s
+`, name, shQuote(root), root)
+}
+
+// writeBashValues writes the statements completing words and, if files is
+// set, file names against the word in cur
+func writeBashValues(w io.Writer, words []string, files bool, cur string) {
+	if len(words) > 0 {
+		fmt.Fprintf(w, "            COMPREPLY+=($(compgen -W %s -- \"%s\"))\n", shQuote(strings.Join(words, " ")), cur)
+	}
+	if files {
+		fmt.Fprintln(w, "            compopt -o filenames 2>/dev/null")
+		fmt.Fprintf(w, "            COMPREPLY+=($(compgen -f -- \"%s\"))\n", cur)
+	}
+}
+
+// shQuote single-quotes s for bash and zsh
+func shQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
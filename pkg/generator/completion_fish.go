@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// writeFishCompletion writes a fish completion script. Every completion is
+// guarded by a condition on the command path resolved from the words typed
+// so far.
+func writeFishCompletion(w io.Writer, commands []completionCommand) {
+	root := commands[0].Path
+	name := completionName(commands)
+
+	fmt.Fprintf(w, "# fish completion for %s\n", root)
+	fmt.Fprint(w, "# Generated by gospec-cli from an OpenCLI specification. DO NOT EDIT.\n\n")
+
+	fmt.Fprintf(w, "function __%s_subcommand\n", name)
+	fmt.Fprintln(w, "    switch \"$argv[1] $argv[2]\"")
+	for _, c := range commands {
+		for _, sub := range c.Subcommands {
+			fmt.Fprintf(w, "        case %s\n", fishQuote(c.Path+" "+sub.Word))
+			fmt.Fprintf(w, "            echo %s\n", fishQuote(sub.Path))
+		}
+	}
+	fmt.Fprintln(w, "        case '*'")
+	fmt.Fprintln(w, "            return 1")
+	fmt.Fprint(w, "    end\nend\n\n")
+
+	flags := valueFlags(commands)
+	for i, flag := range flags {
+		flags[i] = fishQuote(flag)
+	}
+	fmt.Fprintf(w, "set -g __%s_value_flags %s\n\n", name, strings.Join(flags, " "))
+
+	fmt.Fprintf(w, `function __%[1]s_command
+    set -l cmd %[2]s
+    set -l skip 0
+    for word in (commandline -opc)[2..-1]
+        if test $skip = 1
+            set skip 0
+            continue
+        end
+        switch $word
+            case '--*=*'
+            case '-*'
+                contains -- "$cmd $word" $__%[1]s_value_flags; and set skip 1
+            case '*'
+                set -l next (__%[1]s_subcommand $cmd $word); and set cmd $next
+        end
+    end
+    echo $cmd
+end
+
+function __%[1]s_is
+    test (__%[1]s_command) = "$argv"
+end
+
+complete -c %[3]s -f
+`, name, fishQuote(root), fishQuote(root))
+
+	for _, c := range commands {
+		condition := fishQuote("__" + name + "_is " + fishWords(c.Path))
+		prefix := fmt.Sprintf("complete -c %s -n %s", fishQuote(root), condition)
+
+		for _, sub := range c.Subcommands {
+			fmt.Fprintf(w, "%s -a %s%s\n", prefix, fishQuote(sub.Word), fishDescription(sub.Description))
+		}
+
+		for _, f := range c.Flags {
+			line := prefix
+			for _, flag := range f.Names {
+				if strings.HasPrefix(flag, "--") {
+					line += " -l " + fishQuote(strings.TrimPrefix(flag, "--"))
+				} else {
+					line += " -s " + fishQuote(strings.TrimPrefix(flag, "-"))
+				}
+			}
+			switch {
+			case f.TakesValue && f.Files:
+				line += " -r -F"
+			case f.TakesValue:
+				line += " -x"
+			}
+			if len(f.Values) > 0 {
+				line += " -a " + fishQuote(strings.Join(f.Values, " "))
+			}
+			fmt.Fprintln(w, line+fishDescription(f.Description))
+		}
+
+		if len(c.ArgValues) > 0 {
+			fmt.Fprintf(w, "%s -a %s\n", prefix, fishQuote(strings.Join(c.ArgValues, " ")))
+		}
+		if c.ArgFiles {
+			fmt.Fprintf(w, "%s -F\n", prefix)
+		}
+	}
+}
+
+// fishDescription returns the -d option for a description, if any
+func fishDescription(description string) string {
+	if description == "" {
+		return ""
+	}
+	return " -d " + fishQuote(description)
+}
+
+// fishWords quotes each word of a command path
+func fishWords(path string) string {
+	words := strings.Fields(path)
+	for i, word := range words {
+		words[i] = fishQuote(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// fishQuote single-quotes s for fish, where backslash escapes a quote or
+// another backslash
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,209 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// Shell identifies a shell for which completion scripts can be generated
+type Shell string
+
+const (
+	ShellBash       Shell = "bash"
+	ShellZsh        Shell = "zsh"
+	ShellFish       Shell = "fish"
+	ShellPowerShell Shell = "powershell"
+)
+
+// Shells lists the supported shells
+var Shells = []Shell{ShellBash, ShellZsh, ShellFish, ShellPowerShell}
+
+// CompletionGenerator generates a standalone shell completion script from an
+// OpenCLI specification. The script completes subcommands and their aliases,
+// flags and their shorthands, enum values and the candidates recorded in
+// x-completion extensions, and file paths for parameters whose schema format
+// is "path"; it does not call the CLI.
+type CompletionGenerator struct {
+	shell Shell
+}
+
+// NewCompletionGenerator creates a new completion generator for shell
+func NewCompletionGenerator(shell Shell) *CompletionGenerator {
+	return &CompletionGenerator{
+		shell: shell,
+	}
+}
+
+// Generate writes the completion script
+func (g *CompletionGenerator) Generate(spec *spec.OpenCLISpec, writer io.Writer) error {
+	commands := completionTree(spec)
+	if len(commands) == 0 {
+		return fmt.Errorf("specification has no commands")
+	}
+
+	w := bufio.NewWriter(writer)
+	switch g.shell {
+	case ShellBash:
+		writeBashCompletion(w, commands)
+	case ShellZsh:
+		writeZshCompletion(w, commands)
+	case ShellFish:
+		writeFishCompletion(w, commands)
+	case ShellPowerShell:
+		writePowerShellCompletion(w, commands)
+	default:
+		return fmt.Errorf("unsupported shell: %s", g.shell)
+	}
+	return w.Flush()
+}
+
+// GenerateToString returns the completion script as a string
+func (g *CompletionGenerator) GenerateToString(spec *spec.OpenCLISpec) (string, error) {
+	var b strings.Builder
+	if err := g.Generate(spec, &b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// completionCommand is what a completion script needs to know about a command
+type completionCommand struct {
+	// Path is the command line that invokes the command, e.g. "app user create"
+	Path        string
+	Description string
+	// Subcommands maps every name and alias of a child command to its path
+	Subcommands []completionWord
+	// Flags includes the flags inherited from ancestors
+	Flags []completionFlag
+	// ArgValues are the enum values accepted by positional arguments
+	ArgValues []string
+	// ArgFiles is set when a positional argument is a path
+	ArgFiles bool
+}
+
+// completionWord is a word to complete and what it stands for
+type completionWord struct {
+	Word        string
+	Path        string
+	Description string
+}
+
+// completionFlag is a flag together with the completion of its value
+type completionFlag struct {
+	// Names are the flags as typed, shorthand first, e.g. "-r", "--role"
+	Names       []string
+	Description string
+	// TakesValue is set unless the flag is a boolean switch
+	TakesValue bool
+	Values     []string
+	Files      bool
+}
+
+// completionTree collects the visible commands of a spec, root first
+func completionTree(s *spec.OpenCLISpec) []completionCommand {
+	tree := docTree(s)
+	commands := make([]completionCommand, 0, len(tree))
+	for _, cmd := range tree {
+		c := completionCommand{
+			Path:        cmd.Path,
+			Description: cmd.Command.Summary,
+		}
+
+		for _, child := range cmd.Children {
+			name := child.Path[strings.LastIndex(child.Path, " ")+1:]
+			for _, word := range append([]string{name}, child.Command.Aliases...) {
+				c.Subcommands = append(c.Subcommands, completionWord{Word: word, Path: child.Path, Description: child.Command.Summary})
+			}
+		}
+
+		for _, p := range append(cmd.flags(), cmd.inheritedFlags()...) {
+			values, files := completionValues(p)
+			c.Flags = append(c.Flags, completionFlag{
+				Names:       flagNames(p),
+				Description: firstLine(p.Description),
+				TakesValue:  takesValue(p),
+				Values:      values,
+				Files:       files,
+			})
+		}
+
+		for _, arg := range cmd.arguments() {
+			values, files := completionValues(arg)
+			c.ArgValues = append(c.ArgValues, values...)
+			c.ArgFiles = c.ArgFiles || files
+		}
+
+		commands = append(commands, c)
+	}
+	return commands
+}
+
+// completionValues returns the enum values of a parameter, or else the
+// candidates its x-completion extension records, and whether it takes file
+// paths, looking at the items of array parameters
+func completionValues(p spec.Parameter) ([]string, bool) {
+	schema := p.Schema
+	if schema == nil {
+		return nil, false
+	}
+	if schema.Type == "array" && schema.Items != nil {
+		schema = schema.Items
+	}
+
+	values := spec.AllowedValues(p.Schema)
+	if completion := spec.ParameterCompletion(p); completion != nil && len(values) == 0 {
+		values = append(values, completion.Values...)
+	}
+	return values, schema.Format == "path"
+}
+
+// takesValue reports whether a flag is followed by a value
+func takesValue(p spec.Parameter) bool {
+	return p.Schema != nil && p.Schema.Type != "" && p.Schema.Type != "boolean"
+}
+
+// valueFlags returns the names of all flags of the commands that take a
+// value, keyed by "<command path> <flag>"
+func valueFlags(commands []completionCommand) []string {
+	result := make([]string, 0)
+	for _, c := range commands {
+		for _, f := range c.Flags {
+			if f.TakesValue {
+				for _, name := range f.Names {
+					result = append(result, c.Path+" "+name)
+				}
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// completionName returns an identifier derived from the root command name
+// that is safe to use in function and variable names
+func completionName(commands []completionCommand) string {
+	var b strings.Builder
+	for _, r := range commands[0].Path {
+		switch {
+		case ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') || r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// firstLine returns the first line of a description
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return s
+}
@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+func completionSpec() *spec.OpenCLISpec {
+	s := docSpec()
+	s.Commands["app"] = spec.Command{
+		Summary: "App CLI",
+		Parameters: []spec.Parameter{
+			{Name: "config", In: "flag", Scope: "inherited", Alias: []string{"c"}, Description: "Config file",
+				Schema: &spec.Schema{Type: "string", Format: "path"}},
+			{Name: "verbose", In: "flag", Scope: "inherited", Schema: &spec.Schema{Type: "boolean"}},
+		},
+	}
+	s.Commands["/app/user"] = spec.Command{Summary: "Manage users", Aliases: []string{"users"}}
+	s.Commands["/app/user/import"] = spec.Command{
+		Summary: "Import users",
+		Parameters: []spec.Parameter{
+			{Name: "file", In: "argument", Position: 1, Schema: &spec.Schema{Type: "string", Format: "path"}},
+			{Name: "team", In: "flag", Schema: &spec.Schema{Type: "string"},
+				Extensions: map[string]interface{}{spec.ExtensionCompletion: map[string]interface{}{"values": []interface{}{"red", "blue"}}}},
+		},
+	}
+	return s
+}
+
+func TestCompletionGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		shell Shell
+		want  []string
+	}{
+		{ShellBash, []string{
+			"'app user'|'app users') echo 'app user' ;;",
+			"'app user create --role'|'app user create -c'|'app user create -r'|",
+			"'app user create -r'|'app user create --role')\n            COMPREPLY+=($(compgen -W 'member admin' -- \"$3\"))",
+			"'app user create') echo '-r --role -c --config --verbose' ;;",
+			"'app user import')\n            compopt -o filenames 2>/dev/null",
+			"'app user import --team')\n            COMPREPLY+=($(compgen -W 'red blue' -- \"$3\"))",
+			"complete -F _app app\n",
+		}},
+		{ShellZsh, []string{
+			"#compdef app\n",
+			"'app users') print -r -- 'app user' ;;",
+			"'app user create') flags=('-r:Role | group' '--role:Role | group' '-c:Config file' '--config:Config file' '--verbose') ;;",
+			"commands=('user:Manage users' 'users:Manage users')",
+			"compadd -- 'member' 'admin'",
+			"compdef _app app\n",
+		}},
+		{ShellFish, []string{
+			"case 'app users'\n            echo 'app user'",
+			"set -g __app_value_flags 'app --config' 'app -c'",
+			"complete -c 'app' -f\n",
+			"complete -c 'app' -n '__app_is \\'app\\'' -a 'users' -d 'Manage users'",
+			"-s 'r' -l 'role' -x -a 'member admin' -d 'Role | group'",
+			"-s 'c' -l 'config' -r -F -d 'Config file'",
+			"-n '__app_is \\'app\\' \\'user\\' \\'import\\'' -F\n",
+		}},
+		{ShellPowerShell, []string{
+			"Register-ArgumentCompleter -Native -CommandName 'app' -ScriptBlock {",
+			"@{ Word = 'users'; Path = 'app user'; Description = 'Manage users' }",
+			"@{ Names = @('-r', '--role'); Description = 'Role | group'; TakesValue = $true; Values = @('member', 'admin'); Files = $false }",
+			"@{ Names = @('--verbose'); Description = '--verbose'; TakesValue = $false; Values = @(); Files = $false }",
+			"ArgFiles = $true",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.shell), func(t *testing.T) {
+			out, err := NewCompletionGenerator(tt.shell).GenerateToString(completionSpec())
+			if err != nil {
+				t.Fatalf("Failed to generate: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range []string{"secret", "internal"} {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("Expected hidden %q to be omitted", unwanted)
+				}
+			}
+		})
+	}
+
+	if _, err := NewCompletionGenerator("tcsh").GenerateToString(completionSpec()); err == nil {
+		t.Error("Expected error for unsupported shell")
+	}
+}
+
+func TestCompletionGenerator_Bash(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "app.bash")
+	out, err := NewCompletionGenerator(ShellBash).GenerateToString(completionSpec())
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	if err := os.WriteFile(script, []byte(out), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "users.csv"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"app ", "user users"},
+		{"app users ", "create add new import"},
+		{"app --config cfg.yaml u", "user users"},
+		{"app user create --", "--role --config --verbose"},
+		{"app user create -r ", "member admin"},
+		{"app user create --role=a", "admin"},
+		{"app --verbose user import us", "users.csv"},
+		{"app -c us", "users.csv"},
+	}
+	for _, tt := range tests {
+		// An empty last word is kept by splitting on single spaces
+		words := strings.Split(tt.line, " ")
+		if strings.HasSuffix(tt.line, "=a") {
+			last := words[len(words)-1]
+			i := strings.Index(last, "=")
+			words = append(words[:len(words)-1], last[:i], "=", last[i+1:])
+		}
+		quoted := make([]string, len(words))
+		for i, word := range words {
+			quoted[i] = shQuote(word)
+		}
+		cmd := exec.Command(bash, "-c", `source "$1"; COMP_WORDS=(`+strings.Join(quoted, " ")+`); COMP_CWORD=$((${#COMP_WORDS[@]} - 1)); _app; echo "${COMPREPLY[*]}"`, "bash", script)
+		cmd.Dir = dir
+		got, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%q: bash failed: %v", tt.line, err)
+		}
+		if strings.TrimSpace(string(got)) != tt.want {
+			t.Errorf("%q: expected %q, got %q", tt.line, tt.want, strings.TrimSpace(string(got)))
+		}
+	}
+}
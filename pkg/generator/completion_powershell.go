@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// writePowerShellCompletion writes a PowerShell argument completer. The
+// command tree is embedded as a hashtable keyed by command path.
+func writePowerShellCompletion(w io.Writer, commands []completionCommand) {
+	root := commands[0].Path
+
+	fmt.Fprintf(w, "# powershell completion for %s\n", root)
+	fmt.Fprint(w, "# Generated by gospec-cli from an OpenCLI specification. DO NOT EDIT.\n\n")
+
+	fmt.Fprintf(w, "Register-ArgumentCompleter -Native -CommandName %s -ScriptBlock {\n", psQuote(root))
+	fmt.Fprint(w, "    param($wordToComplete, $commandAst, $cursorPosition)\n\n")
+
+	fmt.Fprintln(w, "    $commands = @{")
+	for _, c := range commands {
+		fmt.Fprintf(w, "        %s = @{\n", psQuote(c.Path))
+
+		fmt.Fprintln(w, "            Commands = @(")
+		for _, sub := range c.Subcommands {
+			fmt.Fprintf(w, "                @{ Word = %s; Path = %s; Description = %s }\n",
+				psQuote(sub.Word), psQuote(sub.Path), psQuote(tooltip(sub.Description, sub.Word)))
+		}
+		fmt.Fprintln(w, "            )")
+
+		fmt.Fprintln(w, "            Flags = @(")
+		for _, f := range c.Flags {
+			fmt.Fprintf(w, "                @{ Names = %s; Description = %s; TakesValue = %s; Values = %s; Files = %s }\n",
+				psArray(f.Names), psQuote(tooltip(f.Description, f.Names[len(f.Names)-1])), psBool(f.TakesValue), psArray(f.Values), psBool(f.Files))
+		}
+		fmt.Fprintln(w, "            )")
+
+		fmt.Fprintf(w, "            ArgValues = %s\n", psArray(c.ArgValues))
+		fmt.Fprintf(w, "            ArgFiles = %s\n", psBool(c.ArgFiles))
+		fmt.Fprintln(w, "        }")
+	}
+	fmt.Fprint(w, "    }\n\n")
+
+	fmt.Fprintf(w, `    # Words before the one being completed
+    $words = @($commandAst.CommandElements |
+        Where-Object { $_.Extent.EndOffset -lt $cursorPosition } |
+        ForEach-Object { $_.Extent.Text })
+
+    $cmd = %s
+    $pending = $null
+    foreach ($word in ($words | Select-Object -Skip 1)) {
+        if ($pending) {
+            $pending = $null
+            continue
+        }
+        if ($word -like '-*') {
+            if ($word -notlike '*=*') {
+                $pending = $commands[$cmd].Flags | Where-Object { $_.TakesValue -and $_.Names -contains $word } | Select-Object -First 1
+            }
+            continue
+        }
+        $next = $commands[$cmd].Commands | Where-Object { $_.Word -eq $word } | Select-Object -First 1
+        if ($next) {
+            $cmd = $next.Path
+        }
+    }
+
+    $prefix = ''
+    $current = $wordToComplete
+    if (-not $pending -and $wordToComplete -like '--*=*') {
+        $name, $current = $wordToComplete -split '=', 2
+        $prefix = "$name="
+        $pending = $commands[$cmd].Flags | Where-Object { $_.TakesValue -and $_.Names -contains $name } | Select-Object -First 1
+        if (-not $pending) {
+            return
+        }
+    }
+
+    if ($pending) {
+        foreach ($value in $pending.Values) {
+            if ($value.StartsWith($current)) {
+                [System.Management.Automation.CompletionResult]::new("$prefix$value", $value, 'ParameterValue', $value)
+            }
+        }
+        if ($pending.Files) {
+            foreach ($file in [System.Management.Automation.CompletionCompleters]::CompleteFilename($current)) {
+                [System.Management.Automation.CompletionResult]::new("$prefix$($file.CompletionText)", $file.ListItemText, $file.ResultType, $file.ToolTip)
+            }
+        }
+        return
+    }
+
+    if ($wordToComplete -like '-*') {
+        foreach ($flag in $commands[$cmd].Flags) {
+            foreach ($name in $flag.Names) {
+                if ($name.StartsWith($wordToComplete)) {
+                    [System.Management.Automation.CompletionResult]::new($name, $name, 'ParameterName', $flag.Description)
+                }
+            }
+        }
+        return
+    }
+
+    foreach ($sub in $commands[$cmd].Commands) {
+        if ($sub.Word.StartsWith($wordToComplete)) {
+            [System.Management.Automation.CompletionResult]::new($sub.Word, $sub.Word, 'ParameterValue', $sub.Description)
+        }
+    }
+    foreach ($value in $commands[$cmd].ArgValues) {
+        if ($value.StartsWith($wordToComplete)) {
+            [System.Management.Automation.CompletionResult]::new($value, $value, 'ParameterValue', $value)
+        }
+    }
+    if ($commands[$cmd].ArgFiles) {
+        [System.Management.Automation.CompletionCompleters]::CompleteFilename($wordToComplete)
+    }
+}
+`, psQuote(root))
+}
+
+// tooltip returns description, or fallback when it is empty, because
+// PowerShell rejects empty tooltips
+func tooltip(description, fallback string) string {
+	if description == "" {
+		return fallback
+	}
+	return description
+}
+
+// psArray returns values as a PowerShell array literal
+func psArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = psQuote(v)
+	}
+	return "@(" + strings.Join(quoted, ", ") + ")"
+}
+
+// psBool returns b as a PowerShell boolean
+func psBool(b bool) string {
+	if b {
+		return "$true"
+	}
+	return "$false"
+}
+
+// psQuote single-quotes s for PowerShell, where a quote is escaped by
+// doubling it
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
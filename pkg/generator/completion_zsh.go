@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// writeZshCompletion writes a zsh completion script that can be autoloaded
+// from $fpath or sourced. Like the bash script, the helpers dispatch on the
+// command path resolved from the words typed so far.
+func writeZshCompletion(w io.Writer, commands []completionCommand) {
+	root := commands[0].Path
+	name := completionName(commands)
+
+	fmt.Fprintf(w, "#compdef %s\n", root)
+	fmt.Fprintf(w, "# zsh completion for %s\n", root)
+	fmt.Fprint(w, "# Generated by gospec-cli from an OpenCLI specification. DO NOT EDIT.\n\n")
+
+	fmt.Fprintf(w, "__%s_subcommand() {\n", name)
+	fmt.Fprintln(w, "    case \"$1 $2\" in")
+	for _, c := range commands {
+		for _, sub := range c.Subcommands {
+			fmt.Fprintf(w, "        %s) print -r -- %s ;;\n", shQuote(c.Path+" "+sub.Word), shQuote(sub.Path))
+		}
+	}
+	fmt.Fprintln(w, "        *) return 1 ;;")
+	fmt.Fprint(w, "    esac\n}\n\n")
+
+	fmt.Fprintf(w, "__%s_takes_value() {\n", name)
+	if flags := valueFlags(commands); len(flags) > 0 {
+		for i, flag := range flags {
+			flags[i] = shQuote(flag)
+		}
+		fmt.Fprintln(w, "    case \"$1 $2\" in")
+		fmt.Fprintf(w, "        %s) return 0 ;;\n", strings.Join(flags, "|"))
+		fmt.Fprintln(w, "    esac")
+	}
+	fmt.Fprint(w, "    return 1\n}\n\n")
+
+	fmt.Fprintf(w, "__%s_flag_values() {\n", name)
+	fmt.Fprintln(w, "    case \"$1 $2\" in")
+	for _, c := range commands {
+		for _, f := range c.Flags {
+			if !f.TakesValue || (len(f.Values) == 0 && !f.Files) {
+				continue
+			}
+			patterns := make([]string, len(f.Names))
+			for i, flag := range f.Names {
+				patterns[i] = shQuote(c.Path + " " + flag)
+			}
+			fmt.Fprintf(w, "        %s)\n", strings.Join(patterns, "|"))
+			writeZshValues(w, f.Values, f.Files)
+			fmt.Fprintln(w, "            ;;")
+		}
+	}
+	fmt.Fprint(w, "    esac\n}\n\n")
+
+	fmt.Fprintf(w, "__%s_flags() {\n", name)
+	fmt.Fprintln(w, "    local -a flags")
+	fmt.Fprintln(w, "    case \"$1\" in")
+	for _, c := range commands {
+		if len(c.Flags) == 0 {
+			continue
+		}
+		entries := make([]string, 0)
+		for _, f := range c.Flags {
+			for _, flag := range f.Names {
+				entries = append(entries, zshDescribeEntry(flag, f.Description))
+			}
+		}
+		fmt.Fprintf(w, "        %s) flags=(%s) ;;\n", shQuote(c.Path), strings.Join(entries, " "))
+	}
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprint(w, "    _describe -t flags 'flag' flags\n}\n\n")
+
+	fmt.Fprintf(w, "__%s_arguments() {\n", name)
+	fmt.Fprintln(w, "    local -a commands")
+	fmt.Fprintln(w, "    case \"$1\" in")
+	for _, c := range commands {
+		if len(c.Subcommands) == 0 && len(c.ArgValues) == 0 && !c.ArgFiles {
+			continue
+		}
+		fmt.Fprintf(w, "        %s)\n", shQuote(c.Path))
+		if len(c.Subcommands) > 0 {
+			entries := make([]string, len(c.Subcommands))
+			for i, sub := range c.Subcommands {
+				entries[i] = zshDescribeEntry(sub.Word, sub.Description)
+			}
+			fmt.Fprintf(w, "            commands=(%s)\n", strings.Join(entries, " "))
+			fmt.Fprintln(w, "            _describe -t commands 'command' commands")
+		}
+		writeZshValues(w, c.ArgValues, c.ArgFiles)
+		fmt.Fprintln(w, "            ;;")
+	}
+	fmt.Fprint(w, "    esac\n}\n\n")
+
+	fmt.Fprintf(w, `_%[1]s() {
+    local cur="${words[CURRENT]}" prev="${words[CURRENT-1]}"
+    local word cmd=%[2]s skip=0 i
+
+    for ((i = 2; i < CURRENT; i++)); do
+        word="${words[i]}"
+        if ((skip)); then
+            skip=0
+            continue
+        fi
+        case "$word" in
+            --*=*) ;;
+            -*) __%[1]s_takes_value "$cmd" "$word" && skip=1 ;;
+            *) word=$(__%[1]s_subcommand "$cmd" "$word") && cmd="$word" ;;
+        esac
+    done
+
+    if [[ $cur == --*=* ]]; then
+        prev="${cur%%%%=*}"
+        compset -P '*='
+        __%[1]s_takes_value "$cmd" "$prev" && __%[1]s_flag_values "$cmd" "$prev"
+        return
+    fi
+    if [[ $prev == -* ]] && __%[1]s_takes_value "$cmd" "$prev"; then
+        __%[1]s_flag_values "$cmd" "$prev"
+        return
+    fi
+    if [[ $cur == -* ]]; then
+        __%[1]s_flags "$cmd"
+        return
+    fi
+    __%[1]s_arguments "$cmd"
+}
+
+if [[ "$funcstack[1]" == "_%[1]s" ]]; then
+    _%[1]s "$@"
+else
+    compdef _%[1]s %[3]s
+fi
+`, name, shQuote(root), root)
+}
+
+// writeZshValues writes the statements completing words and, if files is
+// set, file names
+func writeZshValues(w io.Writer, words []string, files bool) {
+	if len(words) > 0 {
+		quoted := make([]string, len(words))
+		for i, word := range words {
+			quoted[i] = shQuote(word)
+		}
+		fmt.Fprintf(w, "            compadd -- %s\n", strings.Join(quoted, " "))
+	}
+	if files {
+		fmt.Fprintln(w, "            _files")
+	}
+}
+
+// zshDescribeEntry returns a "word:description" entry for _describe, which
+// requires colons in the word to be escaped
+func zshDescribeEntry(word, description string) string {
+	entry := strings.ReplaceAll(word, ":", `\:`)
+	if description != "" {
+		entry += ":" + description
+	}
+	return shQuote(entry)
+}
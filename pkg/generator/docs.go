@@ -0,0 +1,219 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// docCommand is a command of the spec placed in its command tree, as needed
+// by the documentation generators
+type docCommand struct {
+	// Key is the key of the command in spec.Commands
+	Key string
+	// Path is the command line that invokes the command, e.g. "app user create"
+	Path     string
+	Command  spec.Command
+	Parent   *docCommand
+	Children []*docCommand
+}
+
+// docTree arranges the visible commands of a spec by path, parents before
+// their children. Command keys are paths such as "/app/user/create".
+func docTree(s *spec.OpenCLISpec) []*docCommand {
+	byPath := make(map[string]*docCommand)
+	for key, cmd := range s.Commands {
+		if cmd.Hidden {
+			continue
+		}
+		path := strings.Join(strings.Split(strings.Trim(key, "/"), "/"), " ")
+		byPath[path] = &docCommand{Key: key, Path: path, Command: cmd}
+	}
+
+	commands := make([]*docCommand, 0, len(byPath))
+	for _, cmd := range byPath {
+		commands = append(commands, cmd)
+	}
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Path < commands[j].Path })
+
+	for _, cmd := range commands {
+		if i := strings.LastIndex(cmd.Path, " "); i >= 0 {
+			if parent, ok := byPath[cmd.Path[:i]]; ok {
+				cmd.Parent = parent
+				parent.Children = append(parent.Children, cmd)
+			}
+		}
+	}
+	return commands
+}
+
+// synopsis returns the usage line of a command. The x-usage extension holds
+// the framework's usage string without the parent path, e.g. "create <name>".
+func (c *docCommand) synopsis() string {
+	flags := len(c.flags()) > 0 || len(c.inheritedFlags()) > 0
+
+	if usage, ok := c.Command.Extensions[spec.ExtensionUsage].(string); ok && usage != "" {
+		line := usage
+		if c.Parent != nil {
+			line = c.Parent.Path + " " + usage
+		}
+		// Usage strings such as "[flags]" or "[OPTION]..." already mention flags
+		lower := strings.ToLower(usage)
+		if flags && !strings.Contains(lower, "[flag") && !strings.Contains(lower, "[option") {
+			line += " [flags]"
+		}
+		return line
+	}
+
+	parts := []string{c.Path}
+	if len(c.Children) > 0 {
+		parts = append(parts, "[command]")
+	}
+	for _, arg := range c.arguments() {
+		name := arg.Name
+		if arg.Arity != nil && arg.Arity.Max == nil {
+			name += "..."
+		}
+		if arg.Required {
+			parts = append(parts, "<"+name+">")
+		} else {
+			parts = append(parts, "["+name+"]")
+		}
+	}
+	if flags {
+		parts = append(parts, "[flags]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// examples returns the examples of a command, if any
+func (c *docCommand) examples() string {
+	examples, _ := c.Command.Extensions[spec.ExtensionExamples].(string)
+	return strings.TrimSpace(examples)
+}
+
+// flags returns the visible flags declared on the command itself
+func (c *docCommand) flags() []spec.Parameter {
+	result := make([]spec.Parameter, 0)
+	for _, p := range c.Command.Parameters {
+		if p.In != "argument" && !p.Hidden {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// inheritedFlags returns the visible flags ancestors pass down to the command
+func (c *docCommand) inheritedFlags() []spec.Parameter {
+	result := make([]spec.Parameter, 0)
+	seen := make(map[string]bool)
+	for _, p := range c.Command.Parameters {
+		seen[p.Name] = true
+	}
+	for parent := c.Parent; parent != nil; parent = parent.Parent {
+		for _, p := range parent.flags() {
+			if (p.Scope == "inherited" || p.Scope == "global") && !seen[p.Name] {
+				seen[p.Name] = true
+				result = append(result, p)
+			}
+		}
+	}
+	return result
+}
+
+// environment returns the environment variables of s that set a visible
+// parameter of the command or a flag it inherits. Variables bound to no
+// parameter of the CLI apply to all of it and are listed on the root.
+func (c *docCommand) environment(s *spec.OpenCLISpec) []spec.EnvironmentVariable {
+	bound := make(map[string]bool)
+	for _, cmd := range s.Commands {
+		for _, p := range cmd.Parameters {
+			for _, name := range p.Env {
+				bound[name] = true
+			}
+		}
+	}
+
+	own := make(map[string]bool)
+	params := append(c.arguments(), c.flags()...)
+	params = append(params, c.inheritedFlags()...)
+	for _, p := range params {
+		for _, name := range p.Env {
+			own[name] = true
+		}
+	}
+
+	result := make([]spec.EnvironmentVariable, 0)
+	for _, env := range s.Environment {
+		if own[env.Name] || (c.Parent == nil && !bound[env.Name]) {
+			result = append(result, env)
+		}
+	}
+	return result
+}
+
+// arguments returns the positional arguments ordered by position
+func (c *docCommand) arguments() []spec.Parameter {
+	result := make([]spec.Parameter, 0)
+	for _, p := range c.Command.Parameters {
+		if p.In == "argument" {
+			result = append(result, p)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Position < result[j].Position })
+	return result
+}
+
+// flagNames renders the names of a flag as typed, shorthand first
+func flagNames(p spec.Parameter) []string {
+	names := make([]string, 0, len(p.Alias)+1)
+	for _, alias := range p.Alias {
+		if len(alias) == 1 {
+			names = append(names, "-"+alias)
+		}
+	}
+	names = append(names, "--"+p.Name)
+	for _, alias := range p.Alias {
+		if len(alias) > 1 {
+			names = append(names, "--"+alias)
+		}
+	}
+	return names
+}
+
+// paramType returns the schema type of a parameter, if known
+func paramType(p spec.Parameter) string {
+	if p.Schema == nil {
+		return ""
+	}
+	if p.Schema.Type == "array" && p.Schema.Items != nil && p.Schema.Items.Type != "" {
+		return p.Schema.Items.Type + "[]"
+	}
+	return p.Schema.Type
+}
+
+// paramDefault returns the default value of a parameter, if any
+func paramDefault(p spec.Parameter) string {
+	if p.Schema == nil || p.Schema.Default == nil {
+		return ""
+	}
+	return fmt.Sprint(p.Schema.Default)
+}
+
+// paramDescription returns the description of a parameter including its
+// allowed values, environment variables and deprecation
+func paramDescription(p spec.Parameter) string {
+	description := p.Description
+	if values := spec.AllowedValues(p.Schema); len(values) > 0 {
+		description = strings.TrimSpace(description + " (one of: " + strings.Join(values, ", ") + ")")
+	}
+	if len(p.Env) > 0 {
+		description = strings.TrimSpace(description + " (env: " + strings.Join(p.Env, ", ") + ")")
+	}
+	if p.Deprecated {
+		description = strings.TrimSpace(description + " (deprecated)")
+	}
+	return description
+}
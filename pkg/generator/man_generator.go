@@ -0,0 +1,287 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// ManGenerator generates section 1 man pages in roff format, one page per
+// command
+type ManGenerator struct {
+	section string
+}
+
+// NewManGenerator creates a new man page generator
+func NewManGenerator() *ManGenerator {
+	return &ManGenerator{
+		section: "1",
+	}
+}
+
+// Generate writes the man page of the root command
+func (g *ManGenerator) Generate(spec *spec.OpenCLISpec, writer io.Writer) error {
+	commands := docTree(spec)
+	if len(commands) == 0 {
+		return fmt.Errorf("specification has no commands")
+	}
+	w := bufio.NewWriter(writer)
+	g.writePage(w, spec, commands[0])
+	return w.Flush()
+}
+
+// GenerateToString returns the man page of the root command as a string
+func (g *ManGenerator) GenerateToString(spec *spec.OpenCLISpec) (string, error) {
+	var b strings.Builder
+	if err := g.Generate(spec, &b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// GeneratePages writes one man page per command into dir, named after the
+// command path such as app-user-create.1, and returns the written paths
+func (g *ManGenerator) GeneratePages(spec *spec.OpenCLISpec, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	paths := make([]string, 0)
+	for _, cmd := range docTree(spec) {
+		path := filepath.Join(dir, g.pageName(cmd)+"."+g.section)
+		file, err := os.Create(path)
+		if err != nil {
+			return paths, fmt.Errorf("failed to create %s: %w", path, err)
+		}
+
+		w := bufio.NewWriter(file)
+		g.writePage(w, spec, cmd)
+		err = w.Flush()
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return paths, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// pageName returns the name of a command's man page, e.g. app-user-create
+func (g *ManGenerator) pageName(cmd *docCommand) string {
+	return strings.ReplaceAll(cmd.Path, " ", "-")
+}
+
+// writePage writes the man page of one command
+func (g *ManGenerator) writePage(w io.Writer, s *spec.OpenCLISpec, cmd *docCommand) {
+	name := g.pageName(cmd)
+	source := strings.TrimSpace(s.Info.Title + " " + s.Info.Version)
+
+	fmt.Fprintf(w, ".TH %s %s \"\" %s %s\n", roffQuote(strings.ToUpper(name)), g.section, roffQuote(source), roffQuote(s.Info.Title+" Manual"))
+	fmt.Fprintln(w, ".nh")
+	fmt.Fprintln(w, ".ad l")
+
+	fmt.Fprintln(w, ".SH NAME")
+	if cmd.Command.Summary != "" {
+		fmt.Fprintf(w, "%s \\- %s\n", roffText(name), roffText(cmd.Command.Summary))
+	} else {
+		fmt.Fprintln(w, roffText(name))
+	}
+
+	fmt.Fprintln(w, ".SH SYNOPSIS")
+	synopsis := cmd.synopsis()
+	if rest := strings.TrimPrefix(synopsis, cmd.Path); rest != synopsis {
+		fmt.Fprintf(w, "\\fB%s\\fP%s\n", roffText(cmd.Path), roffText(rest))
+	} else {
+		fmt.Fprintln(w, roffText(synopsis))
+	}
+
+	fmt.Fprintln(w, ".SH DESCRIPTION")
+	description := cmd.Command.Description
+	if description == "" {
+		description = cmd.Command.Summary
+	}
+	if description != "" {
+		writeRoffParagraphs(w, description)
+	}
+	if cmd.Command.Deprecated {
+		fmt.Fprintln(w, ".PP")
+		fmt.Fprintln(w, "This command is deprecated and may be removed in a future version.")
+	}
+	if len(cmd.Command.Aliases) > 0 {
+		fmt.Fprintln(w, ".PP")
+		fmt.Fprintf(w, "Aliases: %s\n", roffText(strings.Join(cmd.Command.Aliases, ", ")))
+	}
+
+	if args := cmd.arguments(); len(args) > 0 {
+		fmt.Fprintln(w, ".SH ARGUMENTS")
+		for _, arg := range args {
+			fmt.Fprintln(w, ".TP")
+			fmt.Fprintf(w, "\\fI%s\\fP\n", roffText(arg.Name))
+			description := paramDescription(arg)
+			if arg.Required {
+				description = strings.TrimSpace(description + " (required)")
+			}
+			writeRoffLine(w, description)
+		}
+	}
+
+	if flags := cmd.flags(); len(flags) > 0 {
+		fmt.Fprintln(w, ".SH OPTIONS")
+		writeManFlags(w, flags)
+	}
+	if flags := cmd.inheritedFlags(); len(flags) > 0 {
+		fmt.Fprintln(w, ".SH OPTIONS INHERITED FROM PARENT COMMANDS")
+		writeManFlags(w, flags)
+	}
+
+	if environment := cmd.environment(s); len(environment) > 0 {
+		fmt.Fprintln(w, ".SH ENVIRONMENT")
+		for _, env := range environment {
+			fmt.Fprintln(w, ".TP")
+			fmt.Fprintf(w, ".B %s\n", roffText(env.Name))
+			description := env.Description
+			if env.Required {
+				description = strings.TrimSpace(description + " (required)")
+			}
+			if env.Default != "" {
+				description = strings.TrimSpace(description + " [default: " + env.Default + "]")
+			}
+			writeRoffLine(w, description)
+		}
+	}
+
+	if len(cmd.Command.Responses) > 0 {
+		fmt.Fprintln(w, ".SH EXIT STATUS")
+		for _, code := range exitCodes(cmd.Command.Responses) {
+			fmt.Fprintln(w, ".TP")
+			fmt.Fprintf(w, ".B %s\n", roffText(code))
+			writeRoffLine(w, cmd.Command.Responses[code].Description)
+		}
+	}
+
+	if examples := cmd.examples(); examples != "" {
+		fmt.Fprintln(w, ".SH EXAMPLES")
+		fmt.Fprintln(w, ".PP")
+		fmt.Fprintln(w, ".nf")
+		for _, line := range strings.Split(examples, "\n") {
+			fmt.Fprintln(w, roffText(line))
+		}
+		fmt.Fprintln(w, ".fi")
+	}
+
+	if related := g.related(cmd); len(related) > 0 {
+		fmt.Fprintln(w, ".SH SEE ALSO")
+		refs := make([]string, len(related))
+		for i, other := range related {
+			refs[i] = fmt.Sprintf("\\fB%s\\fP(%s)", roffText(g.pageName(other)), g.section)
+		}
+		fmt.Fprintln(w, strings.Join(refs, ", "))
+	}
+}
+
+// related returns the parent, siblings and children of a command
+func (g *ManGenerator) related(cmd *docCommand) []*docCommand {
+	related := make([]*docCommand, 0)
+	if cmd.Parent != nil {
+		related = append(related, cmd.Parent)
+		for _, sibling := range cmd.Parent.Children {
+			if sibling != cmd {
+				related = append(related, sibling)
+			}
+		}
+	}
+	return append(related, cmd.Children...)
+}
+
+// writeManFlags writes flags as tagged paragraphs
+func writeManFlags(w io.Writer, flags []spec.Parameter) {
+	for _, flag := range flags {
+		names := flagNames(flag)
+		for i, name := range names {
+			names[i] = "\\fB" + roffText(name) + "\\fP"
+		}
+		label := strings.Join(names, ", ")
+		if t := paramType(flag); t != "" && t != "boolean" {
+			label += "=\\fI" + roffText(t) + "\\fP"
+		}
+
+		description := paramDescription(flag)
+		if flag.Required {
+			description = strings.TrimSpace(description + " (required)")
+		}
+		if value := paramDefault(flag); value != "" {
+			description = strings.TrimSpace(description + " [default: " + value + "]")
+		}
+
+		fmt.Fprintln(w, ".TP")
+		fmt.Fprintln(w, label)
+		writeRoffLine(w, description)
+	}
+}
+
+// exitCodes returns the response keys in numeric order
+func exitCodes(responses map[string]spec.Response) []string {
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		a, errA := strconv.Atoi(codes[i])
+		b, errB := strconv.Atoi(codes[j])
+		if errA != nil || errB != nil {
+			return codes[i] < codes[j]
+		}
+		return a < b
+	})
+	return codes
+}
+
+// writeRoffLine writes the body of a tagged paragraph, if any
+func writeRoffLine(w io.Writer, s string) {
+	if s = strings.TrimSpace(s); s != "" {
+		fmt.Fprintln(w, roffText(s))
+	}
+}
+
+// writeRoffParagraphs writes text whose paragraphs are separated by blank
+// lines, which roff would otherwise print verbatim
+func writeRoffParagraphs(w io.Writer, s string) {
+	for i, paragraph := range strings.Split(strings.TrimSpace(s), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		if i > 0 {
+			fmt.Fprintln(w, ".PP")
+		}
+		fmt.Fprintln(w, roffText(paragraph))
+	}
+}
+
+// roffText escapes text so roff prints it literally. Backslashes are escaped,
+// hyphens become minus signs so they can be searched for and copied, and
+// lines that would start a request are protected.
+func roffText(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roffQuote returns s as a quoted request argument
+func roffQuote(s string) string {
+	return `"` + strings.ReplaceAll(roffText(s), `"`, `\(dq`) + `"`
+}
@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+func manSpec() *spec.OpenCLISpec {
+	s := docSpec()
+	s.Environment = []spec.EnvironmentVariable{
+		{Name: "APP_TOKEN", Description: "API token", Required: true},
+		{Name: "APP_HOME", Description: "Data directory", Default: "~/.app"},
+		{Name: "APP_CONFIG", Description: "Config file"},
+		{Name: "APP_ROLE", Description: "Role of new users"},
+	}
+	bindEnv(s, "app", "config", "APP_CONFIG")
+	bindEnv(s, "/app/user/create", "role", "APP_ROLE")
+	create := s.Commands["/app/user/create"]
+	create.Responses = map[string]spec.Response{
+		"10": {Description: "User already exists"},
+		"2":  {Description: "Invalid usage"},
+		"0":  {Description: "User created"},
+	}
+	s.Commands["/app/user/create"] = create
+	s.Commands["/app/user/delete"] = spec.Command{Summary: "Delete a user"}
+	return s
+}
+
+// bindEnv makes the environment variable env set a flag of a command
+func bindEnv(s *spec.OpenCLISpec, key, flag, env string) {
+	cmd := s.Commands[key]
+	params := append([]spec.Parameter{}, cmd.Parameters...)
+	for i := range params {
+		if params[i].Name == flag {
+			params[i].Env = append(params[i].Env, env)
+		}
+	}
+	cmd.Parameters = params
+	s.Commands[key] = cmd
+}
+
+func TestManGenerator_Generate(t *testing.T) {
+	out, err := NewManGenerator().GenerateToString(manSpec())
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	for _, want := range []string{
+		".TH \"APP\" 1 \"\" \"App CLI 1.2.0\" \"App CLI Manual\"\n",
+		".SH NAME\napp \\- App CLI\n",
+		".SH SYNOPSIS\n\\fBapp\\fP [flags]\n",
+		".TP\n\\fB\\-\\-config\\fP=\\fIstring\\fP\nConfig file (env: APP_CONFIG)\n",
+		".SH ENVIRONMENT\n.TP\n.B APP_TOKEN\nAPI token (required)\n.TP\n.B APP_HOME\nData directory [default: ~/.app]\n.TP\n.B APP_CONFIG\nConfig file\n.SH",
+		".SH SEE ALSO\n\\fBapp\\-user\\fP(1)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestManGenerator_PageEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewManGenerator().GeneratePages(manSpec(), dir); err != nil {
+		t.Fatalf("Failed to generate pages: %v", err)
+	}
+
+	tests := []struct {
+		page string
+		want string
+	}{
+		// Unbound variables are listed on the root only
+		{"app.1", "APP_TOKEN APP_HOME APP_CONFIG"},
+		// Variables of inherited flags are listed on every descendant
+		{"app-user.1", "APP_CONFIG"},
+		{"app-user-create.1", "APP_CONFIG APP_ROLE"},
+		{"app-user-delete.1", "APP_CONFIG"},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(filepath.Join(dir, tt.page))
+		if err != nil {
+			t.Fatalf("Failed to read page: %v", err)
+		}
+		_, section, _ := strings.Cut(string(data), ".SH ENVIRONMENT\n")
+		section, _, _ = strings.Cut(section, ".SH ")
+		names := make([]string, 0)
+		for _, line := range strings.Split(section, "\n") {
+			if name, ok := strings.CutPrefix(line, ".B "); ok {
+				names = append(names, name)
+			}
+		}
+		if got := strings.Join(names, " "); got != tt.want {
+			t.Errorf("%s: expected environment %q, got %q", tt.page, tt.want, got)
+		}
+	}
+}
+
+func TestManGenerator_GeneratePages(t *testing.T) {
+	dir := t.TempDir()
+	paths, err := NewManGenerator().GeneratePages(manSpec(), dir)
+	if err != nil {
+		t.Fatalf("Failed to generate pages: %v", err)
+	}
+
+	want := []string{"app.1", "app-user.1", "app-user-create.1", "app-user-delete.1"}
+	if len(paths) != len(want) {
+		t.Fatalf("Expected %d pages, got %v", len(want), paths)
+	}
+	for i, name := range want {
+		if paths[i] != filepath.Join(dir, name) {
+			t.Errorf("Expected page %s, got %s", name, paths[i])
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "app-user-create.1"))
+	if err != nil {
+		t.Fatalf("Failed to read page: %v", err)
+	}
+	page := string(data)
+	for _, want := range []string{
+		".SH NAME\napp\\-user\\-create \\- Create a user\n",
+		".SH SYNOPSIS\n\\fBapp user create\\fP <name> [flags]\n",
+		".SH DESCRIPTION\nCreate a user account.\n.PP\nThis command is deprecated",
+		"Aliases: add, new\n",
+		".SH ARGUMENTS\n.TP\n\\fIname\\fP\nUser name (required)\n",
+		".SH OPTIONS\n.TP\n\\fB\\-r\\fP, \\fB\\-\\-role\\fP=\\fIstring\\fP\nRole | group (one of: member, admin) (env: APP_ROLE) [default: member]\n",
+		".SH OPTIONS INHERITED FROM PARENT COMMANDS\n.TP\n\\fB\\-\\-config\\fP",
+		".SH EXIT STATUS\n.TP\n.B 0\nUser created\n.TP\n.B 2\nInvalid usage\n.TP\n.B 10\nUser already exists\n",
+		".SH EXAMPLES\n.PP\n.nf\napp user create alice \\-\\-role admin\n.fi\n",
+		".SH SEE ALSO\n\\fBapp\\-user\\fP(1), \\fBapp\\-user\\-delete\\fP(1)\n",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected page to contain %q, got:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "secret") {
+		t.Error("Expected hidden flag to be omitted")
+	}
+}
+
+func TestRoffText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{`C:\path`, `C:\epath`},
+		{"--flag", `\-\-flag`},
+		{".hidden\n'quoted", "\\&.hidden\n\\&'quoted"},
+	}
+	for _, tt := range tests {
+		if got := roffText(tt.in); got != tt.want {
+			t.Errorf("roffText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
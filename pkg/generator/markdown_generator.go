@@ -0,0 +1,219 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// MarkdownGenerator generates Markdown documentation for OpenCLI
+// specifications, either as a single document or as one page per command
+type MarkdownGenerator struct{}
+
+// NewMarkdownGenerator creates a new Markdown generator
+func NewMarkdownGenerator() *MarkdownGenerator {
+	return &MarkdownGenerator{}
+}
+
+// Generate writes the documentation of every command as a single Markdown
+// document, linking commands by anchor
+func (g *MarkdownGenerator) Generate(spec *spec.OpenCLISpec, writer io.Writer) error {
+	w := bufio.NewWriter(writer)
+
+	fmt.Fprintf(w, "# %s\n\n", spec.Info.Title)
+	if spec.Info.Description != "" {
+		fmt.Fprintf(w, "%s\n\n", spec.Info.Description)
+	}
+	if spec.Info.Version != "" {
+		fmt.Fprintf(w, "Version: %s\n\n", spec.Info.Version)
+	}
+
+	link := func(cmd *docCommand) string { return "#" + markdownAnchor(cmd.Path) }
+	for _, cmd := range docTree(spec) {
+		g.writeCommand(w, cmd, 2, link)
+	}
+	return w.Flush()
+}
+
+// GenerateToString returns the single-document Markdown as a string
+func (g *MarkdownGenerator) GenerateToString(spec *spec.OpenCLISpec) (string, error) {
+	var b strings.Builder
+	if err := g.Generate(spec, &b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// GeneratePages writes one Markdown page per command into dir, named after
+// the command path such as app_user_create.md, and returns the written paths
+func (g *MarkdownGenerator) GeneratePages(spec *spec.OpenCLISpec, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	link := func(cmd *docCommand) string { return markdownPageName(cmd) }
+	paths := make([]string, 0)
+	for _, cmd := range docTree(spec) {
+		path := filepath.Join(dir, markdownPageName(cmd))
+		file, err := os.Create(path)
+		if err != nil {
+			return paths, fmt.Errorf("failed to create %s: %w", path, err)
+		}
+
+		w := bufio.NewWriter(file)
+		g.writeCommand(w, cmd, 1, link)
+		err = w.Flush()
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return paths, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// writeCommand writes the documentation of one command. level is the heading
+// level of the command title; link returns the target of a cross-link.
+func (g *MarkdownGenerator) writeCommand(w io.Writer, cmd *docCommand, level int, link func(*docCommand) string) {
+	heading := strings.Repeat("#", level)
+	sub := heading + "#"
+
+	fmt.Fprintf(w, "%s %s\n\n", heading, cmd.Path)
+	if cmd.Command.Deprecated {
+		fmt.Fprint(w, "> **Deprecated:** this command is deprecated and may be removed in a future version.\n\n")
+	}
+	if cmd.Command.Summary != "" {
+		fmt.Fprintf(w, "%s\n\n", cmd.Command.Summary)
+	}
+
+	fmt.Fprintf(w, "%s Synopsis\n\n", sub)
+	if cmd.Command.Description != "" && cmd.Command.Description != cmd.Command.Summary {
+		fmt.Fprintf(w, "%s\n\n", cmd.Command.Description)
+	}
+	fmt.Fprintf(w, "```\n%s\n```\n\n", cmd.synopsis())
+
+	if len(cmd.Command.Aliases) > 0 {
+		fmt.Fprintf(w, "%s Aliases\n\n", sub)
+		aliases := make([]string, len(cmd.Command.Aliases))
+		for i, alias := range cmd.Command.Aliases {
+			aliases[i] = "`" + alias + "`"
+		}
+		fmt.Fprintf(w, "%s\n\n", strings.Join(aliases, ", "))
+	}
+
+	if examples := cmd.examples(); examples != "" {
+		fmt.Fprintf(w, "%s Examples\n\n```\n%s\n```\n\n", sub, examples)
+	}
+
+	if args := cmd.arguments(); len(args) > 0 {
+		fmt.Fprintf(w, "%s Arguments\n\n", sub)
+		fmt.Fprintln(w, "| Argument | Type | Required | Description |")
+		fmt.Fprintln(w, "|----------|------|----------|-------------|")
+		for _, arg := range args {
+			required := "no"
+			if arg.Required {
+				required = "yes"
+			}
+			fmt.Fprintf(w, "| `%s` | %s | %s | %s |\n", arg.Name, paramType(arg), required, markdownCell(paramDescription(arg)))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if flags := cmd.flags(); len(flags) > 0 {
+		fmt.Fprintf(w, "%s Options\n\n", sub)
+		writeFlagTable(w, flags)
+	}
+	if flags := cmd.inheritedFlags(); len(flags) > 0 {
+		fmt.Fprintf(w, "%s Options inherited from parent commands\n\n", sub)
+		writeFlagTable(w, flags)
+	}
+	if len(cmd.Command.FlagGroups) > 0 {
+		fmt.Fprintf(w, "%s Flag constraints\n\n", sub)
+		for _, group := range cmd.Command.FlagGroups {
+			fmt.Fprintf(w, "* %s\n", flagGroupText(group))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if cmd.Parent != nil || len(cmd.Children) > 0 {
+		fmt.Fprintf(w, "%s See also\n\n", sub)
+		if cmd.Parent != nil {
+			fmt.Fprintf(w, "* [%s](%s) - %s\n", cmd.Parent.Path, link(cmd.Parent), cmd.Parent.Command.Summary)
+		}
+		for _, child := range cmd.Children {
+			fmt.Fprintf(w, "* [%s](%s) - %s\n", child.Path, link(child), child.Command.Summary)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// writeFlagTable writes flags as a Markdown table
+func writeFlagTable(w io.Writer, flags []spec.Parameter) {
+	fmt.Fprintln(w, "| Flag | Type | Default | Description |")
+	fmt.Fprintln(w, "|------|------|---------|-------------|")
+	for _, flag := range flags {
+		names := flagNames(flag)
+		for i, name := range names {
+			names[i] = "`" + name + "`"
+		}
+		defaultValue := paramDefault(flag)
+		if defaultValue != "" {
+			defaultValue = "`" + defaultValue + "`"
+		}
+		description := paramDescription(flag)
+		if flag.Required {
+			description = strings.TrimSpace(description + " (required)")
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n", strings.Join(names, ", "), paramType(flag), defaultValue, markdownCell(description))
+	}
+	fmt.Fprintln(w)
+}
+
+// flagGroupText describes a flag group in a sentence
+func flagGroupText(group spec.FlagGroup) string {
+	names := make([]string, len(group.Flags))
+	for i, name := range group.Flags {
+		names[i] = "`--" + name + "`"
+	}
+	list := strings.Join(names, ", ")
+	switch group.Kind {
+	case spec.FlagGroupMutuallyExclusive:
+		return "Only one of " + list + " can be used"
+	case spec.FlagGroupRequiredTogether:
+		return list + " must be used together"
+	case spec.FlagGroupOneRequired:
+		return "At least one of " + list + " is required"
+	}
+	return group.Kind + ": " + list
+}
+
+// markdownPageName returns the file name of a command's page
+func markdownPageName(cmd *docCommand) string {
+	return strings.ReplaceAll(cmd.Path, " ", "_") + ".md"
+}
+
+// markdownAnchor returns the anchor GitHub generates for a heading
+func markdownAnchor(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || ('a' <= r && r <= 'z') || ('0' <= r && r <= '9'):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// markdownCell keeps text inside a table cell
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(s)
+}
@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+func docSpec() *spec.OpenCLISpec {
+	return &spec.OpenCLISpec{
+		OpenCLI: "1.0.0",
+		Info:    spec.Info{Title: "App CLI", Description: "Manages apps", Version: "1.2.0"},
+		Commands: map[string]spec.Command{
+			"app": {
+				Summary: "App CLI",
+				Parameters: []spec.Parameter{
+					{Name: "config", In: "flag", Scope: "inherited", Description: "Config file", Schema: &spec.Schema{Type: "string"}},
+				},
+				Extensions: map[string]interface{}{spec.ExtensionUsage: "app"},
+			},
+			"/app/user": {
+				Summary: "Manage users",
+			},
+			"/app/user/create": {
+				Summary:     "Create a user",
+				Description: "Create a user account.",
+				Aliases:     []string{"add", "new"},
+				Deprecated:  true,
+				Parameters: []spec.Parameter{
+					{Name: "role", In: "flag", Scope: "local", Alias: []string{"r"}, Description: "Role | group",
+						Schema: &spec.Schema{Type: "string", Default: "member", Enum: []interface{}{"member", "admin"}}},
+					{Name: "secret", In: "flag", Hidden: true},
+					{Name: "name", In: "argument", Position: 1, Required: true, Description: "User name"},
+				},
+				FlagGroups: []spec.FlagGroup{{Kind: spec.FlagGroupOneRequired, Flags: []string{"role", "config"}}},
+				Extensions: map[string]interface{}{
+					spec.ExtensionUsage:    "create <name>",
+					spec.ExtensionExamples: "  app user create alice --role admin",
+				},
+			},
+			"/app/internal": {Summary: "Internal", Hidden: true},
+		},
+	}
+}
+
+func TestMarkdownGenerator_Generate(t *testing.T) {
+	out, err := NewMarkdownGenerator().GenerateToString(docSpec())
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"# App CLI\n\nManages apps\n\nVersion: 1.2.0\n",
+		"## app user create\n\n> **Deprecated:**",
+		"```\napp user create <name> [flags]\n```",
+		"### Aliases\n\n`add`, `new`",
+		"### Examples\n\n```\napp user create alice --role admin\n```",
+		"| `name` |  | yes | User name |",
+		"| `-r`, `--role` | string | `member` | Role \\| group (one of: member, admin) |",
+		"### Options inherited from parent commands\n\n| Flag | Type | Default | Description |\n|------|------|---------|-------------|\n| `--config` | string |  | Config file |",
+		"### Flag constraints\n\n* At least one of `--role`, `--config` is required\n",
+		"* [app user](#app-user) - Manage users",
+		"* [app user create](#app-user-create) - Create a user",
+		"```\napp user [command] [flags]\n```",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	for _, unwanted := range []string{"secret", "internal"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("Expected hidden %q to be omitted", unwanted)
+		}
+	}
+
+	// Parents come before their children
+	if strings.Index(out, "## app\n") > strings.Index(out, "## app user\n") {
+		t.Error("Expected root command first")
+	}
+}
+
+func TestMarkdownGenerator_GeneratePages(t *testing.T) {
+	dir := t.TempDir()
+	paths, err := NewMarkdownGenerator().GeneratePages(docSpec(), dir)
+	if err != nil {
+		t.Fatalf("Failed to generate pages: %v", err)
+	}
+
+	want := []string{"app.md", "app_user.md", "app_user_create.md"}
+	if len(paths) != len(want) {
+		t.Fatalf("Expected %d pages, got %v", len(want), paths)
+	}
+	for i, name := range want {
+		if paths[i] != filepath.Join(dir, name) {
+			t.Errorf("Expected page %s, got %s", name, paths[i])
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "app_user.md"))
+	if err != nil {
+		t.Fatalf("Failed to read page: %v", err)
+	}
+	page := string(data)
+	if !strings.HasPrefix(page, "# app user\n") {
+		t.Errorf("Expected page title, got:\n%s", page)
+	}
+	for _, link := range []string{"* [app](app.md) - App CLI", "* [app user create](app_user_create.md) - Create a user"} {
+		if !strings.Contains(page, link) {
+			t.Errorf("Expected link %q, got:\n%s", link, page)
+		}
+	}
+}
+
+func TestMarkdownGenerator_ArrayEnum(t *testing.T) {
+	s := docSpec()
+	create := s.Commands["/app/user/create"]
+	create.Parameters = append(create.Parameters, spec.Parameter{Name: "tag", In: "flag", Scope: "local", Description: "Tags",
+		Schema: &spec.Schema{Type: "array", Items: &spec.Schema{Type: "string", Enum: []interface{}{"blue", "green"}}}})
+	s.Commands["/app/user/create"] = create
+
+	out, err := NewMarkdownGenerator().GenerateToString(s)
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	if want := "| `--tag` | string[] |  | Tags (one of: blue, green) |"; !strings.Contains(out, want) {
+		t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+	}
+
+	dir := t.TempDir()
+	if _, err := NewManGenerator().GeneratePages(s, dir); err != nil {
+		t.Fatalf("Failed to generate pages: %v", err)
+	}
+	man, err := os.ReadFile(filepath.Join(dir, "app-user-create.1"))
+	if err != nil {
+		t.Fatalf("Failed to read page: %v", err)
+	}
+	if want := "Tags (one of: blue, green)\n"; !strings.Contains(string(man), want) {
+		t.Errorf("Expected man page to contain %q, got:\n%s", want, man)
+	}
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/mcp"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// ToolFormat is a layout of tool definitions
+type ToolFormat string
+
+// Supported tool formats
+const (
+	// ToolFormatOpenAI is a list of function tools for the OpenAI API
+	ToolFormatOpenAI ToolFormat = "openai"
+	// ToolFormatAnthropic is a list of tools for the Anthropic API
+	ToolFormatAnthropic ToolFormat = "anthropic"
+	// ToolFormatMCP is the result of an MCP tools/list request
+	ToolFormatMCP ToolFormat = "mcp"
+)
+
+// ToolFormats lists the supported tool formats
+var ToolFormats = []ToolFormat{ToolFormatOpenAI, ToolFormatAnthropic, ToolFormatMCP}
+
+// openAITool is a function tool of the OpenAI API
+type openAITool struct {
+	Type     string         `json:"type"`
+	Function openAIFunction `json:"function"`
+}
+
+// openAIFunction is the function of an openAITool
+type openAIFunction struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+// anthropicTool is a tool of the Anthropic API
+type anthropicTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+// ToolsGenerator generates tool definitions letting language models call
+// the runnable commands of a CLI
+type ToolsGenerator struct {
+	format ToolFormat
+}
+
+// NewToolsGenerator creates a tools generator for format
+func NewToolsGenerator(format ToolFormat) *ToolsGenerator {
+	return &ToolsGenerator{format: format}
+}
+
+// Generate writes the tool definitions as JSON to the writer
+func (g *ToolsGenerator) Generate(spec *spec.OpenCLISpec, writer io.Writer) error {
+	tools := mcp.NewToolset(spec).Tools()
+
+	var doc interface{}
+	switch g.format {
+	case ToolFormatOpenAI:
+		functions := make([]openAITool, len(tools))
+		for i, tool := range tools {
+			functions[i] = openAITool{Type: "function", Function: openAIFunction{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.InputSchema,
+			}}
+		}
+		doc = functions
+	case ToolFormatAnthropic:
+		definitions := make([]anthropicTool, len(tools))
+		for i, tool := range tools {
+			definitions[i] = anthropicTool{
+				Name:        tool.Name,
+				Description: tool.Description,
+				InputSchema: tool.InputSchema,
+			}
+		}
+		doc = definitions
+	case ToolFormatMCP:
+		doc = map[string]interface{}{"tools": tools}
+	default:
+		names := make([]string, len(ToolFormats))
+		for i, f := range ToolFormats {
+			names[i] = string(f)
+		}
+		return fmt.Errorf("unsupported tool format %q (expected one of %s)", g.format, strings.Join(names, ", "))
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// GenerateToString returns the tool definitions as a JSON string
+func (g *ToolsGenerator) GenerateToString(spec *spec.OpenCLISpec) (string, error) {
+	var b strings.Builder
+	if err := g.Generate(spec, &b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+func TestToolsGenerator(t *testing.T) {
+	s := &spec.OpenCLISpec{
+		OpenCLI: "1.0.0",
+		Info:    spec.Info{Title: "app", Version: "1.0.0"},
+		Commands: map[string]spec.Command{
+			"app": {Summary: "App"},
+			"/app/ping": {
+				Summary: "Ping a host",
+				Parameters: []spec.Parameter{
+					{Name: "count", In: "flag", Schema: &spec.Schema{Type: "integer", Default: 3}},
+					{Name: "host", In: "argument", Position: 1, Required: true},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		format ToolFormat
+		want   []string
+	}{
+		{ToolFormatOpenAI, []string{`"type": "function"`, `"function": {`, `"name": "app_ping"`, `"parameters": {`}},
+		{ToolFormatAnthropic, []string{`"name": "app_ping"`, `"description": "Ping a host"`, `"input_schema": {`}},
+		{ToolFormatMCP, []string{`"tools": [`, `"name": "app_ping"`, `"inputSchema": {`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			out, err := NewToolsGenerator(tt.format).GenerateToString(s)
+			if err != nil {
+				t.Fatalf("Failed to generate tools: %v", err)
+			}
+			if !json.Valid([]byte(out)) {
+				t.Fatalf("Expected valid JSON, got:\n%s", out)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+				}
+			}
+			// The root has subcommands, so it is not a tool
+			if strings.Contains(out, `"name": "app"`) {
+				t.Errorf("Expected root command to be left out, got:\n%s", out)
+			}
+		})
+	}
+
+	if _, err := NewToolsGenerator("xml").GenerateToString(s); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,445 @@
+package harness
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	goparser "go/parser"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/converter"
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+	"golang.org/x/mod/modfile"
+)
+
+// GospecModule is the module path of this library, which the harness imports
+const GospecModule = "github.com/harihs-330/gospec-cli"
+
+// harnessModule is the module path of the generated throwaway module
+const harnessModule = "gospec-harness"
+
+// Options controls how the harness is built and run
+type Options struct {
+	// Dir is the directory of the Go package exposing the root command
+	Dir string
+
+	// RootFunc is the exported function returning the root command, or in
+	// a main package any function or *cobra.Command variable providing it.
+	// It is auto-detected when empty.
+	RootFunc string
+
+	// Framework is the CLI framework of the target ("cobra" if empty)
+	Framework string
+
+	// GospecDir points at a local gospec-cli checkout to build against.
+	// When empty the module is located through the target's dependencies
+	// or the running binary's build info.
+	GospecDir string
+
+	// Convert holds the conversion options passed to the harness
+	Convert *parser.ConvertOptions
+
+	// Timeout bounds building and running the harness (default 5 minutes)
+	Timeout time.Duration
+
+	// Stderr receives the output of the go tool and the harness, if set
+	Stderr io.Writer
+
+	// KeepWorkDir leaves the temporary module on disk for debugging
+	KeepWorkDir bool
+}
+
+// Generate builds a temporary program that imports the target package,
+// calls its root command function and returns the resulting specification
+func Generate(ctx context.Context, opts *Options) (*spec.OpenCLISpec, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("harness options are nil")
+	}
+
+	framework := opts.Framework
+	if framework == "" {
+		framework = "cobra"
+	}
+	if framework != "cobra" {
+		return nil, fmt.Errorf("framework %q is not supported by the harness", framework)
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	target, err := Resolve(opts.Dir, opts.RootFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	gospecDir, gospecVersion, err := locateGospec(ctx, target, opts.GospecDir)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := os.MkdirTemp("", "gospec-harness-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work directory: %w", err)
+	}
+	if opts.KeepWorkDir {
+		fmt.Fprintf(stderrOf(opts), "harness work directory: %s\n", workDir)
+	} else {
+		defer os.RemoveAll(workDir)
+	}
+
+	if err := writeModule(workDir, target, gospecDir, gospecVersion); err != nil {
+		return nil, err
+	}
+
+	convertOptions := opts.Convert
+	if convertOptions == nil {
+		convertOptions = converter.DefaultConvertOptions()
+	}
+	optionsPath := filepath.Join(workDir, "options.json")
+	data, err := json.Marshal(convertOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode convert options: %w", err)
+	}
+	if err := os.WriteFile(optionsPath, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write convert options: %w", err)
+	}
+
+	if err := goCommand(ctx, workDir, opts, "mod", "tidy"); err != nil {
+		return nil, fmt.Errorf("failed to resolve harness dependencies: %w", err)
+	}
+
+	binPath := filepath.Join(workDir, "harness")
+	if err := goCommand(ctx, workDir, opts, "build", "-o", binPath, "."); err != nil {
+		return nil, fmt.Errorf("failed to build harness for %s: %w", target.ImportPath, err)
+	}
+
+	// The spec is written to a file rather than stdout so that anything the
+	// target prints from init() cannot corrupt it
+	specPath := filepath.Join(workDir, "spec.json")
+	run := exec.CommandContext(ctx, binPath, optionsPath, specPath)
+	run.Dir = target.PackageDir
+	run.Stdout = stderrOf(opts)
+	var runErr bytes.Buffer
+	run.Stderr = io.MultiWriter(&runErr, stderrOf(opts))
+	if err := run.Run(); err != nil {
+		return nil, fmt.Errorf("harness failed: %w: %s", err, strings.TrimSpace(runErr.String()))
+	}
+
+	out, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read harness output: %w", err)
+	}
+
+	var result spec.OpenCLISpec
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode harness output: %w", err)
+	}
+
+	return &result, nil
+}
+
+// writeModule writes go.mod, go.sum and main.go for the harness
+func writeModule(workDir string, target *Target, gospecDir, gospecVersion string) error {
+	mod := &modfile.File{}
+	if err := mod.AddModuleStmt(harnessModule); err != nil {
+		return err
+	}
+	goVersion := target.GoVersion
+	if goVersion == "" {
+		goVersion = "1.21"
+	}
+	if err := mod.AddGoStmt(goVersion); err != nil {
+		return err
+	}
+
+	const placeholder = "v0.0.0-00010101000000-000000000000"
+
+	// Carry over the target's own replacements; they only apply in the main module
+	for _, rep := range target.Replaces {
+		if rep.Old.Path == GospecModule && (gospecDir != "" || gospecVersion != "") {
+			continue
+		}
+		if err := mod.AddReplace(rep.Old.Path, rep.Old.Version, rep.New.Path, rep.New.Version); err != nil {
+			return err
+		}
+	}
+
+	if err := mod.AddRequire(target.ModulePath, placeholder); err != nil {
+		return err
+	}
+	if err := mod.AddReplace(target.ModulePath, "", target.ModuleDir, ""); err != nil {
+		return err
+	}
+
+	if target.ModulePath != GospecModule {
+		switch {
+		case gospecDir != "":
+			if err := mod.AddRequire(GospecModule, placeholder); err != nil {
+				return err
+			}
+			if err := mod.AddReplace(GospecModule, "", gospecDir, ""); err != nil {
+				return err
+			}
+		case gospecVersion != "":
+			if err := mod.AddRequire(GospecModule, gospecVersion); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := mod.Format()
+	if err != nil {
+		return fmt.Errorf("failed to format harness go.mod: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "go.mod"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write harness go.mod: %w", err)
+	}
+
+	// Seed go.sum from the target so tidy rarely needs the network
+	if sum, err := os.ReadFile(filepath.Join(target.ModuleDir, "go.sum")); err == nil {
+		if err := os.WriteFile(filepath.Join(workDir, "go.sum"), sum, 0644); err != nil {
+			return fmt.Errorf("failed to write harness go.sum: %w", err)
+		}
+	}
+
+	// A main package cannot be imported, so it is compiled into the harness
+	tmpl, name := mainTemplate, "main.go"
+	if target.PackageName == "main" {
+		if err := copyMainPackage(workDir, target.PackageDir); err != nil {
+			return err
+		}
+		tmpl, name = mainPackageTemplate, harnessFile
+	}
+
+	var src bytes.Buffer
+	if err := tmpl.Execute(&src, target); err != nil {
+		return fmt.Errorf("failed to render harness: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, name), src.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write harness: %w", err)
+	}
+
+	return nil
+}
+
+// harnessFile is the file the harness adds to a copied main package
+const harnessFile = "gospec_harness.go"
+
+// targetMain is what the main function of a copied main package is renamed
+// to, so that the harness can provide its own
+const targetMain = "gospecTargetMain"
+
+// copyMainPackage copies the files of the main package in dir into the
+// harness module, renaming its main function. Other files of the directory
+// are copied along for go:embed; subdirectories are not.
+func copyMainPackage(workDir, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read main package: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || strings.HasSuffix(name, "_test.go") || name == harnessFile {
+			continue
+		}
+		switch name {
+		case "go.mod", "go.sum", "go.work", "go.work.sum":
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if strings.HasSuffix(name, ".go") {
+			if data, err = renameMain(name, data); err != nil {
+				return err
+			}
+		}
+		if err := os.WriteFile(filepath.Join(workDir, name), data, 0644); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// renameMain renames the main function of a Go file of package main
+func renameMain(name string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, name, src, goparser.ParseComments|goparser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	renamed := false
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+			fn.Name.Name = targetMain
+			renamed = true
+		}
+	}
+	if !renamed {
+		return src, nil
+	}
+	var out bytes.Buffer
+	if err := format.Node(&out, fset, file); err != nil {
+		return nil, fmt.Errorf("failed to rewrite %s: %w", name, err)
+	}
+	return out.Bytes(), nil
+}
+
+// locateGospec finds the gospec-cli module the harness should build against.
+// It returns either a local directory or a module version.
+func locateGospec(ctx context.Context, target *Target, override string) (dir, version string, err error) {
+	if override != "" {
+		abs, err := filepath.Abs(override)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid gospec-cli path: %w", err)
+		}
+		return abs, "", nil
+	}
+
+	// Prefer whatever copy the target already depends on
+	list := exec.CommandContext(ctx, "go", "list", "-m", "-json", GospecModule)
+	list.Dir = target.ModuleDir
+	if out, err := list.Output(); err == nil {
+		var m struct {
+			Dir string
+		}
+		if err := json.Unmarshal(out, &m); err == nil && m.Dir != "" {
+			return m.Dir, "", nil
+		}
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Path == GospecModule {
+		v := info.Main.Version
+		if v != "" && v != "(devel)" && !strings.Contains(v, "+dirty") {
+			return "", v, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("cannot locate the %s module for %s; pass a local checkout with --gospec-path", GospecModule, target.ModulePath)
+}
+
+// goCommand runs the go tool inside the harness module
+func goCommand(ctx context.Context, dir string, opts *Options, args ...string) error {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+	var output bytes.Buffer
+	cmd.Stdout = io.MultiWriter(&output, stderrOf(opts))
+	cmd.Stderr = io.MultiWriter(&output, stderrOf(opts))
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+func stderrOf(opts *Options) io.Writer {
+	if opts.Stderr != nil {
+		return opts.Stderr
+	}
+	return io.Discard
+}
+
+var mainTemplate = template.Must(template.New("main").Parse(`// Code generated by gospec-cli. DO NOT EDIT.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	gospec "github.com/harihs-330/gospec-cli"
+	target "{{.ImportPath}}"
+)
+
+func main() {
+	options := gospec.DefaultOptions()
+	data, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		fail(err)
+	}
+	if err := json.Unmarshal(data, options); err != nil {
+		fail(err)
+	}
+
+	result, err := gospec.New().Convert(target.{{.RootFunc}}(), options)
+	if err != nil {
+		fail(err)
+	}
+
+	out, err := json.Marshal(result)
+	if err != nil {
+		fail(err)
+	}
+	if err := os.WriteFile(os.Args[2], out, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+`))
+
+// mainPackageTemplate is added to a copied main package. Its imports are
+// renamed so that they cannot clash with declarations of the package.
+var mainPackageTemplate = template.Must(template.New("main").Parse(`// Code generated by gospec-cli. DO NOT EDIT.
+
+package main
+
+import (
+	gospecjson "encoding/json"
+	gospecfmt "fmt"
+	gospecos "os"
+
+	gospeclib "github.com/harihs-330/gospec-cli"
+)
+
+func main() {
+	options := gospeclib.DefaultOptions()
+	data, err := gospecos.ReadFile(gospecos.Args[1])
+	if err != nil {
+		gospecFail(err)
+	}
+	if err := gospecjson.Unmarshal(data, options); err != nil {
+		gospecFail(err)
+	}
+
+	result, err := gospeclib.New().Convert({{.RootFunc}}{{if not .RootVar}}(){{end}}, options)
+	if err != nil {
+		gospecFail(err)
+	}
+
+	out, err := gospecjson.Marshal(result)
+	if err != nil {
+		gospecFail(err)
+	}
+	if err := gospecos.WriteFile(gospecos.Args[2], out, 0644); err != nil {
+		gospecFail(err)
+	}
+}
+
+func gospecFail(err error) {
+	gospecfmt.Fprintln(gospecos.Stderr, err)
+	gospecos.Exit(1)
+}
+`))
@@ -0,0 +1,183 @@
+package harness
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const appSource = `package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	// Output of the target must not end up in the spec
+	fmt.Println("loading app")
+}
+
+func NewRootCmd() *cobra.Command {
+	root := &cobra.Command{Use: "app", Short: "Example app"}
+	root.PersistentFlags().Bool("debug", false, "Enable debug output")
+
+	create := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create a user",
+		Args:  cobra.ExactArgs(1),
+		Run:   func(*cobra.Command, []string) {},
+	}
+	create.Flags().Int("age", 0, "Age of the user")
+	root.AddCommand(create)
+	return root
+}
+`
+
+const mainSource = `package main
+
+import (
+	_ "embed"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+//go:embed usage.txt
+var usage string
+
+var rootCmd = &cobra.Command{Use: "tool", Short: usage}
+
+func init() {
+	rootCmd.AddCommand(&cobra.Command{Use: "serve", Short: "Serve", Run: func(*cobra.Command, []string) {}})
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+`
+
+// writeAppModule writes a module depending on cobra, with the go.sum of this
+// repository so that it builds without the network
+func writeAppModule(t *testing.T) string {
+	t.Helper()
+	sum, err := os.ReadFile(filepath.Join("..", "..", "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                "module example.com/app\n\ngo 1.22\n\nrequire github.com/spf13/cobra v1.10.2\n",
+		"go.sum":                string(sum),
+		"cmd/cmd.go":            appSource,
+		"cmd/tool/main.go":      mainSource,
+		"cmd/tool/usage.txt":    "Example tool",
+		"cmd/tool/main_test.go": "package main\n\nfunc broken( {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs a temporary module")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go tool not available")
+	}
+
+	gospecDir, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := writeAppModule(t)
+
+	result, err := Generate(context.Background(), &Options{
+		Dir:       filepath.Join(dir, "cmd"),
+		GospecDir: gospecDir,
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if result.Info.Title != "app" {
+		t.Errorf("Expected title 'app', got '%s'", result.Info.Title)
+	}
+	create, ok := result.Commands["/app/create"]
+	if !ok {
+		t.Fatalf("Expected command /app/create, got %v", result.Commands)
+	}
+	if create.Summary != "Create a user" {
+		t.Errorf("Expected summary 'Create a user', got '%s'", create.Summary)
+	}
+	var names []string
+	for _, param := range create.Parameters {
+		names = append(names, param.In+":"+param.Name)
+	}
+	if got := strings.Join(names, ","); !strings.Contains(got, "flag:age") {
+		t.Errorf("Expected flag age on /app/create, got %s", got)
+	}
+}
+
+func TestGenerate_MainPackage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs a temporary module")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go tool not available")
+	}
+
+	gospecDir, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := writeAppModule(t)
+
+	result, err := Generate(context.Background(), &Options{
+		Dir:       filepath.Join(dir, "cmd", "tool"),
+		GospecDir: gospecDir,
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if root := result.Commands["tool"]; result.Info.Title != "tool" || root.Summary != "Example tool" {
+		t.Errorf("Expected tool summarized by the embedded usage, got %+v %+v", result.Info, root)
+	}
+	if _, ok := result.Commands["/tool/serve"]; !ok {
+		t.Errorf("Expected command /tool/serve added in init, got %v", result.Commands)
+	}
+}
+
+func TestGenerate_UnsupportedFramework(t *testing.T) {
+	_, err := Generate(context.Background(), &Options{Dir: ".", Framework: "urfave-cli"})
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("Expected unsupported framework error, got %v", err)
+	}
+}
+
+func TestLocateGospec_NamesFlag(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go tool not available")
+	}
+	target := &Target{ModulePath: "example.com/app", ModuleDir: t.TempDir()}
+
+	_, _, err := locateGospec(context.Background(), target, "")
+	if err == nil || !strings.Contains(err.Error(), "--gospec-path") {
+		t.Errorf("Expected error naming --gospec-path, got %v", err)
+	}
+}
@@ -0,0 +1,320 @@
+package harness
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// cobraImportPath is the import path whose Command type marks a root function
+const cobraImportPath = "github.com/spf13/cobra"
+
+// preferredRootFuncs are tried in order when a package exports several candidates
+var preferredRootFuncs = []string{"GetRootCmd", "NewRootCmd", "RootCmd", "NewRootCommand", "RootCommand", "GetRootCommand", "newRootCmd", "rootCmd"}
+
+// Target describes the package the harness imports
+type Target struct {
+	ModulePath  string
+	ModuleDir   string
+	GoVersion   string
+	ImportPath  string
+	PackageDir  string
+	PackageName string
+	RootFunc    string
+
+	// RootVar is set when RootFunc names a *cobra.Command variable of a
+	// main package rather than a function
+	RootVar bool
+
+	// Replaces are the target module's replace directives with local paths made absolute
+	Replaces []*modfile.Replace
+}
+
+// Resolve locates the module containing dir and determines the import path
+// and root command function of the package in it
+func Resolve(dir, rootFunc string) (*Target, error) {
+	if dir == "" {
+		dir = "."
+	}
+	pkgDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input path: %w", err)
+	}
+
+	moduleDir, err := findModuleRoot(pkgDir)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(moduleDir, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	mod, err := modfile.Parse(filepath.Join(moduleDir, "go.mod"), data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	if mod.Module == nil {
+		return nil, fmt.Errorf("%s/go.mod has no module directive", moduleDir)
+	}
+
+	rel, err := filepath.Rel(moduleDir, pkgDir)
+	if err != nil {
+		return nil, err
+	}
+	importPath := mod.Module.Mod.Path
+	if rel != "." {
+		importPath += "/" + filepath.ToSlash(rel)
+	}
+
+	target := &Target{
+		ModulePath: mod.Module.Mod.Path,
+		ModuleDir:  moduleDir,
+		ImportPath: importPath,
+		PackageDir: pkgDir,
+	}
+	if mod.Go != nil {
+		target.GoVersion = mod.Go.Version
+	}
+	for _, rep := range mod.Replace {
+		newRep := *rep
+		if modfile.IsDirectoryPath(rep.New.Path) && !filepath.IsAbs(rep.New.Path) {
+			newRep.New.Path = filepath.Join(moduleDir, rep.New.Path)
+		}
+		target.Replaces = append(target.Replaces, &newRep)
+	}
+
+	pkgName, roots, err := findRoots(pkgDir)
+	if err != nil {
+		return nil, err
+	}
+	target.PackageName = pkgName
+
+	if rootFunc != "" {
+		for _, root := range roots {
+			if root.name == rootFunc {
+				target.RootFunc, target.RootVar = root.name, root.isVar
+				return target, nil
+			}
+		}
+		return nil, fmt.Errorf("function %s() *cobra.Command not found in %s", rootFunc, importPath)
+	}
+
+	target.RootFunc, err = chooseRootFunc(rootNames(roots))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", importPath, err)
+	}
+	for _, root := range roots {
+		if root.name == target.RootFunc {
+			target.RootVar = root.isVar
+		}
+	}
+	return target, nil
+}
+
+// rootCandidate is a function or, in main packages, a variable providing
+// the root command
+type rootCandidate struct {
+	name  string
+	isVar bool
+}
+
+func rootNames(roots []rootCandidate) []string {
+	names := make([]string, len(roots))
+	for i, root := range roots {
+		names[i] = root.name
+	}
+	return names
+}
+
+// FindRootFuncs returns the package name and the exported functions in dir
+// that take no arguments and return a single *cobra.Command. The harness
+// compiles main packages into itself, so for them unexported functions and
+// package-level *cobra.Command variables are returned too. Files excluded
+// by build constraints and external test packages are ignored.
+func FindRootFuncs(dir string) (string, []string, error) {
+	pkgName, roots, err := findRoots(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	return pkgName, rootNames(roots), nil
+}
+
+// findRoots implements FindRootFuncs
+func findRoots(dir string) (string, []rootCandidate, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		if strings.HasSuffix(info.Name(), "_test.go") {
+			return false
+		}
+		match, err := build.Default.MatchFile(dir, info.Name())
+		return err == nil && match
+	}, parser.SkipObjectResolution)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse package: %w", err)
+	}
+
+	names := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		if !strings.HasSuffix(name, "_test") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	switch len(names) {
+	case 0:
+		return "", nil, fmt.Errorf("no Go package found in %s", dir)
+	case 1:
+	default:
+		return "", nil, fmt.Errorf("multiple packages found in %s (%s)", dir, strings.Join(names, ", "))
+	}
+
+	pkgName := names[0]
+	isMain := pkgName == "main"
+	var roots []rootCandidate
+	for _, file := range pkgs[pkgName].Files {
+		cobraName := importName(file, cobraImportPath)
+		if cobraName == "" {
+			continue
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv != nil || (!isMain && !decl.Name.IsExported()) || decl.Name.Name == "main" {
+					continue
+				}
+				if isRootFunc(decl.Type, cobraName) {
+					roots = append(roots, rootCandidate{name: decl.Name.Name})
+				}
+			case *ast.GenDecl:
+				if !isMain || decl.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					for _, name := range rootVars(spec.(*ast.ValueSpec), cobraName) {
+						roots = append(roots, rootCandidate{name: name, isVar: true})
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(roots, func(i, j int) bool { return roots[i].name < roots[j].name })
+	return pkgName, roots, nil
+}
+
+// rootVars returns the variables of a declaration that are declared as
+// *cobra.Command or initialized with &cobra.Command{...}
+func rootVars(spec *ast.ValueSpec, cobraName string) []string {
+	names := make([]string, 0)
+	for i, name := range spec.Names {
+		if name.Name == "_" {
+			continue
+		}
+		if spec.Type != nil {
+			if isCommandPointer(spec.Type, cobraName) {
+				names = append(names, name.Name)
+			}
+			continue
+		}
+		if len(spec.Values) != len(spec.Names) {
+			continue
+		}
+		unary, ok := spec.Values[i].(*ast.UnaryExpr)
+		if !ok || unary.Op != token.AND {
+			continue
+		}
+		if lit, ok := unary.X.(*ast.CompositeLit); ok && isCommandType(lit.Type, cobraName) {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// chooseRootFunc picks the root function among several candidates
+func chooseRootFunc(candidates []string) (string, error) {
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("no exported function returning *cobra.Command found")
+	case 1:
+		return candidates[0], nil
+	}
+
+	for _, preferred := range preferredRootFuncs {
+		for _, name := range candidates {
+			if name == preferred {
+				return name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("multiple root command functions found (%s); specify one explicitly", strings.Join(candidates, ", "))
+}
+
+// isRootFunc reports whether a function type is func() *cobra.Command
+func isRootFunc(fnType *ast.FuncType, cobraName string) bool {
+	if fnType.TypeParams != nil && len(fnType.TypeParams.List) > 0 {
+		return false
+	}
+	if fnType.Params != nil && len(fnType.Params.List) > 0 {
+		return false
+	}
+	if fnType.Results == nil || len(fnType.Results.List) != 1 || len(fnType.Results.List[0].Names) > 1 {
+		return false
+	}
+
+	return isCommandPointer(fnType.Results.List[0].Type, cobraName)
+}
+
+// isCommandPointer reports whether expr is the type *cobra.Command
+func isCommandPointer(expr ast.Expr, cobraName string) bool {
+	star, ok := expr.(*ast.StarExpr)
+	return ok && isCommandType(star.X, cobraName)
+}
+
+// isCommandType reports whether expr is the type cobra.Command
+func isCommandType(expr ast.Expr, cobraName string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Command" {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == cobraName
+}
+
+// importName returns the local name under which file imports path, or ""
+func importName(file *ast.File, path string) string {
+	for _, imp := range file.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || p != path {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+		return p[strings.LastIndex(p, "/")+1:]
+	}
+	return ""
+}
+
+// findModuleRoot walks up from dir to the directory containing go.mod
+func findModuleRoot(dir string) (string, error) {
+	for current := dir; ; {
+		if info, err := os.Stat(filepath.Join(current, "go.mod")); err == nil && !info.IsDir() {
+			return current, nil
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", fmt.Errorf("no go.mod found in %s or any parent directory", dir)
+		}
+		current = parent
+	}
+}
@@ -0,0 +1,150 @@
+package harness
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const rootSource = `package cmd
+
+import (
+	cc "github.com/spf13/cobra"
+)
+
+var rootCmd = &cc.Command{Use: "app"}
+
+func GetRootCmd() *cc.Command { return rootCmd }
+
+func NewServerCmd() *cc.Command { return &cc.Command{Use: "server"} }
+
+func WithName(name string) *cc.Command { return rootCmd }
+
+func unexported() *cc.Command { return rootCmd }
+`
+
+func writeTestModule(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":     "module example.com/app\n\ngo 1.22\n\nreplace example.com/lib => ../lib\n",
+		"cmd/cmd.go": rootSource,
+		// Neither of these belongs to the package
+		"cmd/gen.go":      "//go:build ignore\n\npackage main\n",
+		"cmd/example.go":  "package cmd_test\n",
+		"cmd/cmd_test.go": "package cmd_test\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestFindRootFuncs(t *testing.T) {
+	dir := writeTestModule(t)
+
+	pkgName, candidates, err := FindRootFuncs(filepath.Join(dir, "cmd"))
+	if err != nil {
+		t.Fatalf("FindRootFuncs() error = %v", err)
+	}
+	if pkgName != "cmd" {
+		t.Errorf("Expected package 'cmd', got '%s'", pkgName)
+	}
+	if strings.Join(candidates, ",") != "GetRootCmd,NewServerCmd" {
+		t.Errorf("Unexpected candidates: %v", candidates)
+	}
+}
+
+func TestFindRootFuncs_MultiplePackages(t *testing.T) {
+	dir := writeTestModule(t)
+	if err := os.WriteFile(filepath.Join(dir, "cmd", "other.go"), []byte("package other\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := FindRootFuncs(filepath.Join(dir, "cmd"))
+	if err == nil || !strings.Contains(err.Error(), "multiple packages found") {
+		t.Errorf("Expected multiple packages error, got %v", err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	dir := writeTestModule(t)
+
+	target, err := Resolve(filepath.Join(dir, "cmd"), "")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if target.ImportPath != "example.com/app/cmd" {
+		t.Errorf("Expected import path 'example.com/app/cmd', got '%s'", target.ImportPath)
+	}
+	if target.RootFunc != "GetRootCmd" {
+		t.Errorf("Expected preferred root func 'GetRootCmd', got '%s'", target.RootFunc)
+	}
+	if len(target.Replaces) != 1 || target.Replaces[0].New.Path != filepath.Join(dir, "../lib") {
+		t.Errorf("Expected relative replace to be made absolute, got %+v", target.Replaces)
+	}
+
+	if _, err := Resolve(filepath.Join(dir, "cmd"), "Missing"); err == nil {
+		t.Error("Expected error for unknown root func")
+	}
+}
+
+func TestResolve_MainPackage(t *testing.T) {
+	dir := writeTestModule(t)
+	main := `package main
+
+import "github.com/spf13/cobra"
+
+var rootCmd = &cobra.Command{Use: "app"}
+
+var version string
+
+func newRootCmd() *cobra.Command { return rootCmd }
+
+func main() { rootCmd.Execute() }
+`
+	if err := os.MkdirAll(filepath.Join(dir, "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "app", "main.go"), []byte(main), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, candidates, err := FindRootFuncs(filepath.Join(dir, "app"))
+	if err != nil || strings.Join(candidates, ",") != "newRootCmd,rootCmd" {
+		t.Errorf("Expected unexported function and variable candidates, got %v (%v)", candidates, err)
+	}
+
+	target, err := Resolve(filepath.Join(dir, "app"), "")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if target.PackageName != "main" || target.RootFunc != "newRootCmd" || target.RootVar {
+		t.Errorf("Expected root function newRootCmd, got %+v", target)
+	}
+
+	target, err = Resolve(filepath.Join(dir, "app"), "rootCmd")
+	if err != nil || target.RootFunc != "rootCmd" || !target.RootVar {
+		t.Errorf("Expected root variable rootCmd, got %+v (%v)", target, err)
+	}
+}
+
+func TestChooseRootFunc(t *testing.T) {
+	if _, err := chooseRootFunc([]string{"NewA", "NewB"}); err == nil {
+		t.Error("Expected ambiguity error")
+	}
+	if _, err := chooseRootFunc(nil); err == nil {
+		t.Error("Expected error for no candidates")
+	}
+	name, err := chooseRootFunc([]string{"NewA", "NewRootCmd"})
+	if err != nil || name != "NewRootCmd" {
+		t.Errorf("Expected NewRootCmd, got %q (%v)", name, err)
+	}
+}
@@ -0,0 +1,166 @@
+// Package info computes summary statistics for OpenCLI specifications
+package info
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// unspecified is the key used for parameters without a location or scope
+const unspecified = "unspecified"
+
+// Report holds statistics about a specification
+type Report struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	SpecVersion string `json:"specVersion"`
+
+	Commands           int `json:"commands"`
+	Depth              int `json:"depth"`
+	HiddenCommands     int `json:"hiddenCommands"`
+	DeprecatedCommands int `json:"deprecatedCommands"`
+
+	Parameters           int            `json:"parameters"`
+	ParametersByIn       map[string]int `json:"parametersByIn"`
+	ParametersByScope    map[string]int `json:"parametersByScope"`
+	HiddenParameters     int            `json:"hiddenParameters"`
+	DeprecatedParameters int            `json:"deprecatedParameters"`
+
+	Tags           int     `json:"tags"`
+	TaggedCommands int     `json:"taggedCommands"`
+	TagCoverage    float64 `json:"tagCoverage"`
+
+	CommandsWithoutSummary  []string  `json:"commandsWithoutSummary"`
+	FlagsWithoutDescription []FlagRef `json:"flagsWithoutDescription"`
+}
+
+// FlagRef identifies a flag of a command
+type FlagRef struct {
+	Command string `json:"command"`
+	Flag    string `json:"flag"`
+}
+
+// New computes the report for a specification
+func New(s *spec.OpenCLISpec) *Report {
+	r := &Report{
+		Title:                   s.Info.Title,
+		Version:                 s.Info.Version,
+		SpecVersion:             s.OpenCLI,
+		Commands:                len(s.Commands),
+		ParametersByIn:          make(map[string]int),
+		ParametersByScope:       make(map[string]int),
+		Tags:                    len(s.Tags),
+		CommandsWithoutSummary:  make([]string, 0),
+		FlagsWithoutDescription: make([]FlagRef, 0),
+	}
+
+	keys := make([]string, 0, len(s.Commands))
+	for key := range s.Commands {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		cmd := s.Commands[key]
+
+		// Keys are paths such as "/app/user/create"; the root is just "app"
+		if depth := len(strings.Split(strings.Trim(key, "/"), "/")); depth > r.Depth {
+			r.Depth = depth
+		}
+		if cmd.Hidden {
+			r.HiddenCommands++
+		}
+		if cmd.Deprecated {
+			r.DeprecatedCommands++
+		}
+		if len(cmd.Tags) > 0 {
+			r.TaggedCommands++
+		}
+		if cmd.Summary == "" {
+			r.CommandsWithoutSummary = append(r.CommandsWithoutSummary, key)
+		}
+
+		for _, param := range cmd.Parameters {
+			r.Parameters++
+			r.ParametersByIn[orUnspecified(param.In)]++
+			r.ParametersByScope[orUnspecified(param.Scope)]++
+			if param.Hidden {
+				r.HiddenParameters++
+			}
+			if param.Deprecated {
+				r.DeprecatedParameters++
+			}
+			if param.In != "argument" && param.Description == "" {
+				r.FlagsWithoutDescription = append(r.FlagsWithoutDescription, FlagRef{Command: key, Flag: param.Name})
+			}
+		}
+	}
+
+	if r.Commands > 0 {
+		r.TagCoverage = float64(r.TaggedCommands) / float64(r.Commands)
+	}
+
+	return r
+}
+
+// WriteText writes the report in a human-readable form
+func (r *Report) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "  Title: %s\n", r.Title)
+	fmt.Fprintf(w, "  Version: %s\n", r.Version)
+	fmt.Fprintf(w, "  Spec Version: %s\n", r.SpecVersion)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "  Commands: %d (depth %d)\n", r.Commands, r.Depth)
+	fmt.Fprintf(w, "    Hidden: %d\n", r.HiddenCommands)
+	fmt.Fprintf(w, "    Deprecated: %d\n", r.DeprecatedCommands)
+	fmt.Fprintf(w, "  Parameters: %d\n", r.Parameters)
+	fmt.Fprintf(w, "    By location: %s\n", formatCounts(r.ParametersByIn))
+	fmt.Fprintf(w, "    By scope: %s\n", formatCounts(r.ParametersByScope))
+	fmt.Fprintf(w, "    Hidden: %d\n", r.HiddenParameters)
+	fmt.Fprintf(w, "    Deprecated: %d\n", r.DeprecatedParameters)
+	fmt.Fprintf(w, "  Tags: %d\n", r.Tags)
+	fmt.Fprintf(w, "    Tagged commands: %d/%d (%.1f%%)\n", r.TaggedCommands, r.Commands, r.TagCoverage*100)
+
+	if len(r.CommandsWithoutSummary) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "  Commands without summary (%d):\n", len(r.CommandsWithoutSummary))
+		for _, key := range r.CommandsWithoutSummary {
+			fmt.Fprintf(w, "    %s\n", key)
+		}
+	}
+	if len(r.FlagsWithoutDescription) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "  Flags without description (%d):\n", len(r.FlagsWithoutDescription))
+		for _, ref := range r.FlagsWithoutDescription {
+			fmt.Fprintf(w, "    %s --%s\n", ref.Command, ref.Flag)
+		}
+	}
+}
+
+// formatCounts renders counts as "a 1, b 2" ordered by key
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s %d", key, counts[key])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func orUnspecified(s string) string {
+	if s == "" {
+		return unspecified
+	}
+	return s
+}
@@ -0,0 +1,114 @@
+package info
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+func testSpec() *spec.OpenCLISpec {
+	return &spec.OpenCLISpec{
+		OpenCLI: "1.0.0",
+		Info:    spec.Info{Title: "app", Version: "2.0.0"},
+		Tags:    []spec.Tag{{Name: "users"}},
+		Commands: map[string]spec.Command{
+			"app": {
+				Summary: "Root command",
+				Parameters: []spec.Parameter{
+					{Name: "config", In: "flag", Scope: "inherited", Description: "Config file"},
+				},
+			},
+			"/app/user": {
+				Summary: "Manage users",
+				Tags:    []string{"users"},
+			},
+			"/app/user/create": {
+				Tags: []string{"users"},
+				Parameters: []spec.Parameter{
+					{Name: "admin", In: "flag", Scope: "local", Hidden: true},
+					{Name: "name", In: "argument", Scope: "local", Position: 1},
+					{Name: "email"},
+				},
+			},
+			"/app/legacy": {
+				Summary:    "Old command",
+				Deprecated: true,
+				Hidden:     true,
+			},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	r := New(testSpec())
+
+	if r.Title != "app" || r.Version != "2.0.0" || r.SpecVersion != "1.0.0" {
+		t.Errorf("Unexpected info: %q %q %q", r.Title, r.Version, r.SpecVersion)
+	}
+	if r.Commands != 4 || r.Depth != 3 {
+		t.Errorf("Expected 4 commands with depth 3, got %d with depth %d", r.Commands, r.Depth)
+	}
+	if r.HiddenCommands != 1 || r.DeprecatedCommands != 1 {
+		t.Errorf("Expected 1 hidden and 1 deprecated command, got %d and %d", r.HiddenCommands, r.DeprecatedCommands)
+	}
+
+	if r.Parameters != 4 || r.HiddenParameters != 1 {
+		t.Errorf("Expected 4 parameters, 1 hidden, got %d, %d", r.Parameters, r.HiddenParameters)
+	}
+	wantIn := map[string]int{"flag": 2, "argument": 1, "unspecified": 1}
+	for in, count := range wantIn {
+		if r.ParametersByIn[in] != count {
+			t.Errorf("Expected %d %s parameters, got %d", count, in, r.ParametersByIn[in])
+		}
+	}
+	wantScope := map[string]int{"inherited": 1, "local": 2, "unspecified": 1}
+	for scope, count := range wantScope {
+		if r.ParametersByScope[scope] != count {
+			t.Errorf("Expected %d %s parameters, got %d", count, scope, r.ParametersByScope[scope])
+		}
+	}
+
+	if r.Tags != 1 || r.TaggedCommands != 2 || r.TagCoverage != 0.5 {
+		t.Errorf("Expected 2/4 tagged commands, got %d (%v)", r.TaggedCommands, r.TagCoverage)
+	}
+
+	if len(r.CommandsWithoutSummary) != 1 || r.CommandsWithoutSummary[0] != "/app/user/create" {
+		t.Errorf("Expected /app/user/create without summary, got %v", r.CommandsWithoutSummary)
+	}
+	want := []FlagRef{{"/app/user/create", "admin"}, {"/app/user/create", "email"}}
+	if len(r.FlagsWithoutDescription) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, r.FlagsWithoutDescription)
+	}
+	for i := range want {
+		if r.FlagsWithoutDescription[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want[i], r.FlagsWithoutDescription[i])
+		}
+	}
+}
+
+func TestReport_WriteText(t *testing.T) {
+	var buf bytes.Buffer
+	New(testSpec()).WriteText(&buf)
+	out := buf.String()
+
+	for _, want := range []string{
+		"Commands: 4 (depth 3)",
+		"By location: argument 1, flag 2, unspecified 1",
+		"Tagged commands: 2/4 (50.0%)",
+		"Commands without summary (1):\n    /app/user/create",
+		"/app/user/create --email",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestNew_Empty(t *testing.T) {
+	r := New(&spec.OpenCLISpec{})
+	if r.Commands != 0 || r.TagCoverage != 0 || r.Depth != 0 {
+		t.Errorf("Expected empty report, got %+v", r)
+	}
+}
@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// ProtocolVersion is the MCP revision implemented by Server
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Server answers MCP requests over newline-delimited JSON-RPC, running
+// Binary for every tools/call
+type Server struct {
+	// Binary is the path or name of the CLI executable
+	Binary string
+	// Dir is the working directory of the CLI
+	Dir string
+	// Env holds variables added to the environment of the CLI
+	Env []string
+	// Timeout bounds every tool call; zero means no limit
+	Timeout time.Duration
+	// Name and Version identify the server to clients
+	Name    string
+	Version string
+
+	tools *Toolset
+}
+
+// NewServer returns a server exposing the runnable commands of s
+func NewServer(s *spec.OpenCLISpec, binary string) *Server {
+	name := s.Info.Title
+	if name == "" {
+		name = binary
+	}
+	return &Server{
+		Binary:  binary,
+		Name:    name,
+		Version: s.Info.Version,
+		tools:   NewToolset(s),
+	}
+}
+
+// request is a JSON-RPC request or notification
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC response
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the error of a failed request
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// content is a block of a tool result
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// toolResult is the result of tools/call
+type toolResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// Serve reads requests from r and writes responses to w until r is
+// exhausted or ctx is done. Requests are handled one at a time.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	encoder := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if resp := s.handle(ctx, line); resp != nil {
+			if err := encoder.Encode(resp); err != nil {
+				return fmt.Errorf("failed to write response: %w", err)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// handle answers one message; notifications get no response
+func (s *Server) handle(ctx context.Context, line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), codeParseError, "parse error: "+err.Error())
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		id := req.ID
+		if len(id) == 0 {
+			id = json.RawMessage("null")
+		}
+		return errorResponse(id, codeInvalidRequest, "invalid request")
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+
+	switch req.Method {
+	case "initialize":
+		return resultResponse(req.ID, map[string]interface{}{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]interface{}{"name": s.Name, "version": s.Version},
+		})
+	case "ping":
+		return resultResponse(req.ID, map[string]interface{}{})
+	case "tools/list":
+		return resultResponse(req.ID, map[string]interface{}{"tools": s.tools.Tools()})
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil || params.Name == "" {
+			return errorResponse(req.ID, codeInvalidParams, "tools/call requires a tool name")
+		}
+		input := map[string]interface{}{}
+		if len(params.Arguments) > 0 && string(params.Arguments) != "null" {
+			decoder := json.NewDecoder(bytes.NewReader(params.Arguments))
+			decoder.UseNumber()
+			if err := decoder.Decode(&input); err != nil {
+				return errorResponse(req.ID, codeInvalidParams, "arguments must be an object")
+			}
+		}
+		args, err := s.tools.Args(params.Name, input)
+		if err != nil {
+			return errorResponse(req.ID, codeInvalidParams, err.Error())
+		}
+		return resultResponse(req.ID, s.call(ctx, args))
+	default:
+		return errorResponse(req.ID, codeMethodNotFound, "method not found: "+req.Method)
+	}
+}
+
+// call runs the binary with args. A command exiting with a non-zero
+// status is a tool error reported to the model, not a protocol error.
+func (s *Server) call(ctx context.Context, args []string) toolResult {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, s.Binary, args...)
+	cmd.Dir = s.Dir
+	if len(s.Env) > 0 {
+		cmd.Env = append(os.Environ(), s.Env...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	result := toolResult{Content: make([]content, 0, 2)}
+	if stdout.Len() > 0 {
+		result.Content = append(result.Content, content{Type: "text", Text: stdout.String()})
+	}
+	if stderr.Len() > 0 {
+		result.Content = append(result.Content, content{Type: "text", Text: stderr.String()})
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		result.IsError = true
+		result.Content = append(result.Content, content{Type: "text", Text: "command did not finish: " + ctx.Err().Error()})
+	case errors.As(err, &exitErr):
+		result.IsError = true
+		result.Content = append(result.Content, content{Type: "text", Text: fmt.Sprintf("exit status %d", exitErr.ExitCode())})
+	case err != nil:
+		result.IsError = true
+		result.Content = append(result.Content, content{Type: "text", Text: err.Error()})
+	}
+	if len(result.Content) == 0 {
+		result.Content = append(result.Content, content{Type: "text", Text: ""})
+	}
+	return result
+}
+
+// resultResponse returns a successful response
+func resultResponse(id json.RawMessage, result interface{}) *response {
+	return &response{JSONRPC: "2.0", ID: id, Result: result}
+}
+
+// errorResponse returns a failed response
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// serve sends requests to a server running echo and returns the responses
+func serve(t *testing.T, requests ...string) []map[string]interface{} {
+	t.Helper()
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo is not available")
+	}
+
+	var out bytes.Buffer
+	server := NewServer(loadSpec(t), "echo")
+	if err := server.Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")), &out); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	responses := make([]map[string]interface{}, 0)
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var resp map[string]interface{}
+		if err := decoder.Decode(&resp); err != nil {
+			t.Fatalf("Invalid response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestServer(t *testing.T) {
+	responses := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"app_user_create","arguments":{"role":"admin","args":["alice"]}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"app_user_create","arguments":{"role":"root","args":["alice"]}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"resources/list"}`,
+		`not json`,
+	)
+	if len(responses) != 6 {
+		t.Fatalf("Expected 6 responses (none for the notification), got %d: %v", len(responses), responses)
+	}
+
+	result := responses[0]["result"].(map[string]interface{})
+	if result["protocolVersion"] != ProtocolVersion {
+		t.Errorf("Expected protocol version %s, got %v", ProtocolVersion, result["protocolVersion"])
+	}
+	if info := result["serverInfo"].(map[string]interface{}); info["name"] != "App CLI" || info["version"] != "1.2.0" {
+		t.Errorf("Unexpected server info: %v", info)
+	}
+
+	tools := responses[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	if len(tools) != 2 || tools[0].(map[string]interface{})["name"] != "app_user_create" {
+		t.Errorf("Unexpected tools: %v", tools)
+	}
+
+	call := responses[2]["result"].(map[string]interface{})
+	text := call["content"].([]interface{})[0].(map[string]interface{})["text"]
+	if text != "user create --role=admin alice\n" || call["isError"] != nil {
+		t.Errorf("Unexpected call result: %v", call)
+	}
+
+	for i, code := range map[int]float64{3: -32602, 4: -32601, 5: -32700} {
+		rpcErr, ok := responses[i]["error"].(map[string]interface{})
+		if !ok || rpcErr["code"] != code {
+			t.Errorf("Expected response %d to be error %v, got %v", i, code, responses[i])
+		}
+	}
+}
+
+func TestServer_ExitStatus(t *testing.T) {
+	if _, err := exec.LookPath("false"); err != nil {
+		t.Skip("false is not available")
+	}
+
+	var out bytes.Buffer
+	server := NewServer(loadSpec(t), "false")
+	request := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"app_user_delete","arguments":{"args":["a"]}}}`
+	if err := server.Serve(context.Background(), strings.NewReader(request), &out); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"isError":true`) || !strings.Contains(out.String(), "exit status 1") {
+		t.Errorf("Expected a tool error, got %s", out.String())
+	}
+}
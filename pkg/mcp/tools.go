@@ -0,0 +1,595 @@
+// Package mcp exposes the commands of an OpenCLI specification as tools for
+// language models: JSON-Schema tool definitions, and a Model Context
+// Protocol server running the CLI for every tool call.
+//
+// Only the surface described in the spec is reachable. A tool call is
+// checked against the tool's input schema and turned into an argument list
+// without a shell, so arguments cannot smuggle extra commands or flags.
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// ArgsProperty is the input property holding the positional arguments of
+// a command, in order. A flag of the same name is renamed with a "_args"
+// suffix rather than shadowing it.
+const ArgsProperty = "args"
+
+// Tool is the definition of a tool, as listed by an MCP server
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+// Toolset holds the tools of a spec and the commands they run
+type Toolset struct {
+	tools    []Tool
+	commands map[string]*toolCommand
+}
+
+// toolCommand is the command behind a tool
+type toolCommand struct {
+	// Words are the words following the binary that select the command
+	Words []string
+	// Flags maps property names to flag parameters
+	Flags map[string]spec.Parameter
+	// Order lists the flag properties in the order they are passed
+	Order []string
+	// Args are the positional arguments, ordered by position
+	Args []spec.Parameter
+	// ArgsProperty is the property holding the positional arguments
+	ArgsProperty string
+}
+
+// NewToolset derives a tool for every runnable command of s. Hidden
+// commands and flags are left out.
+func NewToolset(s *spec.OpenCLISpec) *Toolset {
+	byPath := make(map[string]spec.Command)
+	paths := make([]string, 0, len(s.Commands))
+	for key, cmd := range s.Commands {
+		path := strings.Join(strings.Split(strings.Trim(key, "/"), "/"), " ")
+		byPath[path] = cmd
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	parents := map[string]bool{}
+	for _, path := range paths {
+		if i := strings.LastIndex(path, " "); i >= 0 {
+			parents[path[:i]] = true
+		}
+	}
+
+	set := &Toolset{
+		tools:    make([]Tool, 0),
+		commands: make(map[string]*toolCommand),
+	}
+	for _, path := range paths {
+		cmd := byPath[path]
+		if !Runnable(cmd, parents[path]) || hiddenPath(byPath, path) {
+			continue
+		}
+
+		words := strings.Fields(path)
+		name := unique(ToolName(words), set.commands)
+		tc := &toolCommand{
+			Words: words[1:],
+			Flags: make(map[string]spec.Parameter),
+		}
+
+		seen := map[string]bool{}
+		for _, p := range cmd.Parameters {
+			if p.In == "argument" {
+				tc.Args = append(tc.Args, p)
+				continue
+			}
+			seen[p.Name] = true
+			tc.addFlag(p)
+		}
+		// Flags passed down by ancestors, nearest first
+		for i := len(words) - 1; i > 0; i-- {
+			for _, p := range byPath[strings.Join(words[:i], " ")].Parameters {
+				if p.In == "argument" || seen[p.Name] || (p.Scope != "inherited" && p.Scope != "global") {
+					continue
+				}
+				seen[p.Name] = true
+				tc.addFlag(p)
+			}
+		}
+		sort.SliceStable(tc.Args, func(i, j int) bool { return tc.Args[i].Position < tc.Args[j].Position })
+		if len(tc.Args) > 0 {
+			tc.ArgsProperty = ArgsProperty
+			if _, ok := tc.Flags[ArgsProperty]; ok {
+				tc.ArgsProperty = ArgsProperty + "_args"
+			}
+		}
+
+		set.commands[name] = tc
+		set.tools = append(set.tools, Tool{
+			Name:        name,
+			Description: toolDescription(path, cmd),
+			InputSchema: tc.inputSchema(),
+		})
+	}
+	return set
+}
+
+// Tools returns the tool definitions, ordered by command path
+func (t *Toolset) Tools() []Tool {
+	return t.tools
+}
+
+// Runnable reports whether cmd does something when invoked. It uses the
+// x-runnable extension, and falls back to treating commands without
+// subcommands as runnable for specs written without it.
+func Runnable(cmd spec.Command, hasChildren bool) bool {
+	if runnable, ok := cmd.Extensions[spec.ExtensionRunnable].(bool); ok {
+		return runnable
+	}
+	return !hasChildren
+}
+
+// ToolName returns the tool name of a command path. Tool names are limited
+// to 64 letters, digits, '_' and '-' by most model providers.
+func ToolName(words []string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, strings.Join(words, "_"))
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// hiddenPath reports whether the command at path or one of its ancestors
+// is hidden
+func hiddenPath(byPath map[string]spec.Command, path string) bool {
+	words := strings.Fields(path)
+	for i := len(words); i > 0; i-- {
+		if byPath[strings.Join(words[:i], " ")].Hidden {
+			return true
+		}
+	}
+	return false
+}
+
+// unique returns name, suffixed with a number if a tool already uses it
+func unique(name string, used map[string]*toolCommand) string {
+	if _, ok := used[name]; !ok {
+		return name
+	}
+	for i := 2; ; i++ {
+		suffix := fmt.Sprintf("_%d", i)
+		candidate := name
+		if len(candidate)+len(suffix) > 64 {
+			candidate = candidate[:64-len(suffix)]
+		}
+		if _, ok := used[candidate+suffix]; !ok {
+			return candidate + suffix
+		}
+	}
+}
+
+// toolDescription returns the summary and description of cmd
+func toolDescription(path string, cmd spec.Command) string {
+	parts := make([]string, 0, 3)
+	if cmd.Summary != "" {
+		parts = append(parts, cmd.Summary)
+	}
+	if cmd.Description != "" && cmd.Description != cmd.Summary {
+		parts = append(parts, cmd.Description)
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "Runs "+path+".")
+	}
+	if cmd.Deprecated {
+		parts = append(parts, "Deprecated: avoid this command where possible.")
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// addFlag adds a visible flag
+func (tc *toolCommand) addFlag(p spec.Parameter) {
+	if p.Hidden {
+		return
+	}
+	tc.Flags[p.Name] = p
+	tc.Order = append(tc.Order, p.Name)
+}
+
+// inputSchema returns the JSON Schema of the tool's arguments
+func (tc *toolCommand) inputSchema() map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+
+	for _, name := range tc.Order {
+		p := tc.Flags[name]
+		properties[name] = propertySchema(p.Schema, p.Description, p.Deprecated)
+		if p.Required {
+			required = append(required, name)
+		}
+	}
+
+	if tc.ArgsProperty != "" {
+		properties[tc.ArgsProperty], required = tc.argsSchema(required)
+	}
+
+	sort.Strings(required)
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// argsSchema returns the array schema of the positional arguments, adding
+// the property to required when an argument is required
+func (tc *toolCommand) argsSchema(required []string) (map[string]interface{}, []string) {
+	minItems, maxItems := 0, 0
+	names := make([]string, 0, len(tc.Args))
+	prefix := make([]interface{}, 0, len(tc.Args))
+	var rest map[string]interface{}
+
+	for _, arg := range tc.Args {
+		lo, hi := argRange(arg)
+		minItems += lo
+
+		label := arg.Name
+		if arg.Description != "" {
+			label += " (" + arg.Description + ")"
+		}
+		names = append(names, label)
+
+		item := propertySchema(itemSchema(arg.Schema), "", false)
+		if hi < 0 {
+			// Values after a variadic argument all belong to it
+			rest = item
+			break
+		}
+		for i := 0; i < hi; i++ {
+			prefix = append(prefix, item)
+		}
+		maxItems += hi
+	}
+
+	schema := map[string]interface{}{
+		"type":        "array",
+		"description": "Positional arguments, in order: " + strings.Join(names, ", "),
+	}
+	if len(prefix) > 0 {
+		schema["prefixItems"] = prefix
+	}
+	// items is still set without a variadic argument, as some providers
+	// require it for every array
+	switch {
+	case rest != nil:
+		schema["items"] = rest
+	case len(prefix) > 0:
+		schema["items"] = prefix[len(prefix)-1]
+		schema["maxItems"] = maxItems
+	default:
+		schema["items"] = map[string]interface{}{"type": "string"}
+		schema["maxItems"] = maxItems
+	}
+	if minItems > 0 {
+		schema["minItems"] = minItems
+		required = append(required, tc.ArgsProperty)
+	}
+	return schema, required
+}
+
+// argRange returns the minimum and maximum number of values of an
+// argument; the maximum is -1 when unlimited
+func argRange(arg spec.Parameter) (int, int) {
+	lo, hi := 0, 1
+	if arg.Arity != nil {
+		lo, hi = arg.Arity.Min, -1
+		if arg.Arity.Max != nil {
+			hi = *arg.Arity.Max
+		}
+	}
+	if arg.Required && lo == 0 {
+		lo = 1
+	}
+	return lo, hi
+}
+
+// itemSchema returns the schema of one value of an argument
+func itemSchema(s *spec.Schema) *spec.Schema {
+	if s != nil && s.Type == "array" {
+		return s.Items
+	}
+	return s
+}
+
+// propertySchema converts a parameter schema to a JSON Schema property
+func propertySchema(s *spec.Schema, description string, deprecated bool) map[string]interface{} {
+	property := map[string]interface{}{}
+	if description != "" {
+		property["description"] = description
+	}
+	if deprecated {
+		property["deprecated"] = true
+	}
+	if s == nil {
+		property["type"] = "string"
+		return property
+	}
+
+	switch s.Type {
+	case "":
+		property["type"] = "string"
+	case "array":
+		property["type"] = "array"
+		property["items"] = propertySchema(s.Items, "", false)
+	case "object":
+		property["type"] = "object"
+		if len(s.Properties) == 0 {
+			values := map[string]interface{}{"type": "string"}
+			if s.AdditionalProperties != nil {
+				values = propertySchema(s.AdditionalProperties, "", false)
+			}
+			property["additionalProperties"] = values
+		}
+	default:
+		property["type"] = s.Type
+	}
+
+	if s.Format != "" {
+		property["format"] = s.Format
+	}
+	if len(s.Enum) > 0 {
+		property["enum"] = s.Enum
+	}
+	if s.Default != nil {
+		property["default"] = s.Default
+	}
+	if s.Pattern != "" {
+		property["pattern"] = s.Pattern
+	}
+	if s.MinLength != nil {
+		property["minLength"] = *s.MinLength
+	}
+	if s.MaxLength != nil {
+		property["maxLength"] = *s.MaxLength
+	}
+	if s.Minimum != nil {
+		property["minimum"] = *s.Minimum
+	}
+	if s.Maximum != nil {
+		property["maximum"] = *s.Maximum
+	}
+	if s.Example != nil {
+		property["examples"] = []interface{}{s.Example}
+	}
+	return property
+}
+
+// Args returns the command line arguments, without the binary, running
+// the named tool with the given input. Input not described by the tool's
+// schema is rejected.
+func (t *Toolset) Args(name string, input map[string]interface{}) ([]string, error) {
+	tc, ok := t.commands[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown tool %q", name)
+	}
+
+	for key := range input {
+		if _, ok := tc.Flags[key]; ok || (key == tc.ArgsProperty && key != "") {
+			continue
+		}
+		return nil, fmt.Errorf("unknown parameter %q", key)
+	}
+
+	args := append([]string{}, tc.Words...)
+	for _, name := range tc.Order {
+		p := tc.Flags[name]
+		value, ok := input[name]
+		if !ok || value == nil {
+			if p.Required {
+				return nil, fmt.Errorf("missing required parameter %q", name)
+			}
+			continue
+		}
+		flagArgs, err := flagValues(p, value)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		args = append(args, flagArgs...)
+	}
+
+	positional, err := tc.positional(input[tc.ArgsProperty])
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q: %w", tc.ArgsProperty, err)
+	}
+	for _, arg := range positional {
+		if strings.HasPrefix(arg, "-") {
+			args = append(args, "--")
+			break
+		}
+	}
+	return append(args, positional...), nil
+}
+
+// flagValues returns the arguments passing value to the flag p
+func flagValues(p spec.Parameter, value interface{}) ([]string, error) {
+	prefix := "--" + p.Name + "="
+	schemaType := ""
+	if p.Schema != nil {
+		schemaType = p.Schema.Type
+	}
+
+	switch schemaType {
+	case "boolean":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected a boolean, got %T", value)
+		}
+		if b && !p.Required {
+			return []string{"--" + p.Name}, nil
+		}
+		if !b && !p.Required && !truthy(p.Schema.Default) {
+			return nil, nil
+		}
+		return []string{prefix + fmt.Sprint(b)}, nil
+	case "array":
+		values, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an array, got %T", value)
+		}
+		args := make([]string, 0, len(values))
+		for _, v := range values {
+			s, err := scalar(p.Schema.Items, v)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, prefix+s)
+		}
+		return args, nil
+	case "object":
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object, got %T", value)
+		}
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		args := make([]string, 0, len(keys))
+		for _, k := range keys {
+			s, err := scalar(p.Schema.AdditionalProperties, values[k])
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", k, err)
+			}
+			args = append(args, prefix+k+"="+s)
+		}
+		return args, nil
+	default:
+		s, err := scalar(p.Schema, value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{prefix + s}, nil
+	}
+}
+
+// positional checks the positional arguments against the command's
+// arguments and returns them as strings
+func (tc *toolCommand) positional(value interface{}) ([]string, error) {
+	if tc.ArgsProperty == "" || value == nil {
+		value = []interface{}{}
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an array, got %T", value)
+	}
+
+	result := make([]string, 0, len(values))
+	i := 0
+	for _, arg := range tc.Args {
+		lo, hi := argRange(arg)
+		if hi < 0 {
+			hi = math.MaxInt
+		}
+		n := 0
+		for ; n < hi && i < len(values); n, i = n+1, i+1 {
+			s, err := scalar(itemSchema(arg.Schema), values[i])
+			if err != nil {
+				return nil, fmt.Errorf("argument %q: %w", arg.Name, err)
+			}
+			result = append(result, s)
+		}
+		if n < lo {
+			return nil, fmt.Errorf("missing required argument %q", arg.Name)
+		}
+	}
+	if i < len(values) {
+		return nil, fmt.Errorf("too many arguments: expected at most %d", i)
+	}
+	return result, nil
+}
+
+// scalar checks a single value against s and formats it as an argument
+func scalar(s *spec.Schema, value interface{}) (string, error) {
+	var result string
+	schemaType := "string"
+	if s != nil && s.Type != "" {
+		schemaType = s.Type
+	}
+
+	switch v := value.(type) {
+	case string:
+		if schemaType != "string" {
+			return "", fmt.Errorf("expected %s, got a string", schemaType)
+		}
+		result = v
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case schemaType == "integer" && f != math.Trunc(f):
+			return "", fmt.Errorf("expected an integer, got %v", v)
+		case schemaType != "integer" && schemaType != "number":
+			return "", fmt.Errorf("expected %s, got a number", schemaType)
+		}
+		result = v.String()
+	case float64:
+		switch {
+		case schemaType == "integer" && v != math.Trunc(v):
+			return "", fmt.Errorf("expected an integer, got %v", v)
+		case schemaType != "integer" && schemaType != "number":
+			return "", fmt.Errorf("expected %s, got a number", schemaType)
+		}
+		result = fmt.Sprint(v)
+		if schemaType == "integer" {
+			result = fmt.Sprintf("%d", int64(v))
+		}
+	case bool:
+		if schemaType != "boolean" {
+			return "", fmt.Errorf("expected %s, got a boolean", schemaType)
+		}
+		result = fmt.Sprint(v)
+	default:
+		return "", fmt.Errorf("expected %s, got %T", schemaType, value)
+	}
+
+	if s != nil && len(s.Enum) > 0 {
+		for _, allowed := range s.Enum {
+			if fmt.Sprint(allowed) == result {
+				return result, nil
+			}
+		}
+		return "", fmt.Errorf("%q is not one of the allowed values", result)
+	}
+	return result, nil
+}
+
+// truthy reports whether a default value is true
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
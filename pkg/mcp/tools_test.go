@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+func loadSpec(t *testing.T) *spec.OpenCLISpec {
+	t.Helper()
+	s, err := spec.Load(filepath.Join("testdata", "app.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+	return s
+}
+
+func TestNewToolset(t *testing.T) {
+	tools := NewToolset(loadSpec(t)).Tools()
+
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Name
+	}
+	// The root is not runnable, "app user" has subcommands and "app debug" is hidden
+	if want := []string{"app_user_create", "app_user_delete"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Expected tools %v, got %v", want, names)
+	}
+
+	data, err := json.Marshal(tools[0].InputSchema)
+	if err != nil {
+		t.Fatalf("Failed to marshal schema: %v", err)
+	}
+	schema := string(data)
+	for _, want := range []string{
+		`"additionalProperties":false`,
+		`"role":{"description":"Role of the user","enum":["member","admin"],"type":"string"}`,
+		`"quota":{"default":10,"type":"integer"}`,
+		`"groups":{"items":{"type":"string"},"type":"array"}`,
+		`"verbose":{"type":"boolean"}`,
+		`"args":{"description":"Positional arguments, in order: name","items":{"type":"string"},"maxItems":1,"minItems":1,"prefixItems":[{"type":"string"}],"type":"array"}`,
+		`"required":["args","role"]`,
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain %s, got %s", want, schema)
+		}
+	}
+	if strings.Contains(schema, "secret") {
+		t.Errorf("Expected hidden flag to be left out, got %s", schema)
+	}
+
+	data, _ = json.Marshal(tools[1].InputSchema)
+	if !strings.Contains(string(data), `"args":{"description":"Positional arguments, in order: names","items":{"type":"string"},"minItems":1,"type":"array"}`) {
+		t.Errorf("Expected variadic arguments, got %s", data)
+	}
+}
+
+func TestRunnable_Fallback(t *testing.T) {
+	if !Runnable(spec.Command{}, false) || Runnable(spec.Command{}, true) {
+		t.Error("Expected commands without x-runnable to be runnable when they have no subcommands")
+	}
+	cmd := spec.Command{Extensions: map[string]interface{}{spec.ExtensionRunnable: true}}
+	if !Runnable(cmd, true) {
+		t.Error("Expected x-runnable to take precedence")
+	}
+}
+
+func TestToolset_Args(t *testing.T) {
+	tools := NewToolset(loadSpec(t))
+
+	tests := []struct {
+		name  string
+		tool  string
+		input string
+		want  []string
+		err   string
+	}{
+		{
+			name:  "flags and arguments",
+			tool:  "app_user_create",
+			input: `{"role":"admin","quota":3,"groups":["a","b"],"verbose":true,"args":["alice"]}`,
+			want:  []string{"user", "create", "--role=admin", "--quota=3", "--groups=a", "--groups=b", "--verbose", "alice"},
+		},
+		{
+			name:  "false boolean with true default",
+			tool:  "app_user_create",
+			input: `{"role":"member","cache":false,"verbose":false,"args":["bob"]}`,
+			want:  []string{"user", "create", "--role=member", "--cache=false", "bob"},
+		},
+		{
+			name:  "argument looking like a flag",
+			tool:  "app_user_delete",
+			input: `{"args":["a","--force"]}`,
+			want:  []string{"user", "delete", "--", "a", "--force"},
+		},
+		{name: "unknown tool", tool: "app_debug", input: `{}`, err: `unknown tool "app_debug"`},
+		{name: "unknown parameter", tool: "app_user_create", input: `{"role":"admin","secret":"x","args":["a"]}`, err: `unknown parameter "secret"`},
+		{name: "missing flag", tool: "app_user_create", input: `{"args":["a"]}`, err: `missing required parameter "role"`},
+		{name: "missing argument", tool: "app_user_create", input: `{"role":"admin"}`, err: `missing required argument "name"`},
+		{name: "too many arguments", tool: "app_user_create", input: `{"role":"admin","args":["a","b"]}`, err: "too many arguments"},
+		{name: "enum", tool: "app_user_create", input: `{"role":"root","args":["a"]}`, err: "not one of the allowed values"},
+		{name: "type", tool: "app_user_create", input: `{"role":"admin","quota":"3","args":["a"]}`, err: "expected integer, got a string"},
+		{name: "fraction", tool: "app_user_create", input: `{"role":"admin","quota":1.5,"args":["a"]}`, err: "expected an integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.input), &input); err != nil {
+				t.Fatalf("Invalid input: %v", err)
+			}
+			got, err := tools.Args(tt.tool, input)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,421 @@
+package binary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+)
+
+const (
+	defaultTimeout  = 10 * time.Second
+	defaultMaxDepth = 5
+)
+
+// BinaryParser implements the Parser interface for compiled executables
+// without source. It runs `<bin> --help` and, for every listed subcommand,
+// `<bin> <sub> --help` (falling back to `<bin> help <sub>`), and parses the
+// usage text. Each command records how reliable its help text looked as
+// binary_confidence, from 0 to 1, in its Extensions.
+type BinaryParser struct{}
+
+// NewBinaryParser creates a new help-text scraping parser
+func NewBinaryParser() *BinaryParser {
+	return &BinaryParser{}
+}
+
+// Name returns the parser name
+func (p *BinaryParser) Name() string {
+	return "binary"
+}
+
+// Supports checks if the source is a parser.BinarySource
+func (p *BinaryParser) Supports(source interface{}) bool {
+	switch source.(type) {
+	case *parser.BinarySource, parser.BinarySource:
+		return true
+	}
+	return false
+}
+
+// Parse extracts CLI structure from the help output of a binary
+func (p *BinaryParser) Parse(source interface{}) (*parser.ParsedCLI, error) {
+	var src parser.BinarySource
+	switch s := source.(type) {
+	case *parser.BinarySource:
+		if s == nil {
+			return nil, parser.ErrInvalidSource
+		}
+		src = *s
+	case parser.BinarySource:
+		src = s
+	default:
+		return nil, &parser.ParserError{
+			Message: "source is not a parser.BinarySource",
+			Cause:   parser.ErrInvalidSource,
+		}
+	}
+	if src.Path == "" {
+		return nil, &parser.ParserError{Message: "binary path is required", Cause: parser.ErrInvalidSource}
+	}
+
+	path, err := exec.LookPath(src.Path)
+	if err != nil {
+		return nil, &parser.ParserError{Message: "binary not found: " + src.Path, Cause: err}
+	}
+	if src.Timeout <= 0 {
+		src.Timeout = defaultTimeout
+	}
+	if src.MaxDepth <= 0 {
+		src.MaxDepth = defaultMaxDepth
+	}
+
+	s := &scraper{source: src, path: path}
+	out, code, err := s.run("--help")
+	if err != nil {
+		return nil, &parser.ParserError{Message: "failed to run " + path + " --help", Cause: err}
+	}
+	if strings.TrimSpace(out) == "" {
+		return nil, &parser.ParserError{Message: path + " --help produced no output"}
+	}
+	doc := parseHelp(out)
+	if len(doc.Usage) == 0 && doc.Name == "" && len(doc.Flags)+len(doc.GlobalFlags)+len(doc.Commands) == 0 {
+		return nil, &parser.ParserError{Message: path + " --help did not print recognizable usage"}
+	}
+
+	parsed := &parser.ParsedCLI{
+		Commands:      make(map[string]*parser.CommandInfo),
+		FrameworkData: make(map[string]interface{}),
+	}
+
+	rootName := rootName(doc, path)
+	root := s.command(rootName, nil, nil, doc, out, code, parsed.Commands)
+	parsed.RootCommand = root
+	parsed.Warnings = s.warnings
+
+	parsed.Metadata = &parser.CLIMetadata{
+		Name:        rootName,
+		Version:     doc.Version,
+		Description: root.Long,
+		Tags:        make([]parser.TagInfo, 0),
+		EnvVars:     make([]parser.EnvVarInfo, 0),
+		Platforms:   make([]parser.PlatformInfo, 0),
+	}
+	if parsed.Metadata.Description == "" {
+		parsed.Metadata.Description = root.Short
+	}
+	parser.CollectMetadata(parsed)
+
+	parsed.FrameworkData["framework"] = "binary"
+	parsed.FrameworkData["style"] = doc.Style
+	parsed.FrameworkData["path"] = path
+
+	return parsed, nil
+}
+
+// scraper runs a binary and walks its subcommands
+type scraper struct {
+	source   parser.BinarySource
+	path     string
+	warnings []string
+}
+
+// run executes the binary with args and returns its combined output. A
+// non-zero exit is not an error; many tools exit 2 after printing help.
+func (s *scraper) run(args ...string) (string, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.source.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, s.path, args...)
+	cmd.Env = append(os.Environ(), "NO_COLOR=1", "TERM=dumb", "COLUMNS=200")
+	cmd.Env = append(cmd.Env, s.source.Env...)
+	// Do not wait for grandchildren that inherited the output pipe
+	cmd.WaitDelay = time.Second
+
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", 0, fmt.Errorf("timed out after %s", s.source.Timeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(out), exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	return string(out), 0, nil
+}
+
+// subcommandHelp fetches the help text of the command at path, returning
+// a nil doc when neither invocation describes that command. `help <path>`
+// is tried first, since `<path> --help` runs the command itself when the
+// binary does not intercept --help. urfave/cli v2 leaves subcommands and
+// options out of `help <cmd>`, so a text listing neither falls back to
+// --help as well.
+func (s *scraper) subcommandHelp(path []string, parentOut string) (*helpDoc, string, int) {
+	attempts := [][]string{
+		append([]string{"help"}, path...),
+		append(append([]string{}, path...), "--help"),
+	}
+	var (
+		partial     *helpDoc
+		partialOut  string
+		partialCode int
+	)
+	for _, args := range attempts {
+		out, code, err := s.run(args...)
+		if err != nil {
+			s.warnings = append(s.warnings, fmt.Sprintf("%s %s: %v", filepath.Base(s.path), strings.Join(args, " "), err))
+			continue
+		}
+		if strings.TrimSpace(out) == "" || out == parentOut {
+			continue
+		}
+		doc := parseHelp(out)
+		if !describes(doc, path) {
+			continue
+		}
+		if partial == nil && len(doc.Commands) == 0 && len(doc.Flags) == 0 && len(doc.GlobalFlags) == 0 {
+			partial, partialOut, partialCode = doc, out, code
+			continue
+		}
+		return doc, out, code
+	}
+	return partial, partialOut, partialCode
+}
+
+// command builds the CommandInfo for one help text and recurses into the
+// subcommands it lists
+func (s *scraper) command(name string, parent *parser.CommandInfo, entry *helpEntry, doc *helpDoc, out string, code int, commands map[string]*parser.CommandInfo) *parser.CommandInfo {
+	path := name
+	words := make([]string, 0)
+	if parent != nil {
+		path = parent.Path + "/" + name
+		words = append(commandWords(parent), name)
+	}
+
+	info := &parser.CommandInfo{
+		Name:            name,
+		Path:            path,
+		Use:             name,
+		Parent:          parent,
+		Subcommands:     make([]*parser.CommandInfo, 0),
+		Flags:           make([]*parser.FlagInfo, 0),
+		Args:            make([]*parser.ArgumentInfo, 0),
+		PersistentFlags: make([]*parser.FlagInfo, 0),
+		Annotations:     make(map[string]string),
+		Tags:            make([]string, 0),
+		Extensions:      make(map[string]interface{}),
+	}
+	commands[info.Path] = info
+
+	if entry != nil {
+		info.Short = entry.Short
+		info.Aliases = entry.Aliases
+		if entry.Category != "" {
+			info.Tags = append(info.Tags, entry.Category)
+		}
+	}
+
+	if doc == nil {
+		s.warnings = append(s.warnings, fmt.Sprintf("%s: no help output found", strings.Join(append([]string{filepath.Base(s.path)}, words...), " ")))
+		info.RunFunc = true
+		info.Extensions["binary_confidence"] = 0.1
+		return info
+	}
+
+	if info.Short == "" {
+		info.Short = doc.Short
+	}
+	info.Long = doc.Long
+	if info.Short == "" && info.Long != "" {
+		info.Short, _, _ = strings.Cut(info.Long, "\n")
+	}
+	if info.Long == info.Short {
+		info.Long = ""
+	}
+	info.Example = doc.Example
+	info.Version = doc.Version
+	for _, alias := range doc.Aliases {
+		if alias != name && !contains(info.Aliases, alias) {
+			info.Aliases = append(info.Aliases, alias)
+		}
+	}
+	if doc.Category != "" && !contains(info.Tags, doc.Category) {
+		info.Tags = append(info.Tags, doc.Category)
+	}
+	info.Use = useString(doc.Usage, name)
+	info.Args = parseUsageArgs(doc.Usage, words)
+
+	for _, flag := range doc.Flags {
+		if !builtinFlag(flag) {
+			info.Flags = append(info.Flags, flag)
+		}
+	}
+	if parent == nil {
+		// Global options of the root apply to every subcommand (urfave/cli)
+		for _, flag := range doc.GlobalFlags {
+			if !builtinFlag(flag) {
+				flag.Persistent = true
+				info.PersistentFlags = append(info.PersistentFlags, flag)
+			}
+		}
+	} else {
+		markPersistent(parent, doc.GlobalFlags)
+	}
+
+	info.Extensions["binary_confidence"] = confidence(doc, code)
+	info.Extensions["binary_help_style"] = doc.Style
+
+	for _, sub := range doc.Commands {
+		if sub.Name == "help" || sub.Name == "completion" || contains(words, sub.Name) {
+			continue
+		}
+		subPath := append(append([]string{}, words...), sub.Name)
+		var (
+			subDoc  *helpDoc
+			subOut  string
+			subCode int
+		)
+		if len(subPath) > s.source.MaxDepth {
+			s.warnings = append(s.warnings, fmt.Sprintf("%s: maximum depth %d reached", strings.Join(subPath, " "), s.source.MaxDepth))
+		} else {
+			subDoc, subOut, subCode = s.subcommandHelp(subPath, out)
+		}
+		subInfo := s.command(sub.Name, info, sub, subDoc, subOut, subCode, commands)
+		info.Subcommands = append(info.Subcommands, subInfo)
+	}
+	info.RunFunc = len(info.Subcommands) == 0
+
+	return info
+}
+
+// confidence scores how completely a help text was understood
+func confidence(doc *helpDoc, code int) float64 {
+	score := 0.2
+	if code == 0 {
+		score += 0.1
+	}
+	if len(doc.Usage) > 0 || doc.Name != "" {
+		score += 0.3
+	}
+	if doc.Style != styleUnknown {
+		score += 0.2
+	}
+	if len(doc.Flags)+len(doc.GlobalFlags)+len(doc.Commands) > 0 {
+		score += 0.2
+	}
+	return math.Round(score*100) / 100
+}
+
+// describes reports whether a help text is about the command at path.
+// Some frameworks print the parent's help for unknown help topics.
+func describes(doc *helpDoc, path []string) bool {
+	want := strings.Join(path, " ")
+	if doc.Name != "" {
+		return strings.HasSuffix(doc.Name, want)
+	}
+	if len(doc.Usage) == 0 {
+		return true
+	}
+	for _, line := range doc.Usage {
+		if strings.Contains(" "+line+" ", " "+want+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// markPersistent moves flags that a subcommand lists as global from its
+// ancestors' local flags to their persistent flags
+func markPersistent(ancestor *parser.CommandInfo, globals []*parser.FlagInfo) {
+	names := make(map[string]bool)
+	for _, flag := range globals {
+		names[flag.Name] = true
+	}
+	for a := ancestor; a != nil && len(names) > 0; a = a.Parent {
+		local := make([]*parser.FlagInfo, 0, len(a.Flags))
+		for _, flag := range a.Flags {
+			if names[flag.Name] {
+				flag.Persistent = true
+				a.PersistentFlags = append(a.PersistentFlags, flag)
+				delete(names, flag.Name)
+				continue
+			}
+			local = append(local, flag)
+		}
+		a.Flags = local
+		for _, flag := range a.PersistentFlags {
+			delete(names, flag.Name)
+		}
+	}
+}
+
+// rootName returns the program name printed in the help text, or the
+// binary's file name
+func rootName(doc *helpDoc, path string) string {
+	name := doc.Name
+	if name == "" && len(doc.Usage) > 0 {
+		if fields := strings.Fields(doc.Usage[0]); len(fields) > 0 {
+			name = fields[0]
+		}
+	}
+	if name == "" || strings.HasPrefix(name, "[") || strings.HasPrefix(name, "<") {
+		name = path
+	}
+	if fields := strings.Fields(name); len(fields) > 0 {
+		name = fields[0]
+	}
+	return filepath.Base(name)
+}
+
+// useString returns the usage line of a command starting at its name
+func useString(usage []string, name string) string {
+	for _, line := range usage {
+		fields := strings.Fields(line)
+		for i := len(fields) - 1; i >= 0; i-- {
+			if filepath.Base(fields[i]) == name {
+				return strings.Join(append([]string{name}, fields[i+1:]...), " ")
+			}
+		}
+	}
+	return name
+}
+
+// builtinFlag reports whether a flag is added by the CLI framework itself
+func builtinFlag(flag *parser.FlagInfo) bool {
+	switch flag.Name {
+	case "help":
+		return true
+	case "version":
+		usage := strings.ToLower(flag.Usage)
+		return strings.HasPrefix(usage, "version for ") || strings.HasPrefix(usage, "print the version") ||
+			strings.HasPrefix(usage, "show application version")
+	}
+	return false
+}
+
+// commandWords returns the subcommand words leading to cmd, without the root
+func commandWords(cmd *parser.CommandInfo) []string {
+	words := make([]string, 0)
+	for c := cmd; c != nil && c.Parent != nil; c = c.Parent {
+		words = append([]string{c.Name}, words...)
+	}
+	return words
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package binary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+)
+
+func fakeCLI(t *testing.T, style string) *parser.BinarySource {
+	t.Helper()
+	path, err := filepath.Abs(filepath.Join("testdata", "fakecli.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &parser.BinarySource{Path: path, Env: []string{"FAKECLI_STYLE=" + style}}
+}
+
+func TestBinaryParser_Supports(t *testing.T) {
+	p := NewBinaryParser()
+	if p.Name() != "binary" {
+		t.Errorf("Expected parser name 'binary', got '%s'", p.Name())
+	}
+	if !p.Supports(&parser.BinarySource{}) || !p.Supports(parser.BinarySource{}) {
+		t.Error("Expected BinarySource to be supported")
+	}
+	if p.Supports("/bin/ls") || p.Supports(nil) {
+		t.Error("Expected strings and nil to be unsupported")
+	}
+}
+
+func TestBinaryParser_ParseCobra(t *testing.T) {
+	parsed, err := NewBinaryParser().Parse(fakeCLI(t, "cobra"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	root := parsed.RootCommand
+	if root.Name != "testapp" || len(root.Subcommands) != 1 {
+		t.Fatalf("Unexpected root command: %+v", root)
+	}
+	if len(parsed.Commands) != 3 {
+		t.Errorf("Expected 3 commands, got %d", len(parsed.Commands))
+	}
+	if f := findFlag(root.PersistentFlags, "config"); f == nil || !f.Persistent {
+		t.Errorf("Expected config to be persistent, got %+v", root.PersistentFlags)
+	}
+	if f := findFlag(root.Flags, "workers"); f == nil {
+		t.Errorf("Expected local workers flag, got %+v", root.Flags)
+	}
+	if findFlag(root.Flags, "help") != nil {
+		t.Error("Expected help flag to be skipped")
+	}
+
+	user := parsed.Commands["testapp/user"]
+	if user == nil || len(user.Aliases) != 1 || user.Aliases[0] != "users" || user.RunFunc {
+		t.Errorf("Unexpected user command: %+v", user)
+	}
+
+	create := parsed.Commands["testapp/user/create"]
+	if create == nil {
+		t.Fatal("Expected command testapp/user/create")
+	}
+	if create.Use != "create <username> [groups...] [flags]" || create.Short != "Create a user" || create.Long != "Create a user account." {
+		t.Errorf("Unexpected create command: %+v", create)
+	}
+	if len(create.Args) != 2 || len(create.Flags) != 3 || len(create.PersistentFlags) != 0 {
+		t.Errorf("Unexpected create parameters: args %d flags %d", len(create.Args), len(create.Flags))
+	}
+	if create.Extensions["binary_confidence"] != 1.0 || create.Extensions["binary_help_style"] != styleCobra {
+		t.Errorf("Unexpected extensions: %+v", create.Extensions)
+	}
+	if len(parsed.Warnings) != 0 {
+		t.Errorf("Unexpected warnings: %v", parsed.Warnings)
+	}
+}
+
+func TestBinaryParser_PrefersHelpCommand(t *testing.T) {
+	log := filepath.Join(t.TempDir(), "invocations")
+	source := fakeCLI(t, "cobra")
+	source.Env = append(source.Env, "FAKECLI_LOG="+log)
+	if _, err := NewBinaryParser().Parse(source); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	data, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "--help\nhelp user\nhelp user create\n"
+	if string(data) != want {
+		t.Errorf("Expected subcommands to be run only through help, got invocations:\n%s", data)
+	}
+}
+
+func TestBinaryParser_ParseUrfave(t *testing.T) {
+	parsed, err := NewBinaryParser().Parse(fakeCLI(t, "urfave"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if f := findFlag(parsed.RootCommand.PersistentFlags, "config"); f == nil {
+		t.Errorf("Expected global config flag, got %+v", parsed.RootCommand.PersistentFlags)
+	}
+	if findFlag(parsed.RootCommand.PersistentFlags, "version") != nil {
+		t.Error("Expected generated version flag to be skipped")
+	}
+
+	create := parsed.Commands["testapp/user/create"]
+	if create == nil {
+		t.Fatal("Expected command testapp/user/create")
+	}
+	if len(create.Aliases) != 1 || create.Aliases[0] != "add" || create.Short != "Create a user" {
+		t.Errorf("Unexpected create command: %+v", create)
+	}
+	if f := findFlag(create.Flags, "uid"); f == nil || f.DefaultValue != "1000" {
+		t.Errorf("Unexpected uid flag: %+v", f)
+	}
+	if user := parsed.Commands["testapp/user"]; len(user.Tags) != 1 || user.Tags[0] != "accounts" {
+		t.Errorf("Expected accounts tag, got %+v", user.Tags)
+	}
+	if len(parsed.Metadata.EnvVars) != 1 || parsed.Metadata.EnvVars[0].Name != "TESTAPP_CONFIG" {
+		t.Errorf("Unexpected env vars: %+v", parsed.Metadata.EnvVars)
+	}
+	if parsed.Metadata.Version != "1.0.0" {
+		t.Errorf("Unexpected version %q", parsed.Metadata.Version)
+	}
+}
+
+func TestBinaryParser_ParseGNU(t *testing.T) {
+	parsed, err := NewBinaryParser().Parse(fakeCLI(t, "gnu"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	root := parsed.RootCommand
+	if root.Name != "env" || !root.RunFunc || len(root.Flags) < 10 {
+		t.Errorf("Unexpected root command: %+v", root)
+	}
+	if confidence := root.Extensions["binary_confidence"].(float64); confidence < 0.5 {
+		t.Errorf("Expected reasonable confidence, got %v", confidence)
+	}
+}
+
+func TestBinaryParser_Timeout(t *testing.T) {
+	source := fakeCLI(t, "slow")
+	source.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	if _, err := NewBinaryParser().Parse(source); err == nil {
+		t.Error("Expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Timeout not enforced, took %s", elapsed)
+	}
+}
+
+func TestBinaryParser_Errors(t *testing.T) {
+	p := NewBinaryParser()
+	if _, err := p.Parse(&parser.BinarySource{}); err == nil {
+		t.Error("Expected error for empty path")
+	}
+	if _, err := p.Parse(&parser.BinarySource{Path: "/nonexistent/binary"}); err == nil {
+		t.Error("Expected error for missing binary")
+	}
+	if _, err := p.Parse(fakeCLI(t, "unknown")); err == nil {
+		t.Error("Expected error when help output is unusable")
+	}
+}
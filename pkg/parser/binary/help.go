@@ -0,0 +1,540 @@
+package binary
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+)
+
+// Help output styles recognized by parseHelp
+const (
+	styleCobra   = "cobra"
+	styleUrfave  = "urfave"
+	styleKong    = "kong"
+	styleKingpin = "kingpin"
+	styleFlag    = "flag"
+	styleGNU     = "gnu"
+	styleUnknown = "unknown"
+)
+
+// Sections of a help text
+const (
+	sectionNone = iota
+	sectionDescription
+	sectionUsage
+	sectionName
+	sectionAliases
+	sectionExamples
+	sectionVersion
+	sectionCategory
+	sectionCommands
+	sectionFlags
+	sectionGlobalFlags
+	sectionOther
+)
+
+var (
+	ansiRe     = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+	usageRe    = regexp.MustCompile(`^(?i)usage(?: of ([^:]+))?:\s*(.*)$`)
+	flagLineRe = regexp.MustCompile(`^--?[A-Za-z0-9\[]`)
+	defaultRe  = regexp.MustCompile(`\s*\(default:?\s+([^)]*)\)`)
+	envRe      = regexp.MustCompile(`\s*[\[(]((?:\$[A-Za-z_][A-Za-z0-9_]*(?:,\s*)?)+)[\])]`)
+	requiredRe = regexp.MustCompile(`(?i)\s*[\[(]required[\])]`)
+
+	// usageMetaRe matches usage placeholders and inline flags such as
+	// "[build flags]" or "[-o output]", which are not positional arguments
+	usageMetaRe = regexp.MustCompile(`(?i)\[(?:[\w -]+ )?(?:flags|options?)\](?:\.\.\.)?|\[<flags>\]|\[command\]|<command>|\[arguments\.\.\.\]|\[<args> ?\.\.\.\]|\[-[^\]]*\]`)
+)
+
+// placeholderTypes maps value placeholders printed by pflag, kong and
+// kingpin to the type names used by other parsers
+var placeholderTypes = map[string]string{
+	"string":         "string",
+	"strings":        "stringSlice",
+	"stringarray":    "stringArray",
+	"int":            "int",
+	"int8":           "int8",
+	"int16":          "int16",
+	"int32":          "int32",
+	"int64":          "int64",
+	"ints":           "intSlice",
+	"uint":           "uint",
+	"uint8":          "uint8",
+	"uint16":         "uint16",
+	"uint32":         "uint32",
+	"uint64":         "uint64",
+	"uints":          "uintSlice",
+	"float":          "float64",
+	"float32":        "float32",
+	"float64":        "float64",
+	"duration":       "duration",
+	"durations":      "durationSlice",
+	"bool":           "bool",
+	"bools":          "boolSlice",
+	"ip":             "ip",
+	"ipnet":          "ipNet",
+	"ipmask":         "ipMask",
+	"stringtostring": "stringToString",
+	"stringtoint":    "stringToInt",
+	"stringtoint64":  "stringToInt64",
+}
+
+// helpEntry is a subcommand listed in a commands section
+type helpEntry struct {
+	Name     string
+	Aliases  []string
+	Short    string
+	Category string
+}
+
+// helpDoc is the structure recovered from one help text
+type helpDoc struct {
+	Style       string
+	Usage       []string
+	Name        string
+	Short       string
+	Long        string
+	Aliases     []string
+	Example     string
+	Version     string
+	Category    string
+	Commands    []*helpEntry
+	Flags       []*parser.FlagInfo
+	GlobalFlags []*parser.FlagInfo
+}
+
+// HelpFlags returns the flags listed in a help text, local and global,
+// leaving out the help and version flags added by CLI frameworks
+func HelpFlags(text string) []*parser.FlagInfo {
+	doc := parseHelp(text)
+	flags := make([]*parser.FlagInfo, 0, len(doc.Flags)+len(doc.GlobalFlags))
+	for _, flag := range append(doc.Flags, doc.GlobalFlags...) {
+		if !builtinFlag(flag) {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// parseHelp parses Cobra, urfave/cli, Kong, Kingpin, stdlib flag and GNU
+// style help output
+func parseHelp(text string) *helpDoc {
+	doc := &helpDoc{}
+	section := sectionNone
+
+	var (
+		headers     = make(map[string]bool)
+		description []string
+		examples    []string
+		inlineUsage bool
+		trailer     string
+		flag        *parser.FlagInfo
+		flagIndent  int
+		entry       *helpEntry
+		entryIndent int
+		category    string
+		seen        = make(map[string]*helpEntry)
+	)
+
+	for _, line := range strings.Split(ansiRe.ReplaceAllString(text, ""), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		indent := indentation(line)
+
+		if trimmed == "" {
+			flag, entry = nil, nil
+			if section == sectionDescription {
+				description = append(description, "")
+			}
+			if section == sectionExamples {
+				examples = append(examples, "")
+			}
+			continue
+		}
+
+		// Section headers start at column zero
+		if indent == 0 {
+			flag, entry = nil, nil
+			if m := usageRe.FindStringSubmatch(trimmed); m != nil {
+				headers["usage"] = true
+				if m[1] != "" {
+					doc.Style = styleFlag
+					doc.Name = m[1]
+					section = sectionFlags
+					continue
+				}
+				section = sectionUsage
+				if m[2] != "" {
+					inlineUsage = true
+					doc.Usage = append(doc.Usage, m[2])
+				}
+				continue
+			}
+			if strings.HasPrefix(trimmed, `Use "`) || strings.HasPrefix(trimmed, `Run "`) {
+				trailer = trimmed[:3]
+				continue
+			}
+			if strings.HasSuffix(trimmed, ":") && len(strings.Fields(trimmed)) <= 4 {
+				header := strings.TrimSuffix(trimmed, ":")
+				headers[strings.ToLower(header)] = true
+				if header == strings.ToUpper(header) {
+					headers["uppercase"] = true
+				}
+				section = classifyHeader(header)
+				category = ""
+				continue
+			}
+			if !flagLineRe.MatchString(trimmed) {
+				section = sectionDescription
+				description = append(description, trimmed)
+				continue
+			}
+		}
+
+		switch section {
+		case sectionUsage:
+			doc.Usage = append(doc.Usage, trimmed)
+		case sectionName:
+			name, short, _ := strings.Cut(trimmed, " - ")
+			doc.Name, doc.Short = strings.TrimSpace(name), strings.TrimSpace(short)
+		case sectionAliases:
+			for _, alias := range strings.Split(trimmed, ",") {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					doc.Aliases = append(doc.Aliases, alias)
+				}
+			}
+		case sectionExamples:
+			examples = append(examples, line)
+		case sectionVersion:
+			doc.Version = trimmed
+		case sectionCategory:
+			doc.Category = trimmed
+		case sectionCommands:
+			// Category headings nested in the list (urfave/cli)
+			if strings.HasSuffix(trimmed, ":") && !strings.Contains(trimmed, "  ") {
+				category = strings.TrimSuffix(trimmed, ":")
+				entry = nil
+				continue
+			}
+			// Descriptions printed below the entry (Kong, Kingpin)
+			if entry != nil && entry.Short == "" && indent > entryIndent {
+				entry.Short = trimmed
+				continue
+			}
+			entry, entryIndent = parseCommandLine(trimmed), indent
+			if entry == nil {
+				continue
+			}
+			entry.Category = category
+			if existing, ok := seen[entry.Name]; ok {
+				entry = existing
+				continue
+			}
+			seen[entry.Name] = entry
+			doc.Commands = append(doc.Commands, entry)
+		case sectionFlags, sectionGlobalFlags, sectionNone, sectionDescription:
+			// Description lines wrapped below a flag
+			if flag != nil && indent > flagIndent && !flagLineRe.MatchString(trimmed) {
+				flag.Usage = strings.TrimSpace(flag.Usage + " " + trimmed)
+				continue
+			}
+			if !flagLineRe.MatchString(trimmed) {
+				if section == sectionNone || section == sectionDescription {
+					section = sectionDescription
+					description = append(description, trimmed)
+				}
+				flag = nil
+				continue
+			}
+			flag, flagIndent = parseFlagLine(trimmed), indent
+			if section == sectionGlobalFlags {
+				doc.GlobalFlags = append(doc.GlobalFlags, flag)
+			} else {
+				doc.Flags = append(doc.Flags, flag)
+			}
+		}
+	}
+
+	for _, flags := range [][]*parser.FlagInfo{doc.Flags, doc.GlobalFlags} {
+		for _, f := range flags {
+			finishFlag(f)
+		}
+	}
+
+	doc.Long = strings.TrimSpace(strings.Join(description, "\n"))
+	doc.Example = strings.TrimRight(strings.Join(examples, "\n"), "\n")
+
+	if doc.Style == "" {
+		switch {
+		case headers["uppercase"] && headers["name"] && headers["usage"]:
+			doc.Style = styleUrfave
+		case trailer == "Use" || headers["available commands"]:
+			doc.Style = styleCobra
+		case trailer == "Run":
+			doc.Style = styleKong
+		case inlineUsage && len(doc.Usage) > 0 && strings.Contains(doc.Usage[0], "[<flags>]"):
+			doc.Style = styleKingpin
+		case inlineUsage:
+			doc.Style = styleGNU
+		case headers["usage"] && headers["flags"]:
+			doc.Style = styleCobra
+		default:
+			doc.Style = styleUnknown
+		}
+	}
+
+	return doc
+}
+
+// classifyHeader maps a section header to its section
+func classifyHeader(header string) int {
+	h := strings.ToLower(header)
+	switch {
+	case h == "name":
+		return sectionName
+	case h == "description":
+		return sectionDescription
+	case h == "aliases":
+		return sectionAliases
+	case strings.HasPrefix(h, "example"):
+		return sectionExamples
+	case h == "version":
+		return sectionVersion
+	case h == "category":
+		return sectionCategory
+	case strings.Contains(h, "command"):
+		return sectionCommands
+	case strings.Contains(h, "flag") || strings.Contains(h, "option"):
+		if strings.Contains(h, "global") || strings.Contains(h, "inherited") {
+			return sectionGlobalFlags
+		}
+		return sectionFlags
+	}
+	return sectionOther
+}
+
+// parseCommandLine parses "name, alias  description". Listings that show
+// full paths ("user create <name>") only yield the first word, without a
+// description.
+func parseCommandLine(line string) *helpEntry {
+	names, short := splitColumns(line)
+	aliases := strings.Split(names, ",")
+	words := strings.Fields(aliases[0])
+	if len(words) == 0 {
+		return nil
+	}
+
+	entry := &helpEntry{Name: strings.TrimSuffix(words[0], ":"), Short: short}
+	if len(words) > 1 && !strings.HasPrefix(words[1], "<") && !strings.HasPrefix(words[1], "[") {
+		entry.Short = ""
+	}
+	for _, alias := range aliases[1:] {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			entry.Aliases = append(entry.Aliases, alias)
+		}
+	}
+	return entry
+}
+
+// parseFlagLine parses a flag definition such as "-c, --config string",
+// "--config value, -c value", "-w, --width=COLS" or "--color[=WHEN]"
+func parseFlagLine(line string) *parser.FlagInfo {
+	spec, usage := splitColumns(line)
+	info := &parser.FlagInfo{
+		Usage:       usage,
+		Annotations: make(map[string]string),
+	}
+
+	// urfave/cli marks repeatable flags with "[ --name value ]"
+	repeated := false
+	if i := strings.Index(spec, "[ -"); i >= 0 {
+		repeated = true
+		spec = strings.TrimSpace(spec[:i])
+	}
+
+	var long, short, placeholder string
+	for _, part := range strings.Split(spec, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		token := fields[0]
+		if len(fields) > 1 {
+			placeholder = fields[1]
+		}
+		if i := strings.Index(token, "[="); i >= 0 {
+			placeholder = strings.TrimSuffix(token[i+2:], "]")
+			token = token[:i]
+		} else if i := strings.Index(token, "="); i >= 0 {
+			placeholder = token[i+1:]
+			token = token[:i]
+		}
+
+		double := strings.HasPrefix(token, "--")
+		name := strings.TrimLeft(token, "-")
+		if strings.HasPrefix(name, "[no-]") {
+			name = strings.TrimPrefix(name, "[no-]")
+			info.Annotations["negatable"] = "no-" + name
+		}
+		switch {
+		case name == "":
+		case !double && len(name) == 1:
+			if short == "" {
+				short = name
+			}
+		case long == "":
+			long = name
+		}
+	}
+
+	info.Name = long
+	if info.Name == "" {
+		info.Name = short
+	} else {
+		info.Shorthand = short
+	}
+	info.Type = placeholderType(placeholder, repeated)
+	if placeholder != "" && placeholderTypes[strings.ToLower(placeholder)] == "" && strings.ToLower(placeholder) != "value" {
+		info.Annotations["placeholder"] = placeholder
+	}
+	return info
+}
+
+// finishFlag moves defaults, environment variables and required markers out
+// of the usage text
+func finishFlag(info *parser.FlagInfo) {
+	if m := envRe.FindStringSubmatch(info.Usage); m != nil {
+		names := make([]string, 0)
+		for _, name := range strings.Split(m[1], ",") {
+			names = append(names, strings.TrimPrefix(strings.TrimSpace(name), "$"))
+		}
+		info.Annotations["envVars"] = strings.Join(names, ",")
+		info.Usage = strings.Replace(info.Usage, m[0], "", 1)
+	}
+	if m := defaultRe.FindStringSubmatch(info.Usage); m != nil {
+		value := strings.TrimSpace(m[1])
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		info.DefaultValue = value
+		info.Usage = strings.Replace(info.Usage, m[0], "", 1)
+	}
+	if m := requiredRe.FindString(info.Usage); m != "" {
+		info.Required = true
+		info.Usage = strings.Replace(info.Usage, m, "", 1)
+	}
+	info.Usage = strings.TrimSpace(info.Usage)
+}
+
+// placeholderType derives a flag type from its value placeholder; flags
+// without a value are booleans
+func placeholderType(placeholder string, repeated bool) string {
+	if placeholder == "" {
+		return "bool"
+	}
+	flagType, ok := placeholderTypes[strings.ToLower(placeholder)]
+	if !ok {
+		flagType = "string"
+	}
+	if repeated && !strings.HasSuffix(flagType, "Slice") {
+		flagType += "Slice"
+	}
+	return flagType
+}
+
+// parseUsageArgs derives positional arguments from the usage line of the
+// command at path
+func parseUsageArgs(usage []string, path []string) []*parser.ArgumentInfo {
+	for _, line := range usage {
+		fields := strings.Fields(usageMetaRe.ReplaceAllString(line, ""))
+		start := -1
+		if len(path) == 0 {
+			start = 0
+		} else {
+			for i, field := range fields {
+				if field == path[len(path)-1] {
+					start = i
+				}
+			}
+		}
+		if start < 0 || start >= len(fields) {
+			continue
+		}
+
+		rest := make([]string, 0)
+		for _, field := range fields[start+1:] {
+			if strings.EqualFold(field, "command") || strings.HasPrefix(field, "-") {
+				continue
+			}
+			rest = append(rest, field)
+		}
+		if args := parseArgsUsage(rest); len(args) > 0 {
+			return args
+		}
+	}
+	return make([]*parser.ArgumentInfo, 0)
+}
+
+// parseArgsUsage converts usage tokens such as "<source> [files...]":
+// angle brackets and bare words are required, square brackets optional and
+// a trailing ellipsis repeatable.
+func parseArgsUsage(tokens []string) []*parser.ArgumentInfo {
+	args := make([]*parser.ArgumentInfo, 0)
+	for _, token := range tokens {
+		required := !strings.HasPrefix(token, "[")
+		variadic := strings.Contains(token, "...")
+		name := strings.Trim(token, "[]<>.")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		arg := &parser.ArgumentInfo{
+			Name:     name,
+			Position: len(args) + 1,
+			Required: required,
+			Type:     "string",
+			MaxArgs:  1,
+		}
+		if required {
+			arg.MinArgs = 1
+		}
+		if variadic {
+			arg.MaxArgs = -1
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+// splitColumns splits a listing line at the first gap of two spaces or a tab
+func splitColumns(line string) (string, string) {
+	gap := -1
+	if i := strings.Index(line, "  "); i >= 0 {
+		gap = i
+	}
+	if i := strings.Index(line, "\t"); i >= 0 && (gap < 0 || i < gap) {
+		gap = i
+	}
+	if gap < 0 {
+		return line, ""
+	}
+	return strings.TrimSpace(line[:gap]), strings.TrimSpace(line[gap:])
+}
+
+// indentation returns the width of leading whitespace, counting tabs as eight
+func indentation(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 8
+		default:
+			return width
+		}
+	}
+	return width
+}
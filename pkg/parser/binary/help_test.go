@@ -0,0 +1,149 @@
+package binary
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+)
+
+func readHelp(t *testing.T, name string) *helpDoc {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parseHelp(string(data))
+}
+
+func findFlag(flags []*parser.FlagInfo, name string) *parser.FlagInfo {
+	for _, flag := range flags {
+		if flag.Name == name {
+			return flag
+		}
+	}
+	return nil
+}
+
+func TestParseHelp_Cobra(t *testing.T) {
+	doc := readHelp(t, "cobra_root.txt")
+	if doc.Style != styleCobra {
+		t.Errorf("Expected cobra style, got %s", doc.Style)
+	}
+	if doc.Long != "Testapp manages users and deployments." {
+		t.Errorf("Unexpected description %q", doc.Long)
+	}
+	if len(doc.Commands) != 3 || doc.Commands[2].Name != "user" || doc.Commands[2].Short != "User management" {
+		t.Errorf("Unexpected commands: %+v", doc.Commands)
+	}
+
+	config := findFlag(doc.Flags, "config")
+	if config == nil || config.Shorthand != "c" || config.Type != "string" || config.DefaultValue != "app.yaml" || config.Usage != "config file" {
+		t.Errorf("Unexpected config flag: %+v", config)
+	}
+	if f := findFlag(doc.Flags, "verbose"); f == nil || f.Type != "bool" || f.Shorthand != "v" {
+		t.Errorf("Unexpected verbose flag: %+v", f)
+	}
+	if f := findFlag(doc.Flags, "workers"); f == nil || f.Type != "int" || f.DefaultValue != "4" {
+		t.Errorf("Unexpected workers flag: %+v", f)
+	}
+
+	create := readHelp(t, "cobra_user_create.txt")
+	if create.Example != "  testapp user create jane admins" {
+		t.Errorf("Unexpected example %q", create.Example)
+	}
+	if f := findFlag(create.Flags, "tags"); f == nil || f.Type != "stringSlice" {
+		t.Errorf("Expected stringSlice tags flag, got %+v", f)
+	}
+	if f := findFlag(create.Flags, "timeout"); f == nil || f.Type != "duration" || f.DefaultValue != "30s" {
+		t.Errorf("Expected duration timeout flag, got %+v", f)
+	}
+	if len(create.GlobalFlags) != 2 {
+		t.Errorf("Expected 2 global flags, got %d", len(create.GlobalFlags))
+	}
+
+	args := parseUsageArgs(create.Usage, []string{"user", "create"})
+	if len(args) != 2 || args[0].Name != "username" || !args[0].Required || args[1].Required || args[1].MaxArgs != -1 {
+		t.Errorf("Unexpected args: %+v", args)
+	}
+}
+
+func TestParseHelp_Urfave(t *testing.T) {
+	doc := readHelp(t, "urfave_root.txt")
+	if doc.Style != styleUrfave || doc.Name != "testapp" || doc.Short != "A test application" || doc.Version != "1.0.0" {
+		t.Errorf("Unexpected doc: %+v", doc)
+	}
+	if len(doc.Commands) != 2 || doc.Commands[1].Name != "user" || doc.Commands[1].Category != "accounts" {
+		t.Errorf("Unexpected commands: %+v", doc.Commands)
+	}
+
+	config := findFlag(doc.GlobalFlags, "config")
+	if config == nil || config.Shorthand != "c" || config.DefaultValue != "app.yaml" || config.Annotations["envVars"] != "TESTAPP_CONFIG" {
+		t.Errorf("Unexpected config flag: %+v", config)
+	}
+	if config.Usage != "config file" {
+		t.Errorf("Expected cleaned usage, got %q", config.Usage)
+	}
+
+	create := readHelp(t, "urfave_user_create.txt")
+	if f := findFlag(create.Flags, "tag"); f == nil || f.Type != "stringSlice" {
+		t.Errorf("Expected repeatable tag flag, got %+v", f)
+	}
+	args := parseUsageArgs(create.Usage, []string{"user", "create"})
+	if len(args) != 2 || args[0].Name != "username" {
+		t.Errorf("Unexpected args: %+v", args)
+	}
+}
+
+func TestParseHelp_GNU(t *testing.T) {
+	doc := readHelp(t, "gnu_env.txt")
+	if doc.Style != styleGNU {
+		t.Errorf("Expected gnu style, got %s", doc.Style)
+	}
+	if f := findFlag(doc.Flags, "unset"); f == nil || f.Shorthand != "u" || f.Type != "string" || f.Annotations["placeholder"] != "NAME" {
+		t.Errorf("Unexpected unset flag: %+v", f)
+	}
+	if f := findFlag(doc.Flags, "split-string"); f == nil || f.Usage != "process and split S into separate arguments; used to pass multiple arguments on shebang lines" {
+		t.Errorf("Expected wrapped usage, got %+v", f)
+	}
+	if f := findFlag(doc.Flags, "block-signal"); f == nil || f.Type != "string" {
+		t.Errorf("Expected optional-value flag, got %+v", f)
+	}
+	if f := findFlag(doc.Flags, "null"); f == nil || f.Shorthand != "0" || f.Type != "bool" {
+		t.Errorf("Unexpected null flag: %+v", f)
+	}
+}
+
+func TestParseHelp_Flag(t *testing.T) {
+	doc := readHelp(t, "flag_root.txt")
+	if doc.Style != styleFlag || doc.Name != "tool" {
+		t.Errorf("Unexpected doc: %+v", doc)
+	}
+	if len(doc.Flags) != 3 {
+		t.Fatalf("Expected 3 flags, got %d", len(doc.Flags))
+	}
+	if f := findFlag(doc.Flags, "config"); f == nil || f.Usage != "config file" || f.DefaultValue != "app.yaml" {
+		t.Errorf("Unexpected config flag: %+v", f)
+	}
+	if f := findFlag(doc.Flags, "v"); f == nil || f.Type != "bool" || f.Usage != "verbose output" {
+		t.Errorf("Unexpected v flag: %+v", f)
+	}
+}
+
+func TestHelpFlags(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "cobra_user_create.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0)
+	for _, flag := range HelpFlags(string(data)) {
+		names = append(names, flag.Name)
+	}
+	// help is added by Cobra, the global flags are listed as well
+	want := []string{"role", "tags", "timeout", "config", "verbose"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected flags %v, got %v", want, names)
+	}
+}
@@ -0,0 +1,18 @@
+#!/bin/sh
+# Prints recorded help output for the style named by $FAKECLI_STYLE.
+# Invocations are appended to $FAKECLI_LOG when it is set.
+dir=$(dirname "$0")
+[ -n "$FAKECLI_LOG" ] && echo "$*" >> "$FAKECLI_LOG"
+case "$FAKECLI_STYLE $*" in
+"cobra --help") cat "$dir/cobra_root.txt" ;;
+"cobra user --help" | "cobra help user") cat "$dir/cobra_user.txt" ;;
+"cobra user create --help" | "cobra help user create") cat "$dir/cobra_user_create.txt" ;;
+"gnu --help") cat "$dir/gnu_env.txt" ;;
+"urfave --help") cat "$dir/urfave_root.txt" ;;
+# urfave/cli v2 omits subcommands from "help <cmd>"
+"urfave help user" | "urfave help user create") cat "$dir/urfave_help_user.txt" ;;
+"urfave user --help") cat "$dir/urfave_user.txt" ;;
+"urfave user create --help") cat "$dir/urfave_user_create.txt" ;;
+"slow --help") sleep 5 ;;
+*) echo "unknown command" >&2; exit 1 ;;
+esac
@@ -1,36 +1,71 @@
 package cobra
 
 import (
-	"reflect"
 	"strings"
+	"time"
 
 	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
 // CobraParser implements the Parser interface for Cobra CLI framework
-type CobraParser struct{}
+type CobraParser struct {
+	opts Options
+}
+
+// Options enables the parts of parsing that run code of the CLI
+type Options struct {
+	// Completions calls the ValidArgsFunction of every command and the
+	// completion functions registered for its flags with no arguments and
+	// an empty word, and records the candidates and directives they return
+	Completions bool
+	// CompletionTimeout bounds every completion call; zero means
+	// DefaultCompletionTimeout
+	CompletionTimeout time.Duration
+}
 
 // NewCobraParser creates a new Cobra parser
 func NewCobraParser() *CobraParser {
 	return &CobraParser{}
 }
 
+// NewCobraParserWithOptions creates a Cobra parser with optional features
+// enabled. Register it in place of the default parser:
+//
+//	g := gospec.New()
+//	g.RegisterParser(cobra.NewCobraParserWithOptions(cobra.Options{Completions: true}))
+func NewCobraParserWithOptions(opts Options) *CobraParser {
+	return &CobraParser{opts: opts}
+}
+
 // Name returns the parser name
 func (p *CobraParser) Name() string {
 	return "cobra"
 }
 
-// Supports checks if the source is a Cobra command
+// Supports checks if the source is a Cobra command, optionally bound to Viper
 func (p *CobraParser) Supports(source interface{}) bool {
-	_, ok := source.(*cobra.Command)
-	return ok
+	switch source.(type) {
+	case *cobra.Command, *ViperSource:
+		return true
+	}
+	return false
 }
 
 // Parse extracts CLI structure from a Cobra command
 func (p *CobraParser) Parse(source interface{}) (*parser.ParsedCLI, error) {
+	var v *viper.Viper
 	cmd, ok := source.(*cobra.Command)
+	if src, isViper := source.(*ViperSource); isViper && src != nil {
+		cmd, ok = src.Command, src.Command != nil
+		v = src.Viper
+		if v == nil {
+			v = viper.GetViper()
+		}
+	}
 	if !ok {
 		return nil, &parser.ParserError{
 			Message: "source is not a *cobra.Command",
@@ -51,9 +86,17 @@ func (p *CobraParser) Parse(source interface{}) (*parser.ParsedCLI, error) {
 	// Parse all subcommands recursively
 	p.parseSubcommands(cmd, rootInfo, parsed.Commands)
 
+	if p.opts.Completions {
+		parsed.Warnings = append(parsed.Warnings, p.parseCompletions(cmd, parsed.Commands)...)
+	}
+
 	// Extract metadata
 	parsed.Metadata = p.extractMetadata(cmd)
 
+	if v != nil {
+		p.parseViper(cmd, v, parsed)
+	}
+
 	// Store framework-specific data
 	parsed.FrameworkData["framework"] = "cobra"
 	parsed.FrameworkData["version"] = getCobraVersion()
@@ -104,6 +147,11 @@ func (p *CobraParser) parseCommand(cmd *cobra.Command, parent *parser.CommandInf
 					return
 				}
 			}
+			// Cobra merges inherited flags into Flags() once a subcommand
+			// has been parsed or has had flag groups marked
+			if !ownsFlag(cmd, flag) {
+				return
+			}
 			info.Flags = append(info.Flags, p.parseFlag(flag, false))
 		})
 	}
@@ -115,6 +163,8 @@ func (p *CobraParser) parseCommand(cmd *cobra.Command, parent *parser.CommandInf
 		})
 	}
 
+	info.FlagGroups = parseFlagGroups(cmd)
+
 	// Parse arguments from ValidArgs and Args
 	info.Args = p.parseArguments(cmd)
 
@@ -136,7 +186,7 @@ func (p *CobraParser) parseFlag(flag *pflag.Flag, persistent bool) *parser.FlagI
 	if flag.Annotations != nil {
 		for key, values := range flag.Annotations {
 			if len(values) > 0 {
-				annotations[key] = values[0]
+				annotations[key] = strings.Join(values, ",")
 			}
 		}
 	}
@@ -146,7 +196,7 @@ func (p *CobraParser) parseFlag(flag *pflag.Flag, persistent bool) *parser.FlagI
 		Shorthand:    flag.Shorthand,
 		Usage:        flag.Usage,
 		Type:         flag.Value.Type(),
-		DefaultValue: flag.DefValue,
+		DefaultValue: typedDefault(flag),
 		Required:     isRequiredFlag(flag),
 		Hidden:       flag.Hidden,
 		Deprecated:   flag.Deprecated,
@@ -162,49 +212,165 @@ func (p *CobraParser) parseFlag(flag *pflag.Flag, persistent bool) *parser.FlagI
 	return flagInfo
 }
 
-// parseArguments extracts argument information from Cobra command
+// Annotations Cobra sets on every flag of a group, one value per group
+// holding the space-separated names of its flags
+var flagGroupAnnotations = []struct {
+	annotation string
+	kind       string
+}{
+	{"cobra_annotation_mutually_exclusive", spec.FlagGroupMutuallyExclusive},
+	{"cobra_annotation_required_if_others_set", spec.FlagGroupRequiredTogether},
+	{"cobra_annotation_one_required", spec.FlagGroupOneRequired},
+}
+
+// parseFlagGroups returns the groups set up with MarkFlagsMutuallyExclusive,
+// MarkFlagsRequiredTogether and MarkFlagsOneRequired that apply to cmd.
+// Cobra annotates the flags themselves, and persistent flags are shared
+// with subcommands, so a group is reported on the commands that define at
+// least one of its flags and see all the others.
+func parseFlagGroups(cmd *cobra.Command) []*parser.FlagGroupInfo {
+	groups := make([]*parser.FlagGroupInfo, 0)
+	seen := make(map[string]bool)
+
+	visit := func(flag *pflag.Flag) {
+		if !ownsFlag(cmd, flag) {
+			return
+		}
+		for _, g := range flagGroupAnnotations {
+			for _, group := range flag.Annotations[g.annotation] {
+				names := strings.Fields(group)
+				key := g.kind + "\x00" + strings.Join(names, " ")
+				if len(names) == 0 || seen[key] {
+					continue
+				}
+				seen[key] = true
+				if !seesFlags(cmd, names) {
+					continue
+				}
+				groups = append(groups, &parser.FlagGroupInfo{Kind: g.kind, Flags: names})
+			}
+		}
+	}
+	cmd.Flags().VisitAll(visit)
+	cmd.PersistentFlags().VisitAll(visit)
+	return groups
+}
+
+// ownsFlag reports whether flag is defined by cmd rather than inherited
+// from one of its ancestors
+func ownsFlag(cmd *cobra.Command, flag *pflag.Flag) bool {
+	for parent := cmd.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.PersistentFlags().Lookup(flag.Name) == flag {
+			return false
+		}
+	}
+	return true
+}
+
+// seesFlags reports whether every named flag is defined by cmd or
+// inherited from one of its ancestors
+func seesFlags(cmd *cobra.Command, names []string) bool {
+	for _, name := range names {
+		found := cmd.Flags().Lookup(name) != nil || cmd.PersistentFlags().Lookup(name) != nil
+		for parent := cmd.Parent(); parent != nil && !found; parent = parent.Parent() {
+			found = parent.PersistentFlags().Lookup(name) != nil
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// parseArguments derives the positional arguments of a Cobra command from
+// its Args validator, naming them after the placeholders of its Use line
 func (p *CobraParser) parseArguments(cmd *cobra.Command) []*parser.ArgumentInfo {
 	args := make([]*parser.ArgumentInfo, 0)
+	valid := validArgs(cmd)
 
-	// If ValidArgs is set, create arguments from it
-	if len(cmd.ValidArgs) > 0 {
-		for i, validArg := range cmd.ValidArgs {
-			args = append(args, &parser.ArgumentInfo{
-				Name:        validArg,
-				Position:    i + 1,
-				Required:    true,
-				Type:        "string",
-				ValidValues: []string{validArg},
-			})
+	if cmd.Args == nil {
+		// Without a validator Cobra accepts any arguments for commands
+		// without subcommands; ValidArgs are then completion hints only
+		if (len(valid) > 0 || cmd.ValidArgsFunction != nil) && !cmd.HasSubCommands() {
+			args = append(args, &parser.ArgumentInfo{Name: "args", Position: 1, Type: "string", MaxArgs: -1})
 		}
 		return args
 	}
 
-	// Infer from Args validator
-	if cmd.Args != nil {
-		minArgs, maxArgs := inferArgsArity(cmd)
-		if minArgs > 0 || maxArgs > 0 {
-			// Create generic argument info
-			argName := "args"
-			if cmd.Use != "" {
-				// Try to extract argument name from Use string
-				parts := strings.Fields(cmd.Use)
-				if len(parts) > 1 {
-					argName = parts[1]
-				}
-			}
+	minArgs, maxArgs, ok := inferArgsArity(cmd)
+	if !ok || maxArgs == 0 {
+		return args
+	}
 
-			args = append(args, &parser.ArgumentInfo{
-				Name:     argName,
-				Position: 1,
-				Required: minArgs > 0,
-				Type:     "string",
-				MinArgs:  minArgs,
-				MaxArgs:  maxArgs,
-			})
+	args = usageArgs(cmd.Use, minArgs, maxArgs)
+	if len(valid) > 0 && onlyValidArgs(cmd, minArgs) {
+		for _, arg := range args {
+			arg.ValidValues = valid
 		}
 	}
+	return args
+}
+
+// usageArgs spreads minArgs..maxArgs arguments over the placeholders of a
+// Use line such as "copy <source> [files...]". A single argument holding
+// the whole range is returned when they do not match.
+func usageArgs(use string, minArgs, maxArgs int) []*parser.ArgumentInfo {
+	tokens := make([]string, 0)
+	fields := strings.Fields(use)
+	if len(fields) > 0 {
+		fields = fields[1:]
+	}
+	for _, field := range fields {
+		if field == "[flags]" || field == "[command]" || strings.HasPrefix(field, "-") || strings.HasPrefix(field, "[-") {
+			continue
+		}
+		if strings.Trim(field, "[]<>.") != "" {
+			tokens = append(tokens, field)
+		}
+	}
+
+	n := len(tokens)
+	variadic := n > 0 && (strings.Contains(tokens[n-1], "...") || maxArgs < 0)
+	fits := n > 0 && ((variadic && (maxArgs < 0 || maxArgs >= n)) || (!variadic && maxArgs == n))
+	if !fits {
+		name := "args"
+		if n > 0 {
+			name = strings.Trim(tokens[0], "[]<>.")
+		} else if maxArgs == 1 {
+			name = "arg"
+		}
+		return []*parser.ArgumentInfo{{
+			Name:     name,
+			Position: 1,
+			Required: minArgs > 0,
+			Type:     "string",
+			MinArgs:  minArgs,
+			MaxArgs:  maxArgs,
+		}}
+	}
 
+	args := make([]*parser.ArgumentInfo, n)
+	for i, token := range tokens {
+		lo, hi := 0, 1
+		if i < minArgs {
+			lo = 1
+		}
+		if i == n-1 && variadic {
+			lo = max(0, minArgs-(n-1))
+			hi = -1
+			if maxArgs >= 0 {
+				hi = maxArgs - (n - 1)
+			}
+		}
+		args[i] = &parser.ArgumentInfo{
+			Name:     strings.Trim(token, "[]<>."),
+			Position: i + 1,
+			Required: lo > 0,
+			Type:     "string",
+			MinArgs:  lo,
+			MaxArgs:  hi,
+		}
+	}
 	return args
 }
 
@@ -275,13 +441,13 @@ func extractTags(cmd *cobra.Command) []string {
 }
 
 func isRequiredFlag(flag *pflag.Flag) bool {
-	// Check if flag has required annotation
-	if flag.Annotations != nil {
-		if _, ok := flag.Annotations["required"]; ok {
-			return true
-		}
+	// MarkFlagRequired sets BashCompOneRequiredFlag to "true"; a plain
+	// "required" annotation is honored as well
+	if values, ok := flag.Annotations[cobra.BashCompOneRequiredFlag]; ok {
+		return len(values) > 0 && values[0] == "true"
 	}
-	return false
+	_, ok := flag.Annotations["required"]
+	return ok
 }
 
 func extractValidValues(flag *pflag.Flag) []string {
@@ -296,33 +462,83 @@ func extractValidValues(flag *pflag.Flag) []string {
 	return nil
 }
 
-func inferArgsArity(cmd *cobra.Command) (min, max int) {
-	// This is a best-effort inference based on common Cobra validators
-	// In practice, you might need to use reflection or custom annotations
-
+// inferArgsArity probes the Args validator of cmd with 0 to argsProbeLimit
+// arguments and returns the fewest and the most it accepts, so that
+// closures such as ExactArgs(2), RangeArgs(1, 3) or MatchAll compositions
+// are recognized. max is -1 when argsProbeLimit arguments are accepted. ok
+// is false when no count is accepted, as for validators checking the
+// contents of the arguments.
+func inferArgsArity(cmd *cobra.Command) (min, max int, ok bool) {
 	if cmd.Args == nil {
-		return 0, -1 // unlimited
-	}
-
-	// Try to infer from common validators
-	argsType := reflect.TypeOf(cmd.Args)
-	if argsType != nil {
-		argsName := argsType.String()
-		switch {
-		case strings.Contains(argsName, "NoArgs"):
-			return 0, 0
-		case strings.Contains(argsName, "ExactArgs"):
-			return 1, 1 // Default, would need more context
-		case strings.Contains(argsName, "MinimumNArgs"):
-			return 1, -1
-		case strings.Contains(argsName, "MaximumNArgs"):
-			return 0, 1
-		case strings.Contains(argsName, "RangeArgs"):
-			return 1, -1 // Default range
+		return 0, -1, true
+	}
+
+	min, max = -1, -1
+	for n := 0; n <= argsProbeLimit; n++ {
+		if !acceptsArgs(cmd, probeArgs(cmd, n)) {
+			continue
+		}
+		if min < 0 {
+			min = n
 		}
+		max = n
+	}
+	if min < 0 {
+		return 0, -1, false
+	}
+	if max == argsProbeLimit {
+		max = -1
 	}
+	return min, max, true
+}
+
+// argsProbeLimit is the largest argument count inferArgsArity tries
+const argsProbeLimit = 16
+
+// onlyValidArgs reports whether the validator of cmd rejects arguments
+// outside ValidArgs, as OnlyValidArgs does
+func onlyValidArgs(cmd *cobra.Command, n int) bool {
+	if n == 0 {
+		n = 1
+	}
+	args := probeArgs(cmd, n)
+	args[0] = "gospec-invalid-argument"
+	return acceptsArgs(cmd, probeArgs(cmd, n)) && !acceptsArgs(cmd, args)
+}
 
-	return 0, -1 // Default: unlimited
+// probeArgs returns n arguments, using the first of ValidArgs so that an
+// OnlyValidArgs check does not reject them
+func probeArgs(cmd *cobra.Command, n int) []string {
+	value := "arg"
+	if valid := validArgs(cmd); len(valid) > 0 {
+		value = valid[0]
+	}
+	args := make([]string, n)
+	for i := range args {
+		args[i] = value
+	}
+	return args
+}
+
+// acceptsArgs runs the validator of cmd; a panicking validator rejects
+func acceptsArgs(cmd *cobra.Command, args []string) (accepted bool) {
+	defer func() {
+		if recover() != nil {
+			accepted = false
+		}
+	}()
+	return cmd.Args(cmd, args) == nil
+}
+
+// validArgs returns the ValidArgs of cmd without the descriptions Cobra
+// allows after a tab
+func validArgs(cmd *cobra.Command) []string {
+	values := make([]string, 0, len(cmd.ValidArgs))
+	for _, v := range cmd.ValidArgs {
+		value, _, _ := strings.Cut(v, "\t")
+		values = append(values, value)
+	}
+	return values
 }
 
 func getCobraVersion() string {
@@ -1,7 +1,12 @@
 package cobra
 
 import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -177,6 +182,121 @@ func TestCobraParser_ParseFlags(t *testing.T) {
 			t.Errorf("Flag '%s': expected type '%s', got '%s'", name, expectedType, flagTypes[name])
 		}
 	}
+
+	for _, flag := range parsed.RootCommand.Flags {
+		if flag.Required != (flag.Name == "required") {
+			t.Errorf("Flag '%s': expected required %v, got %v", flag.Name, flag.Name == "required", flag.Required)
+		}
+	}
+}
+
+func TestCobraParser_TypedDefaults(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	flags := cmd.Flags()
+	flags.Int("port", 8080, "")
+	flags.Int("zero", 0, "")
+	flags.Uint64("size", 1<<40, "")
+	flags.Float64("ratio", 0.5, "")
+	flags.Bool("color", true, "")
+	flags.Bool("quiet", false, "")
+	flags.String("name", "", "")
+	flags.String("mode", "fast", "")
+	flags.Duration("timeout", 90*time.Second, "")
+	flags.Duration("delay", 0, "")
+	flags.StringSlice("tags", []string{"a", "b,c"}, "")
+	flags.StringSlice("empty", nil, "")
+	flags.IntSlice("ports", []int{80, 443}, "")
+	flags.BoolSlice("switches", []bool{true, false}, "")
+	flags.StringToString("labels", map[string]string{"env": "prod", "team": "core"}, "")
+	flags.StringToInt("limits", map[string]int{"cpu": 2}, "")
+	flags.IP("bind", net.IPv4(127, 0, 0, 1), "")
+	flags.IP("peer", nil, "")
+	flags.CountP("verbose", "v", "")
+
+	parsed, err := NewCobraParser().Parse(cmd)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"port":     8080,
+		"zero":     nil,
+		"size":     1 << 40,
+		"ratio":    0.5,
+		"color":    true,
+		"quiet":    nil,
+		"name":     nil,
+		"mode":     "fast",
+		"timeout":  "1m30s",
+		"delay":    nil,
+		"tags":     []interface{}{"a", "b,c"},
+		"empty":    nil,
+		"ports":    []interface{}{80, 443},
+		"switches": []interface{}{true, false},
+		"labels":   map[string]interface{}{"env": "prod", "team": "core"},
+		"limits":   map[string]interface{}{"cpu": 2},
+		"bind":     "127.0.0.1",
+		"peer":     nil,
+		"verbose":  nil,
+	}
+	for _, flag := range parsed.RootCommand.Flags {
+		if !reflect.DeepEqual(flag.DefaultValue, want[flag.Name]) {
+			t.Errorf("--%s: expected default %#v, got %#v", flag.Name, want[flag.Name], flag.DefaultValue)
+		}
+	}
+}
+
+func TestCobraParser_ParseFlagGroups(t *testing.T) {
+	root := &cobra.Command{Use: "app"}
+	root.PersistentFlags().Bool("verbose", false, "")
+	root.PersistentFlags().Bool("quiet", false, "")
+	root.MarkFlagsMutuallyExclusive("verbose", "quiet")
+
+	export := &cobra.Command{Use: "export", Run: func(*cobra.Command, []string) {}}
+	export.Flags().Bool("json", false, "")
+	export.Flags().Bool("yaml", false, "")
+	export.Flags().String("user", "", "")
+	export.Flags().String("password", "", "")
+	root.AddCommand(export)
+	export.MarkFlagsMutuallyExclusive("json", "yaml")
+	export.MarkFlagsMutuallyExclusive("json", "quiet")
+	export.MarkFlagsRequiredTogether("user", "password")
+	export.MarkFlagsOneRequired("json", "yaml")
+
+	parsed, err := NewCobraParser().Parse(root)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// Marking groups merges inherited flags into the flags of export
+	for _, flag := range parsed.Commands["app/export"].Flags {
+		if flag.Name == "verbose" || flag.Name == "quiet" {
+			t.Errorf("Expected inherited flag %q not to be a local flag of export", flag.Name)
+		}
+	}
+
+	groupsOf := func(path string) []string {
+		groups := make([]string, 0)
+		for _, g := range parsed.Commands[path].FlagGroups {
+			groups = append(groups, g.Kind+": "+strings.Join(g.Flags, " "))
+		}
+		return groups
+	}
+
+	tests := map[string][]string{
+		"app": {"mutually-exclusive: verbose quiet"},
+		"app/export": {
+			"mutually-exclusive: json yaml",
+			"mutually-exclusive: json quiet",
+			"one-required: json yaml",
+			"required-together: user password",
+		},
+	}
+	for path, want := range tests {
+		if got := groupsOf(path); strings.Join(got, "; ") != strings.Join(want, "; ") {
+			t.Errorf("%s: expected groups %q, got %q", path, want, got)
+		}
+	}
 }
 
 func TestCobraParser_ParseMetadata(t *testing.T) {
@@ -258,3 +378,75 @@ func TestCobraParser_ParseHiddenCommands(t *testing.T) {
 		}
 	}
 }
+
+func TestCobraParser_ParseArguments(t *testing.T) {
+	type arg struct {
+		name     string
+		min, max int
+	}
+	onlyX := func(cmd *cobra.Command, args []string) error {
+		for _, a := range args {
+			if a != "x" {
+				return fmt.Errorf("invalid argument %q", a)
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name      string
+		use       string
+		args      cobra.PositionalArgs
+		validArgs []string
+		want      []arg
+		enum      bool
+	}{
+		{name: "no args", use: "cmd <name>", args: cobra.NoArgs, want: []arg{}},
+		{name: "exact", use: "cmd <name>", args: cobra.ExactArgs(1), want: []arg{{"name", 1, 1}}},
+		{name: "exact spread over placeholders", use: "cmd <src> <dst> [flags]", args: cobra.ExactArgs(2), want: []arg{{"src", 1, 1}, {"dst", 1, 1}}},
+		{name: "exact without placeholders", use: "cmd", args: cobra.ExactArgs(2), want: []arg{{"args", 2, 2}}},
+		{name: "range", use: "cmd <name> [more...]", args: cobra.RangeArgs(1, 3), want: []arg{{"name", 1, 1}, {"more", 0, 2}}},
+		{name: "minimum", use: "cmd <files>...", args: cobra.MinimumNArgs(2), want: []arg{{"files", 2, -1}}},
+		{name: "maximum", use: "cmd [name]", args: cobra.MaximumNArgs(1), want: []arg{{"name", 0, 1}}},
+		{name: "arbitrary", use: "cmd [args...]", args: cobra.ArbitraryArgs, want: []arg{{"args", 0, -1}}},
+		{
+			name: "match all with only valid args", use: "cmd <state>",
+			args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+			validArgs: []string{"active\tthe user can log in", "locked"},
+			want:      []arg{{"state", 1, 1}}, enum: true,
+		},
+		{
+			name: "valid args not enforced", use: "cmd <state>",
+			args: cobra.ExactArgs(1), validArgs: []string{"active", "locked"},
+			want: []arg{{"state", 1, 1}},
+		},
+		{name: "content validator", use: "cmd <name>", args: onlyX, want: []arg{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: tt.use, Args: tt.args, ValidArgs: tt.validArgs, Run: func(*cobra.Command, []string) {}}
+			parsed, err := NewCobraParser().Parse(cmd)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			got := parsed.RootCommand.Args
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d arguments, got %d: %+v", len(tt.want), len(got), got)
+			}
+			for i, want := range tt.want {
+				a := got[i]
+				if a.Name != want.name || a.MinArgs != want.min || a.MaxArgs != want.max || a.Position != i+1 || a.Required != (want.min > 0) {
+					t.Errorf("Argument %d: expected %+v, got %+v", i, want, *a)
+				}
+				if tt.enum != (len(a.ValidValues) > 0) {
+					t.Errorf("Argument %d: unexpected valid values %v", i, a.ValidValues)
+				}
+				if tt.enum && strings.Join(a.ValidValues, ",") != "active,locked" {
+					t.Errorf("Argument %d: expected valid values without descriptions, got %v", i, a.ValidValues)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package cobra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+// DefaultCompletionTimeout bounds a completion call when
+// Options.CompletionTimeout is zero
+const DefaultCompletionTimeout = 2 * time.Second
+
+// parseCompletions calls the completion functions of cmd and its
+// descendants and records what they return on the parsed commands. It
+// returns a warning for every call that failed.
+func (p *CobraParser) parseCompletions(cmd *cobra.Command, commands map[string]*parser.CommandInfo) []string {
+	warnings := make([]string, 0)
+
+	var walk func(cmd *cobra.Command, path string)
+	walk = func(cmd *cobra.Command, path string) {
+		if info, ok := commands[path]; ok {
+			warnings = append(warnings, p.completeCommand(cmd, info)...)
+		}
+		for _, child := range cmd.Commands() {
+			walk(child, path+"/"+child.Name())
+		}
+	}
+	walk(cmd, cmd.Name())
+	return warnings
+}
+
+// completeCommand records the completions of the first positional argument
+// and of the flags of one command. After a call times out, the command is
+// left alone, since the abandoned call may still be using it.
+func (p *CobraParser) completeCommand(cmd *cobra.Command, info *parser.CommandInfo) []string {
+	warnings := make([]string, 0)
+	path := cmd.CommandPath()
+
+	if cmd.ValidArgsFunction != nil && len(info.Args) > 0 {
+		completion, err := p.complete(cmd, cmd.ValidArgsFunction)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: completion of arguments %v", path, err))
+			if errors.Is(err, errCompletionTimeout) {
+				return warnings
+			}
+		}
+		info.Args[0].Completion = completion
+	}
+
+	for _, flags := range [][]*parser.FlagInfo{info.Flags, info.PersistentFlags} {
+		for _, flag := range flags {
+			fn, ok := cmd.GetFlagCompletionFunc(flag.Name)
+			if !ok {
+				continue
+			}
+			completion, err := p.complete(cmd, fn)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s: completion of --%s %v", path, flag.Name, err))
+				if errors.Is(err, errCompletionTimeout) {
+					return warnings
+				}
+			}
+			flag.Completion = completion
+		}
+	}
+	return warnings
+}
+
+// errCompletionTimeout is returned by complete for calls that did not
+// return in time
+var errCompletionTimeout = errors.New("timed out")
+
+// complete calls fn as Cobra does when the user asks for completions right
+// after the command name, with no arguments and an empty word. The call runs
+// with a fresh context of cmd that is cancelled when the timeout expires; a
+// panic is reported as an error. A nil completion means fn offered nothing
+// or reported ShellCompDirectiveError.
+func (p *CobraParser) complete(cmd *cobra.Command, fn cobra.CompletionFunc) (*parser.CompletionInfo, error) {
+	timeout := p.opts.CompletionTimeout
+	if timeout <= 0 {
+		timeout = DefaultCompletionTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	type result struct {
+		values    []cobra.Completion
+		directive cobra.ShellCompDirective
+		err       error
+	}
+	done := make(chan result, 1)
+	previous := cmd.Context()
+	cmd.SetContext(ctx)
+	go func() {
+		var r result
+		func() {
+			defer func() {
+				if v := recover(); v != nil {
+					r.err = fmt.Errorf("panicked: %v", v)
+				}
+			}()
+			r.values, r.directive = fn(cmd, []string{}, "")
+		}()
+		// The context is restored before the result is handed over, so it
+		// is the caller's again once complete returns in time
+		cmd.SetContext(previous)
+		done <- r
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		return completionInfo(r.values, r.directive), nil
+	case <-ctx.Done():
+		return nil, errCompletionTimeout
+	}
+}
+
+// completionInfo interprets the result of a completion function. With
+// ShellCompDirectiveFilterFileExt the values are file extensions, and with
+// ShellCompDirectiveFilterDirs the directory to complete in.
+func completionInfo(values []cobra.Completion, directive cobra.ShellCompDirective) *parser.CompletionInfo {
+	if directive&cobra.ShellCompDirectiveError != 0 {
+		return nil
+	}
+
+	words := make([]string, 0, len(values))
+	for _, value := range values {
+		// Candidates may carry a description after a tab
+		word, _, _ := strings.Cut(value, "\t")
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	info := &parser.CompletionInfo{
+		NoFiles:   directive&cobra.ShellCompDirectiveNoFileComp != 0,
+		NoSpace:   directive&cobra.ShellCompDirectiveNoSpace != 0,
+		KeepOrder: directive&cobra.ShellCompDirectiveKeepOrder != 0,
+	}
+	switch {
+	case directive&cobra.ShellCompDirectiveFilterFileExt != 0:
+		info.FileExtensions = words
+	case directive&cobra.ShellCompDirectiveFilterDirs != 0:
+		info.Directories = true
+		if len(words) > 0 {
+			info.Directory = words[0]
+		}
+	default:
+		info.Values = words
+	}
+
+	if len(info.Values) == 0 && len(info.FileExtensions) == 0 && !info.Directories && !info.NoFiles {
+		return nil
+	}
+	return info
+}
@@ -0,0 +1,141 @@
+package cobra
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+func completionApp(release chan struct{}) *cobra.Command {
+	root := &cobra.Command{Use: "app"}
+	root.PersistentFlags().String("profile", "", "")
+	root.RegisterFlagCompletionFunc("profile", func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return []cobra.Completion{"dev", "prod"}, cobra.ShellCompDirectiveKeepOrder
+	})
+
+	get := &cobra.Command{
+		Use: "get <name>",
+		Run: func(*cobra.Command, []string) {},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) != 0 || toComplete != "" || cmd.Context() == nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return []cobra.Completion{"alpha\tThe first", "beta"}, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+	get.Flags().String("config", "", "")
+	get.Flags().String("dir", "", "")
+	get.Flags().String("broken", "", "")
+	get.Flags().String("failing", "", "")
+	get.RegisterFlagCompletionFunc("config", func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return []cobra.Completion{"yaml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+	get.RegisterFlagCompletionFunc("dir", func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return []cobra.Completion{"themes"}, cobra.ShellCompDirectiveFilterDirs
+	})
+	get.RegisterFlagCompletionFunc("broken", func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		panic("no server")
+	})
+	get.RegisterFlagCompletionFunc("failing", func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return []cobra.Completion{"ignored"}, cobra.ShellCompDirectiveError
+	})
+
+	slow := &cobra.Command{
+		Use: "slow",
+		Run: func(*cobra.Command, []string) {},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			<-release
+			return nil, cobra.ShellCompDirectiveDefault
+		},
+	}
+	slow.Flags().String("later", "", "")
+	slow.RegisterFlagCompletionFunc("later", func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return []cobra.Completion{"never"}, cobra.ShellCompDirectiveDefault
+	})
+
+	root.AddCommand(get, slow)
+	return root
+}
+
+func TestCobraParser_ParseCompletions(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	root := completionApp(release)
+
+	parsed, err := NewCobraParserWithOptions(Options{Completions: true, CompletionTimeout: 50 * time.Millisecond}).Parse(root)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	flags := func(path string) map[string]*parser.FlagInfo {
+		m := make(map[string]*parser.FlagInfo)
+		info := parsed.Commands[path]
+		for _, flag := range append(append([]*parser.FlagInfo{}, info.Flags...), info.PersistentFlags...) {
+			m[flag.Name] = flag
+		}
+		return m
+	}
+
+	get := parsed.Commands["app/get"]
+	if len(get.Args) != 1 {
+		t.Fatalf("Expected one argument, got %d", len(get.Args))
+	}
+	tests := []struct {
+		name string
+		got  *parser.CompletionInfo
+		want *parser.CompletionInfo
+	}{
+		{"profile", flags("app")["profile"].Completion, &parser.CompletionInfo{Values: []string{"dev", "prod"}, KeepOrder: true}},
+		{"name", get.Args[0].Completion, &parser.CompletionInfo{Values: []string{"alpha", "beta"}, NoFiles: true}},
+		{"config", flags("app/get")["config"].Completion, &parser.CompletionInfo{FileExtensions: []string{"yaml", "json"}}},
+		{"dir", flags("app/get")["dir"].Completion, &parser.CompletionInfo{Directories: true, Directory: "themes"}},
+		{"broken", flags("app/get")["broken"].Completion, nil},
+		{"failing", flags("app/get")["failing"].Completion, nil},
+		{"later", flags("app/slow")["later"].Completion, nil},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: expected completion %+v, got %+v", tt.name, tt.want, tt.got)
+		}
+	}
+
+	warnings := strings.Join(parsed.Warnings, "\n")
+	for _, want := range []string{
+		"app get: completion of --broken panicked: no server",
+		"app slow: completion of arguments timed out",
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("Expected warning %q, got:\n%s", want, warnings)
+		}
+	}
+	if len(parsed.Warnings) != 2 {
+		t.Errorf("Expected 2 warnings, got %q", parsed.Warnings)
+	}
+
+	if root.Commands()[0].Context() != nil {
+		t.Error("Expected the context of the command to be restored")
+	}
+}
+
+func TestCobraParser_CompletionsOptIn(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+
+	parsed, err := NewCobraParser().Parse(completionApp(release))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for path, info := range parsed.Commands {
+		for _, flags := range [][]*parser.FlagInfo{info.Flags, info.PersistentFlags} {
+			for _, flag := range flags {
+				if flag.Completion != nil {
+					t.Errorf("%s --%s: expected no completion without Options.Completions", path, flag.Name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package cobra
+
+import (
+	"encoding/csv"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// typedDefault converts the default of a flag, which pflag keeps as the
+// text its String method returned, to the type of the flag's schema:
+// numbers and booleans become native values, slices become lists and maps
+// become objects. Durations, addresses and custom values stay text. Zero
+// defaults that pflag leaves out of the help, such as 0, "" and [], are
+// omitted by returning nil.
+func typedDefault(flag *pflag.Flag) interface{} {
+	typ := flag.Value.Type()
+	text := flag.DefValue
+	if isZeroDefault(typ, text) {
+		return nil
+	}
+
+	switch typ {
+	case "stringToString", "stringToInt", "stringToInt64":
+		entries := make(map[string]interface{})
+		for _, entry := range splitList(text) {
+			key, value, _ := strings.Cut(entry, "=")
+			if typ == "stringToString" {
+				entries[key] = value
+			} else {
+				entries[key] = scalarDefault("int", value)
+			}
+		}
+		return entries
+	}
+
+	if elem, ok := sliceElemType(typ); ok {
+		items := splitList(text)
+		values := make([]interface{}, len(items))
+		for i, item := range items {
+			values[i] = scalarDefault(elem, item)
+		}
+		return values
+	}
+	return scalarDefault(typ, text)
+}
+
+// isZeroDefault reports whether pflag's help would leave out a default
+func isZeroDefault(typ, text string) bool {
+	switch typ {
+	case "bool":
+		return text == "false" || text == ""
+	case "duration":
+		return text == "0" || text == "0s"
+	case "int", "int8", "int16", "int32", "int64", "count",
+		"uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return text == "0"
+	case "string":
+		return text == ""
+	case "ip", "ipMask", "ipNet":
+		return text == "<nil>"
+	case "stringToString", "stringToInt", "stringToInt64":
+		return text == "[]" || text == ""
+	}
+	if _, ok := sliceElemType(typ); ok {
+		return text == "[]" || text == ""
+	}
+	return text == "" || text == "<nil>" || text == "false" || text == "0"
+}
+
+// sliceElemType returns the element type of a pflag slice type such as
+// "intSlice" or "stringArray"
+func sliceElemType(typ string) (string, bool) {
+	if typ == "stringArray" {
+		return "string", true
+	}
+	if elem, ok := strings.CutSuffix(typ, "Slice"); ok && elem != "" {
+		return elem, true
+	}
+	return "", false
+}
+
+// scalarDefault converts the text of a single value of type typ
+func scalarDefault(typ, text string) interface{} {
+	switch typ {
+	case "bool":
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b
+		}
+	case "int", "int8", "int16", "int32", "int64", "count":
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return int(n)
+		}
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		if n, err := strconv.ParseUint(text, 10, 64); err == nil {
+			if n <= math.MaxInt {
+				return int(n)
+			}
+			return n
+		}
+	case "float32", "float64":
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	}
+	return text
+}
+
+// splitList splits the text of a slice or map value, "[a,b]", into its
+// elements. String slices quote elements containing commas as CSV does.
+func splitList(text string) []string {
+	inner := strings.TrimSuffix(strings.TrimPrefix(text, "["), "]")
+	if inner == "" {
+		return []string{}
+	}
+	record, err := csv.NewReader(strings.NewReader(inner)).Read()
+	if err != nil {
+		return strings.Split(inner, ",")
+	}
+	return record
+}
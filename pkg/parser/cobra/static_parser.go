@@ -0,0 +1,968 @@
+package cobra
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	cobraImportPath = "github.com/spf13/cobra"
+	pflagImportPath = "github.com/spf13/pflag"
+
+	// maxResolveDepth bounds how far expressions are chased through variables and calls
+	maxResolveDepth = 32
+)
+
+// StaticParser extracts Cobra command trees from Go source without executing it.
+// It rebuilds an inert copy of every command it finds from the AST and hands
+// the copy to CobraParser, so both parsers describe a CLI identically.
+type StaticParser struct {
+	live *CobraParser
+}
+
+// NewStaticParser creates a new static Cobra parser
+func NewStaticParser() *StaticParser {
+	return &StaticParser{live: NewCobraParser()}
+}
+
+// Name returns the parser name
+func (p *StaticParser) Name() string {
+	return "cobra-static"
+}
+
+// Supports checks if the source is a Go package reference
+func (p *StaticParser) Supports(source interface{}) bool {
+	switch source.(type) {
+	case *parser.PackageSource, parser.PackageSource:
+		return true
+	}
+	return false
+}
+
+// Parse loads the packages and extracts the command tree
+func (p *StaticParser) Parse(source interface{}) (*parser.ParsedCLI, error) {
+	var src parser.PackageSource
+	switch s := source.(type) {
+	case *parser.PackageSource:
+		if s == nil {
+			return nil, parser.ErrInvalidSource
+		}
+		src = *s
+	case parser.PackageSource:
+		src = s
+	default:
+		return nil, &parser.ParserError{
+			Message: "source is not a parser.PackageSource",
+			Cause:   parser.ErrInvalidSource,
+		}
+	}
+
+	patterns := src.Patterns
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	a := &staticAnalysis{
+		fset:     token.NewFileSet(),
+		commands: make(map[*ast.CompositeLit]*staticCommand),
+		values:   make(map[types.Object]exprRef),
+		funcs:    make(map[*types.Func]funcRef),
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:  src.Dir,
+		Fset: a.fset,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, &parser.ParserError{Message: "failed to load packages", Cause: err}
+	}
+	if len(pkgs) == 0 {
+		return nil, &parser.ParserError{Message: "no packages matched " + strings.Join(patterns, " ")}
+	}
+	for _, pkg := range pkgs {
+		for _, pkgErr := range pkg.Errors {
+			a.warnings = append(a.warnings, pkgErr.Error())
+		}
+	}
+
+	a.collect(pkgs)
+	a.apply(pkgs)
+
+	root, err := a.selectRoot(pkgs, src.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := p.live.Parse(root.cmd)
+	if err != nil {
+		return nil, err
+	}
+	parsed.FrameworkData["analysis"] = "static"
+	parsed.Warnings = append(parsed.Warnings, a.warnings...)
+
+	return parsed, nil
+}
+
+// staticCommand is a command literal found in the source and its inert copy
+type staticCommand struct {
+	lit    *ast.CompositeLit
+	pkg    *packages.Package
+	cmd    *cobra.Command
+	parent *staticCommand
+	size   int
+}
+
+// exprRef is an expression together with the package whose type info describes it
+type exprRef struct {
+	expr ast.Expr
+	pkg  *packages.Package
+}
+
+// funcRef is a function declaration together with its package
+type funcRef struct {
+	decl *ast.FuncDecl
+	pkg  *packages.Package
+}
+
+// staticAnalysis holds the state of one static parse
+type staticAnalysis struct {
+	fset     *token.FileSet
+	commands map[*ast.CompositeLit]*staticCommand
+	order    []*staticCommand
+	values   map[types.Object]exprRef
+	funcs    map[*types.Func]funcRef
+	warnings []string
+}
+
+// collect records command literals, variable values and function declarations
+func (a *staticAnalysis) collect(pkgs []*packages.Package) {
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch node := n.(type) {
+				case *ast.CompositeLit:
+					if isNamed(pkg.TypesInfo.TypeOf(node), cobraImportPath, "Command") {
+						sc := &staticCommand{lit: node, pkg: pkg, cmd: &cobra.Command{}}
+						a.commands[node] = sc
+						a.order = append(a.order, sc)
+					}
+				case *ast.FuncDecl:
+					if fn, ok := pkg.TypesInfo.Defs[node.Name].(*types.Func); ok {
+						a.funcs[fn] = funcRef{decl: node, pkg: pkg}
+					}
+				case *ast.ValueSpec:
+					for i, name := range node.Names {
+						if i < len(node.Values) && len(node.Names) == len(node.Values) {
+							if obj := pkg.TypesInfo.Defs[name]; obj != nil {
+								a.values[obj] = exprRef{node.Values[i], pkg}
+							}
+						}
+					}
+				case *ast.AssignStmt:
+					if len(node.Lhs) != len(node.Rhs) {
+						return true
+					}
+					for i, lhs := range node.Lhs {
+						if obj := a.assignedObject(lhs, pkg); obj != nil {
+							a.values[obj] = exprRef{node.Rhs[i], pkg}
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+}
+
+// assignedObject returns the variable or non-Cobra field written by an assignment
+func (a *staticAnalysis) assignedObject(lhs ast.Expr, pkg *packages.Package) types.Object {
+	switch e := lhs.(type) {
+	case *ast.Ident:
+		if obj := pkg.TypesInfo.Defs[e]; obj != nil {
+			return obj
+		}
+		return pkg.TypesInfo.Uses[e]
+	case *ast.SelectorExpr:
+		if isCommandPointer(pkg.TypesInfo.TypeOf(e.X)) {
+			return nil
+		}
+		return pkg.TypesInfo.Uses[e.Sel]
+	}
+	return nil
+}
+
+// apply replays literal fields, field assignments, flag definitions and
+// command wiring onto the inert command copies
+func (a *staticAnalysis) apply(pkgs []*packages.Package) {
+	for _, sc := range a.order {
+		for _, elt := range sc.lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				a.setField(sc.cmd, key.Name, exprRef{kv.Value, sc.pkg})
+			}
+		}
+	}
+
+	var flagCalls, markCalls, addCalls []exprRef
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch node := n.(type) {
+				case *ast.AssignStmt:
+					a.applyFieldAssignment(node, pkg)
+				case *ast.CallExpr:
+					sel, ok := node.Fun.(*ast.SelectorExpr)
+					if !ok {
+						return true
+					}
+					recv := pkg.TypesInfo.TypeOf(sel.X)
+					switch {
+					case isCommandPointer(recv) && sel.Sel.Name == "AddCommand":
+						addCalls = append(addCalls, exprRef{node, pkg})
+					case isCommandPointer(recv) && strings.HasPrefix(sel.Sel.Name, "Mark"):
+						markCalls = append(markCalls, exprRef{node, pkg})
+					case isNamedPointer(recv, pflagImportPath, "FlagSet"):
+						if strings.HasPrefix(sel.Sel.Name, "Mark") || sel.Sel.Name == "SetAnnotation" {
+							markCalls = append(markCalls, exprRef{node, pkg})
+						} else {
+							flagCalls = append(flagCalls, exprRef{node, pkg})
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	for _, call := range flagCalls {
+		a.defineFlag(call)
+	}
+	for _, call := range addCalls {
+		a.addCommands(call)
+	}
+	for _, call := range markCalls {
+		a.mark(call)
+	}
+}
+
+// setField applies a cobra.Command field value to the inert copy
+func (a *staticAnalysis) setField(cmd *cobra.Command, field string, value exprRef) {
+	switch field {
+	case "Use", "Short", "Long", "Example", "Version", "Deprecated":
+		s, ok := a.stringValue(value, 0)
+		if !ok {
+			a.warnf(value.expr.Pos(), "cannot resolve value of %s", field)
+			return
+		}
+		reflect.ValueOf(cmd).Elem().FieldByName(field).SetString(s)
+	case "Aliases", "ValidArgs", "SuggestFor":
+		v, ok := a.convert(value, reflect.TypeOf([]string(nil)), 0)
+		if !ok {
+			a.warnf(value.expr.Pos(), "cannot resolve value of %s", field)
+			return
+		}
+		reflect.ValueOf(cmd).Elem().FieldByName(field).Set(v)
+	case "Hidden":
+		v, ok := a.convert(value, reflect.TypeOf(false), 0)
+		if !ok {
+			a.warnf(value.expr.Pos(), "cannot resolve value of %s", field)
+			return
+		}
+		cmd.Hidden = v.Bool()
+	case "Annotations":
+		v, ok := a.convert(value, reflect.TypeOf(map[string]string(nil)), 0)
+		if !ok {
+			a.warnf(value.expr.Pos(), "cannot resolve value of %s", field)
+			return
+		}
+		cmd.Annotations = v.Interface().(map[string]string)
+	case "Args":
+		cmd.Args = a.argsValidator(value, 0)
+	case "Run":
+		cmd.Run = func(*cobra.Command, []string) {}
+	case "RunE":
+		cmd.RunE = func(*cobra.Command, []string) error { return nil }
+	}
+}
+
+// applyFieldAssignment handles statements such as cmd.Short = "..."
+func (a *staticAnalysis) applyFieldAssignment(stmt *ast.AssignStmt, pkg *packages.Package) {
+	if len(stmt.Lhs) != len(stmt.Rhs) {
+		return
+	}
+	for i, lhs := range stmt.Lhs {
+		sel, ok := lhs.(*ast.SelectorExpr)
+		if !ok || !isCommandPointer(pkg.TypesInfo.TypeOf(sel.X)) {
+			continue
+		}
+		sc := a.resolveCommand(exprRef{sel.X, pkg}, 0)
+		if sc == nil {
+			a.warnf(sel.Pos(), "cannot resolve command whose %s is assigned", sel.Sel.Name)
+			continue
+		}
+		a.setField(sc.cmd, sel.Sel.Name, exprRef{stmt.Rhs[i], pkg})
+	}
+}
+
+// argsValidator rebuilds a positional argument validator built from Cobra helpers
+func (a *staticAnalysis) argsValidator(ref exprRef, depth int) cobra.PositionalArgs {
+	if depth > maxResolveDepth {
+		return cobra.ArbitraryArgs
+	}
+	info := ref.pkg.TypesInfo
+
+	switch e := ref.expr.(type) {
+	case *ast.ParenExpr:
+		return a.argsValidator(exprRef{e.X, ref.pkg}, depth+1)
+	case *ast.Ident, *ast.SelectorExpr:
+		if name, ok := cobraObject(info, e); ok {
+			switch name {
+			case "NoArgs":
+				return cobra.NoArgs
+			case "ArbitraryArgs":
+				return cobra.ArbitraryArgs
+			case "OnlyValidArgs":
+				return cobra.OnlyValidArgs
+			}
+		}
+		if value, ok := a.valueOf(ref); ok {
+			return a.argsValidator(value, depth+1)
+		}
+	case *ast.CallExpr:
+		name, ok := cobraObject(info, e.Fun)
+		if !ok {
+			break
+		}
+		ints := make([]int, 0, len(e.Args))
+		for _, arg := range e.Args {
+			if v, ok := a.convert(exprRef{arg, ref.pkg}, reflect.TypeOf(0), depth+1); ok {
+				ints = append(ints, int(v.Int()))
+			}
+		}
+		switch {
+		case name == "ExactArgs" && len(ints) == 1:
+			return cobra.ExactArgs(ints[0])
+		case name == "ExactValidArgs" && len(ints) == 1:
+			return cobra.MatchAll(cobra.ExactArgs(ints[0]), cobra.OnlyValidArgs)
+		case name == "MinimumNArgs" && len(ints) == 1:
+			return cobra.MinimumNArgs(ints[0])
+		case name == "MaximumNArgs" && len(ints) == 1:
+			return cobra.MaximumNArgs(ints[0])
+		case name == "RangeArgs" && len(ints) == 2:
+			return cobra.RangeArgs(ints[0], ints[1])
+		case name == "MatchAll":
+			validators := make([]cobra.PositionalArgs, 0, len(e.Args))
+			for _, arg := range e.Args {
+				validators = append(validators, a.argsValidator(exprRef{arg, ref.pkg}, depth+1))
+			}
+			return cobra.MatchAll(validators...)
+		}
+	}
+
+	a.warnf(ref.expr.Pos(), "cannot resolve Args validator; treating it as arbitrary")
+	return cobra.ArbitraryArgs
+}
+
+// resolveCommand finds the command literal an expression evaluates to
+func (a *staticAnalysis) resolveCommand(ref exprRef, depth int) *staticCommand {
+	if depth > maxResolveDepth || ref.expr == nil {
+		return nil
+	}
+
+	switch e := ref.expr.(type) {
+	case *ast.ParenExpr:
+		return a.resolveCommand(exprRef{e.X, ref.pkg}, depth+1)
+	case *ast.UnaryExpr:
+		if lit, ok := e.X.(*ast.CompositeLit); ok && e.Op == token.AND {
+			return a.commands[lit]
+		}
+	case *ast.Ident, *ast.SelectorExpr:
+		if value, ok := a.valueOf(ref); ok {
+			return a.resolveCommand(value, depth+1)
+		}
+	case *ast.CallExpr:
+		fn := typeutil.StaticCallee(ref.pkg.TypesInfo, e)
+		if decl, ok := a.funcs[fn]; ok {
+			return a.returnedCommand(decl, depth+1)
+		}
+	}
+	return nil
+}
+
+// returnedCommand finds the command literal a function returns
+func (a *staticAnalysis) returnedCommand(fn funcRef, depth int) *staticCommand {
+	if fn.decl.Body == nil {
+		return nil
+	}
+	var found *staticCommand
+	ast.Inspect(fn.decl.Body, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) > 0 {
+				found = a.resolveCommand(exprRef{node.Results[0], fn.pkg}, depth)
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// resolveFlagSet finds the command and flag set kind a *pflag.FlagSet expression refers to
+func (a *staticAnalysis) resolveFlagSet(ref exprRef, depth int) (*staticCommand, bool, bool) {
+	if depth > maxResolveDepth {
+		return nil, false, false
+	}
+
+	switch e := ref.expr.(type) {
+	case *ast.ParenExpr:
+		return a.resolveFlagSet(exprRef{e.X, ref.pkg}, depth+1)
+	case *ast.Ident, *ast.SelectorExpr:
+		if value, ok := a.valueOf(ref); ok {
+			return a.resolveFlagSet(value, depth+1)
+		}
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok || !isCommandPointer(ref.pkg.TypesInfo.TypeOf(sel.X)) {
+			return nil, false, false
+		}
+		var persistent bool
+		switch sel.Sel.Name {
+		case "Flags", "LocalFlags", "LocalNonPersistentFlags":
+		case "PersistentFlags":
+			persistent = true
+		default:
+			return nil, false, false
+		}
+		sc := a.resolveCommand(exprRef{sel.X, ref.pkg}, depth+1)
+		return sc, persistent, sc != nil
+	}
+	return nil, false, false
+}
+
+// valueOf returns the expression last assigned to the variable an identifier names
+func (a *staticAnalysis) valueOf(ref exprRef) (exprRef, bool) {
+	var ident *ast.Ident
+	switch e := ref.expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return exprRef{}, false
+	}
+	obj := ref.pkg.TypesInfo.Uses[ident]
+	if obj == nil {
+		obj = ref.pkg.TypesInfo.Defs[ident]
+	}
+	if obj == nil {
+		return exprRef{}, false
+	}
+	value, ok := a.values[obj]
+	return value, ok
+}
+
+// defineFlag replays a flag definition such as Flags().StringVarP(&v, "name", "n", "", "usage")
+func (a *staticAnalysis) defineFlag(ref exprRef) {
+	call := ref.expr.(*ast.CallExpr)
+	sel := call.Fun.(*ast.SelectorExpr)
+
+	methodName, isVar, hasP, ok := flagMethod(sel.Sel.Name, len(call.Args))
+	generic := sel.Sel.Name == "Var" || sel.Sel.Name == "VarP"
+	if !ok && !generic {
+		return
+	}
+
+	sc, persistent, ok := a.resolveFlagSet(exprRef{sel.X, ref.pkg}, 0)
+	if !ok {
+		a.warnf(call.Pos(), "cannot resolve the command owning flag set in %s call", sel.Sel.Name)
+		return
+	}
+	flags := sc.cmd.Flags()
+	if persistent {
+		flags = sc.cmd.PersistentFlags()
+	}
+	method := reflect.ValueOf(flags).MethodByName(methodName)
+
+	// Normalize arguments to the P variant: name, shorthand, values..., usage
+	args := call.Args
+	var valueArg ast.Expr
+	if isVar || generic {
+		valueArg = args[0]
+		args = args[1:]
+	}
+	if !hasP && sel.Sel.Name != "VarP" {
+		args = append([]ast.Expr{args[0], nil}, args[1:]...)
+	}
+	if len(args) < 3 || (!generic && len(args) != method.Type().NumIn()) {
+		a.warnf(call.Pos(), "unexpected arguments in %s call", sel.Sel.Name)
+		return
+	}
+
+	name, ok := a.stringValue(exprRef{args[0], ref.pkg}, 0)
+	if !ok {
+		a.warnf(call.Pos(), "cannot resolve flag name in %s call", sel.Sel.Name)
+		return
+	}
+	shorthand := ""
+	if args[1] != nil {
+		if shorthand, ok = a.stringValue(exprRef{args[1], ref.pkg}, 0); !ok {
+			a.warnf(call.Pos(), "cannot resolve shorthand of flag %q", name)
+		}
+	}
+	if flags.Lookup(name) != nil {
+		a.warnf(call.Pos(), "flag %q is defined more than once", name)
+		return
+	}
+	if shorthand != "" && flags.ShorthandLookup(shorthand) != nil {
+		a.warnf(call.Pos(), "shorthand %q of flag %q is already in use", shorthand, name)
+		shorthand = ""
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			a.warnf(call.Pos(), "cannot define flag %q: %v", name, r)
+		}
+	}()
+
+	if generic {
+		usage, _ := a.stringValue(exprRef{args[len(args)-1], ref.pkg}, 0)
+		flags.VarP(&staticValue{typ: a.valueType(exprRef{valueArg, ref.pkg})}, name, shorthand, usage)
+		return
+	}
+
+	in := []reflect.Value{reflect.ValueOf(name), reflect.ValueOf(shorthand)}
+	for i := 2; i < method.Type().NumIn(); i++ {
+		paramType := method.Type().In(i)
+		arg := exprRef{args[i], ref.pkg}
+		if paramType.Kind() == reflect.Func {
+			in = append(in, reflect.MakeFunc(paramType, func([]reflect.Value) []reflect.Value {
+				return []reflect.Value{reflect.Zero(paramType.Out(0))}
+			}))
+			continue
+		}
+		v, ok := a.convert(arg, paramType, 0)
+		if !ok {
+			if i == method.Type().NumIn()-1 {
+				a.warnf(arg.expr.Pos(), "cannot resolve usage of flag %q", name)
+			} else {
+				a.warnf(arg.expr.Pos(), "cannot resolve default value of flag %q", name)
+			}
+			v = reflect.Zero(paramType)
+		}
+		in = append(in, v)
+	}
+	method.Call(in)
+}
+
+// addCommands replays parent.AddCommand(children...)
+func (a *staticAnalysis) addCommands(ref exprRef) {
+	call := ref.expr.(*ast.CallExpr)
+	sel := call.Fun.(*ast.SelectorExpr)
+
+	parent := a.resolveCommand(exprRef{sel.X, ref.pkg}, 0)
+	if parent == nil {
+		a.warnf(call.Pos(), "cannot resolve the command receiving AddCommand")
+		return
+	}
+	for _, arg := range call.Args {
+		child := a.resolveCommand(exprRef{arg, ref.pkg}, 0)
+		if child == nil {
+			a.warnf(arg.Pos(), "cannot resolve command passed to AddCommand")
+			continue
+		}
+		if child == parent || isAncestor(child, parent) {
+			a.warnf(arg.Pos(), "ignoring AddCommand that would create a cycle")
+			continue
+		}
+		parent.cmd.AddCommand(child.cmd)
+		child.parent = parent
+	}
+}
+
+// mark replays MarkFlagRequired, MarkHidden, SetAnnotation and similar calls
+func (a *staticAnalysis) mark(ref exprRef) {
+	call := ref.expr.(*ast.CallExpr)
+	sel := call.Fun.(*ast.SelectorExpr)
+
+	var target reflect.Value
+	if isCommandPointer(ref.pkg.TypesInfo.TypeOf(sel.X)) {
+		sc := a.resolveCommand(exprRef{sel.X, ref.pkg}, 0)
+		if sc == nil {
+			a.warnf(call.Pos(), "cannot resolve the command in %s call", sel.Sel.Name)
+			return
+		}
+		target = reflect.ValueOf(sc.cmd)
+	} else {
+		sc, persistent, ok := a.resolveFlagSet(exprRef{sel.X, ref.pkg}, 0)
+		if !ok {
+			return
+		}
+		flags := sc.cmd.Flags()
+		if persistent {
+			flags = sc.cmd.PersistentFlags()
+		}
+		target = reflect.ValueOf(flags)
+	}
+
+	method := target.MethodByName(sel.Sel.Name)
+	if !method.IsValid() {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			a.warnf(call.Pos(), "%s: %v", sel.Sel.Name, r)
+		}
+	}()
+	methodType := method.Type()
+
+	in := make([]reflect.Value, 0, len(call.Args))
+	for i, arg := range call.Args {
+		var paramType reflect.Type
+		switch {
+		case methodType.IsVariadic() && i >= methodType.NumIn()-1:
+			paramType = methodType.In(methodType.NumIn() - 1).Elem()
+		case i < methodType.NumIn():
+			paramType = methodType.In(i)
+		default:
+			return
+		}
+		v, ok := a.convert(exprRef{arg, ref.pkg}, paramType, 0)
+		if !ok {
+			a.warnf(arg.Pos(), "cannot resolve argument of %s", sel.Sel.Name)
+			return
+		}
+		in = append(in, v)
+	}
+	if len(in) < methodType.NumIn() && !(methodType.IsVariadic() && len(in) == methodType.NumIn()-1) {
+		return
+	}
+
+	out := method.Call(in)
+	if len(out) == 1 {
+		if err, ok := out[0].Interface().(error); ok && err != nil {
+			a.warnf(call.Pos(), "%s: %v", sel.Sel.Name, err)
+		}
+	}
+}
+
+// selectRoot picks the root command of the analysed CLI
+func (a *staticAnalysis) selectRoot(pkgs []*packages.Package, name string) (*staticCommand, error) {
+	if name != "" {
+		for _, pkg := range pkgs {
+			if pkg.Types == nil {
+				continue
+			}
+			obj := pkg.Types.Scope().Lookup(name)
+			if obj == nil {
+				continue
+			}
+			var root *staticCommand
+			if fn, ok := obj.(*types.Func); ok {
+				if decl, ok := a.funcs[fn]; ok {
+					root = a.returnedCommand(decl, 0)
+				}
+			} else if value, ok := a.values[obj]; ok {
+				root = a.resolveCommand(value, 0)
+			}
+			if root != nil {
+				return root, nil
+			}
+		}
+		return nil, &parser.ParserError{Message: fmt.Sprintf("root command %q not found", name)}
+	}
+
+	for _, sc := range a.order {
+		for p := sc.parent; p != nil; p = p.parent {
+			p.size++
+		}
+	}
+
+	var root *staticCommand
+	for _, sc := range a.order {
+		if sc.parent == nil && (root == nil || sc.size > root.size) {
+			root = sc
+		}
+	}
+	if root == nil {
+		return nil, &parser.ParserError{Message: "no cobra.Command literals found"}
+	}
+
+	for _, sc := range a.order {
+		if sc.parent == nil && sc != root {
+			a.warnf(sc.lit.Pos(), "command %q is not attached to root command %q", sc.cmd.Name(), root.cmd.Name())
+		}
+	}
+	return root, nil
+}
+
+// stringValue evaluates a string expression
+func (a *staticAnalysis) stringValue(ref exprRef, depth int) (string, bool) {
+	v, ok := a.convert(ref, reflect.TypeOf(""), depth)
+	if !ok {
+		return "", false
+	}
+	return v.String(), true
+}
+
+// convert evaluates an expression into a value of the given Go type. Constants,
+// composite literals of constants and variables with known values are supported.
+func (a *staticAnalysis) convert(ref exprRef, t reflect.Type, depth int) (reflect.Value, bool) {
+	if depth > maxResolveDepth || ref.expr == nil {
+		return reflect.Value{}, false
+	}
+	info := ref.pkg.TypesInfo
+
+	if tv, ok := info.Types[ref.expr]; ok {
+		if tv.IsNil() {
+			return reflect.Zero(t), true
+		}
+		if tv.Value != nil {
+			return constantValue(tv.Value, t)
+		}
+	}
+
+	switch e := ref.expr.(type) {
+	case *ast.ParenExpr:
+		return a.convert(exprRef{e.X, ref.pkg}, t, depth+1)
+	case *ast.Ident, *ast.SelectorExpr:
+		if value, ok := a.valueOf(ref); ok {
+			return a.convert(value, t, depth+1)
+		}
+	case *ast.CompositeLit:
+		switch t.Kind() {
+		case reflect.Slice:
+			slice := reflect.MakeSlice(t, 0, len(e.Elts))
+			for _, elt := range e.Elts {
+				v, ok := a.convert(exprRef{elt, ref.pkg}, t.Elem(), depth+1)
+				if !ok {
+					return reflect.Value{}, false
+				}
+				slice = reflect.Append(slice, v)
+			}
+			return slice, true
+		case reflect.Map:
+			m := reflect.MakeMapWithSize(t, len(e.Elts))
+			for _, elt := range e.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					return reflect.Value{}, false
+				}
+				k, ok := a.convert(exprRef{kv.Key, ref.pkg}, t.Key(), depth+1)
+				if !ok {
+					return reflect.Value{}, false
+				}
+				v, ok := a.convert(exprRef{kv.Value, ref.pkg}, t.Elem(), depth+1)
+				if !ok {
+					return reflect.Value{}, false
+				}
+				m.SetMapIndex(k, v)
+			}
+			return m, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// valueType determines the Type() of a custom pflag.Value from its declaration
+func (a *staticAnalysis) valueType(ref exprRef) string {
+	t := ref.pkg.TypesInfo.TypeOf(ref.expr)
+	if t == nil {
+		return "string"
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, true, ref.pkg.Types, "Type")
+	if fn, ok := obj.(*types.Func); ok {
+		if decl, ok := a.funcs[fn]; ok && decl.decl.Body != nil {
+			for _, stmt := range decl.decl.Body.List {
+				if ret, ok := stmt.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+					if s, ok := a.stringValue(exprRef{ret.Results[0], decl.pkg}, 0); ok {
+						return s
+					}
+				}
+			}
+		}
+	}
+
+	for {
+		ptr, ok := t.(*types.Pointer)
+		if !ok {
+			break
+		}
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return lowerFirst(named.Obj().Name())
+	}
+	return "string"
+}
+
+func (a *staticAnalysis) warnf(pos token.Pos, format string, args ...interface{}) {
+	a.warnings = append(a.warnings, fmt.Sprintf("%s: %s", a.fset.Position(pos), fmt.Sprintf(format, args...)))
+}
+
+// staticValue stands in for custom pflag.Value implementations
+type staticValue struct {
+	typ   string
+	value string
+}
+
+func (v *staticValue) String() string     { return v.value }
+func (v *staticValue) Set(s string) error { v.value = s; return nil }
+func (v *staticValue) Type() string       { return v.typ }
+
+// flagMethod maps a pflag.FlagSet definition method to the name of its P variant.
+// It reports whether the call passes a destination pointer and a shorthand.
+func flagMethod(name string, argc int) (string, bool, bool, bool) {
+	candidates := []struct {
+		base        string
+		isVar, hasP bool
+	}{
+		{strings.TrimSuffix(name, "VarP"), true, true},
+		{strings.TrimSuffix(name, "Var"), true, false},
+		{strings.TrimSuffix(name, "P"), false, true},
+		{name, false, false},
+	}
+
+	flags := reflect.ValueOf(pflag.NewFlagSet("", pflag.ContinueOnError))
+	for i, c := range candidates {
+		if c.base == "" || (i < 3 && c.base == name) {
+			continue
+		}
+		method := flags.MethodByName(c.base + "P")
+		if !method.IsValid() {
+			continue
+		}
+		t := method.Type()
+		if t.NumIn() < 3 || t.In(0).Kind() != reflect.String || t.In(1).Kind() != reflect.String {
+			continue
+		}
+		expected := t.NumIn()
+		if !c.hasP {
+			expected--
+		}
+		if c.isVar {
+			expected++
+		}
+		if expected == argc {
+			return c.base + "P", c.isVar, c.hasP, true
+		}
+	}
+	return "", false, false, false
+}
+
+// constantValue converts a typed constant to a value of type t
+func constantValue(value constant.Value, t reflect.Type) (reflect.Value, bool) {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		if value.Kind() != constant.String {
+			return reflect.Value{}, false
+		}
+		v.SetString(constant.StringVal(value))
+	case reflect.Bool:
+		if value.Kind() != constant.Bool {
+			return reflect.Value{}, false
+		}
+		v.SetBool(constant.BoolVal(value))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := constant.Int64Val(constant.ToInt(value))
+		if !ok {
+			return reflect.Value{}, false
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, ok := constant.Uint64Val(constant.ToInt(value))
+		if !ok {
+			return reflect.Value{}, false
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, _ := constant.Float64Val(constant.ToFloat(value))
+		v.SetFloat(f)
+	default:
+		return reflect.Value{}, false
+	}
+	return v, true
+}
+
+// cobraObject returns the name of a package-level Cobra object an expression refers to
+func cobraObject(info *types.Info, expr ast.Expr) (string, bool) {
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return "", false
+	}
+	obj := info.Uses[ident]
+	if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != cobraImportPath {
+		return "", false
+	}
+	return obj.Name(), true
+}
+
+func isCommandPointer(t types.Type) bool {
+	return isNamedPointer(t, cobraImportPath, "Command")
+}
+
+func isNamedPointer(t types.Type, pkgPath, name string) bool {
+	ptr, ok := t.(*types.Pointer)
+	return ok && isNamed(ptr.Elem(), pkgPath, name)
+}
+
+func isNamed(t types.Type, pkgPath, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+func isAncestor(candidate, sc *staticCommand) bool {
+	for p := sc.parent; p != nil; p = p.parent {
+		if p == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
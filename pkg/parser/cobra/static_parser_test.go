@@ -0,0 +1,146 @@
+package cobra
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+// levelValue mirrors the custom flag value declared in testdata/static/app
+type levelValue struct{ value string }
+
+func (l *levelValue) String() string     { return l.value }
+func (l *levelValue) Set(s string) error { l.value = s; return nil }
+func (l *levelValue) Type() string       { return "level" }
+
+// liveTestApp builds the same CLI as testdata/static/app at runtime
+func liveTestApp() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:     "testapp",
+		Short:   "A test application",
+		Long:    "This is a test application for unit testing",
+		Version: "1.0.0",
+	}
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().StringP("config", "c", "", "Config file path")
+
+	serveCmd := &cobra.Command{
+		Use:     "serve [addr]",
+		Short:   "Serve requests",
+		Aliases: []string{"s", "run"},
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+	flags := serveCmd.Flags()
+	flags.IntP("port", "p", 8080, "Port to listen on")
+	flags.StringSlice("tag", []string{"a", "b"}, "Tags")
+	flags.Duration("timeout", 5*time.Second, "Request timeout")
+	flags.Var(&levelValue{}, "level", "Log level")
+	flags.String("name", "", "Server name")
+	serveCmd.MarkFlagRequired("port")
+	flags.MarkHidden("name")
+
+	hiddenCmd := &cobra.Command{
+		Use:    "internal",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		Run:    func(cmd *cobra.Command, args []string) {},
+	}
+
+	rootCmd.AddCommand(serveCmd, hiddenCmd)
+	return rootCmd
+}
+
+// summarize flattens a ParsedCLI into comparable strings
+func summarize(parsed *parser.ParsedCLI) []string {
+	var lines []string
+	for path, cmd := range parsed.Commands {
+		lines = append(lines, fmt.Sprintf("%s use=%q short=%q long=%q aliases=%v version=%q hidden=%v run=%v",
+			path, cmd.Use, cmd.Short, cmd.Long, cmd.Aliases, cmd.Version, cmd.Hidden, cmd.RunFunc))
+		for _, f := range append(cmd.Flags, cmd.PersistentFlags...) {
+			lines = append(lines, fmt.Sprintf("%s --%s -%s type=%s default=%v required=%v hidden=%v persistent=%v usage=%q",
+				path, f.Name, f.Shorthand, f.Type, f.DefaultValue, f.Required, f.Hidden, f.Persistent, f.Usage))
+		}
+		for _, arg := range cmd.Args {
+			lines = append(lines, fmt.Sprintf("%s arg %s min=%d max=%d", path, arg.Name, arg.MinArgs, arg.MaxArgs))
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func TestStaticParser_Supports(t *testing.T) {
+	p := NewStaticParser()
+	if !p.Supports(&parser.PackageSource{Dir: "."}) {
+		t.Error("Expected *parser.PackageSource to be supported")
+	}
+	if p.Supports(&cobra.Command{}) {
+		t.Error("Expected *cobra.Command to be unsupported")
+	}
+}
+
+func TestStaticParser_ParityWithCobraParser(t *testing.T) {
+	static, err := NewStaticParser().Parse(&parser.PackageSource{
+		Dir:      "testdata/static/app",
+		Patterns: []string{"."},
+	})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	live, err := NewCobraParser().Parse(liveTestApp())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got, want := summarize(static), summarize(live)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Static parse differs from live parse:\ngot:\n  %s\nwant:\n  %s",
+			strings.Join(got, "\n  "), strings.Join(want, "\n  "))
+	}
+
+	if static.FrameworkData["analysis"] != "static" {
+		t.Errorf("Expected analysis=static in framework data, got %v", static.FrameworkData["analysis"])
+	}
+
+	found := false
+	for _, w := range static.Warnings {
+		if strings.Contains(w, `default value of flag "name"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected a warning for the unresolvable default, got %v", static.Warnings)
+	}
+}
+
+func TestStaticParser_Root(t *testing.T) {
+	parsed, err := NewStaticParser().Parse(&parser.PackageSource{
+		Dir:      "testdata/static/app",
+		Patterns: []string{"."},
+		Root:     "GetRootCmd",
+	})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.RootCommand.Name != "testapp" {
+		t.Errorf("Expected root 'testapp', got '%s'", parsed.RootCommand.Name)
+	}
+
+	_, err = NewStaticParser().Parse(&parser.PackageSource{
+		Dir:      "testdata/static/app",
+		Patterns: []string{"."},
+		Root:     "missing",
+	})
+	if err == nil {
+		t.Error("Expected error for unknown root")
+	}
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const defaultPort = 8080
+
+var (
+	verbose bool
+	config  string
+	port    int
+	tags    []string
+	timeout time.Duration
+	level   = &levelValue{value: "info"}
+)
+
+type levelValue struct{ value string }
+
+func (l *levelValue) String() string     { return l.value }
+func (l *levelValue) Set(s string) error { l.value = s; return nil }
+func (l *levelValue) Type() string       { return "level" }
+
+var rootCmd = &cobra.Command{
+	Use:     "testapp",
+	Short:   "A test application",
+	Long:    "This is a test application " + "for unit testing",
+	Version: "1.0.0",
+}
+
+// GetRootCmd returns the root command
+func GetRootCmd() *cobra.Command {
+	return rootCmd
+}
+
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "serve [addr]",
+		Aliases: []string{"s", "run"},
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+	cmd.Short = "Serve requests"
+
+	flags := cmd.Flags()
+	flags.IntVarP(&port, "port", "p", defaultPort, "Port to listen on")
+	flags.StringSliceVar(&tags, "tag", []string{"a", "b"}, "Tags")
+	flags.DurationVar(&timeout, "timeout", 5*time.Second, "Request timeout")
+	flags.Var(level, "level", "Log level")
+	flags.String("name", fmt.Sprint("dynamic"), "Server name")
+	cmd.MarkFlagRequired("port")
+	flags.MarkHidden("name")
+	return cmd
+}
+
+var hiddenCmd = &cobra.Command{
+	Use:    "internal",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	Run:    func(cmd *cobra.Command, args []string) {},
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().StringVarP(&config, "config", "c", "", "Config file path")
+	rootCmd.AddCommand(newServeCmd(), hiddenCmd)
+}
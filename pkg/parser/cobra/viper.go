@@ -0,0 +1,226 @@
+package cobra
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// ViperSource is a Cobra command tree whose flags are bound to a Viper
+// instance. Parsing it also records the environment variables and the
+// configuration keys that set each flag:
+//
+//	v := viper.New()
+//	v.SetEnvPrefix("app")
+//	v.AutomaticEnv()
+//	v.BindPFlags(rootCmd.PersistentFlags())
+//
+//	s, err := gospec.New().Convert(&cobra.ViperSource{Command: rootCmd, Viper: v}, nil)
+type ViperSource struct {
+	Command *cobra.Command
+	// Viper defaults to the global instance returned by viper.GetViper
+	Viper *viper.Viper
+}
+
+// viperBindings is what a Viper instance knows about the origin of its
+// settings
+type viperBindings struct {
+	// keys are all keys Viper knows, sorted
+	keys []string
+	// flags maps bound flags to their keys
+	flags map[*pflag.Flag]string
+	// flagNames maps the names of flags bound through a custom
+	// viper.FlagValue to their keys
+	flagNames map[string]string
+	// env maps keys to the environment variables setting them, in the
+	// order Viper looks them up
+	env map[string][]string
+}
+
+// parseViper records the environment variables and configuration keys of
+// the flags bound to v, and adds every variable Viper reads to the
+// metadata of the CLI
+func (p *CobraParser) parseViper(cmd *cobra.Command, v *viper.Viper, parsed *parser.ParsedCLI) {
+	bindings := readViper(v)
+
+	// keys bound to a flag, which describes the variables setting them
+	described := make(map[string]*parser.FlagInfo)
+
+	var walk func(cmd *cobra.Command, path string)
+	walk = func(cmd *cobra.Command, path string) {
+		if info, ok := parsed.Commands[path]; ok {
+			for _, flags := range [][]*parser.FlagInfo{info.Flags, info.PersistentFlags} {
+				for _, flag := range flags {
+					key, ok := bindings.key(cmd, flag.Name)
+					if !ok {
+						continue
+					}
+					flag.Annotations["configKey"] = key
+					if names := bindings.env[key]; len(names) > 0 {
+						flag.Annotations["envVars"] = strings.Join(names, ",")
+					}
+					if described[key] == nil {
+						described[key] = flag
+					}
+				}
+			}
+		}
+		for _, child := range cmd.Commands() {
+			walk(child, path+"/"+child.Name())
+		}
+	}
+	walk(cmd, cmd.Name())
+
+	seen := make(map[string]bool)
+	for _, key := range bindings.keys {
+		for _, name := range bindings.env[key] {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			env := parser.EnvVarInfo{
+				Name:        name,
+				Description: "Sets the " + key + " configuration key",
+			}
+			if flag := described[key]; flag != nil {
+				env.Description = flag.Usage
+				env.Default = envDefault(flag.DefaultValue)
+			}
+			parsed.Metadata.EnvVars = append(parsed.Metadata.EnvVars, env)
+		}
+	}
+
+	if root := parsed.RootCommand; root != nil && len(bindings.keys) > 0 {
+		root.Extensions[spec.ExtensionConfigKeys] = bindings.keys
+	}
+}
+
+// key returns the Viper key of the flag of cmd with the given name
+func (b viperBindings) key(cmd *cobra.Command, name string) (string, bool) {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		flag = cmd.PersistentFlags().Lookup(name)
+	}
+	if flag != nil {
+		if key, ok := b.flags[flag]; ok {
+			return key, true
+		}
+	}
+	key, ok := b.flagNames[name]
+	return key, ok
+}
+
+// readViper collects the bindings of v. Viper does not export which flags
+// and environment variables are bound to a key, so they are read from its
+// unexported fields; bindings missing from the Viper version in use are
+// left out.
+func readViper(v *viper.Viper) viperBindings {
+	b := viperBindings{
+		keys:      v.AllKeys(),
+		flags:     make(map[*pflag.Flag]string),
+		flagNames: make(map[string]string),
+		env:       make(map[string][]string),
+	}
+	sort.Strings(b.keys)
+
+	if pflags, ok := viperField(v, "pflags").(map[string]viper.FlagValue); ok {
+		for key, value := range pflags {
+			if flag := boundFlag(value); flag != nil {
+				b.flags[flag] = key
+			} else {
+				b.flagNames[value.Name()] = key
+			}
+		}
+	}
+
+	replacer, _ := viperField(v, "envKeyReplacer").(viper.StringReplacer)
+	envName := func(name string) string {
+		if replacer != nil {
+			return replacer.Replace(name)
+		}
+		return name
+	}
+
+	automatic, _ := viperField(v, "automaticEnvApplied").(bool)
+	bound, _ := viperField(v, "env").(map[string][]string)
+	for _, key := range b.keys {
+		names := make([]string, 0)
+		if automatic {
+			name := strings.ToUpper(key)
+			if prefix := v.GetEnvPrefix(); prefix != "" {
+				name = strings.ToUpper(prefix + "_" + key)
+			}
+			names = append(names, envName(name))
+		}
+		for _, name := range bound[key] {
+			names = append(names, envName(name))
+		}
+
+		seen := make(map[string]bool)
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				b.env[key] = append(b.env[key], name)
+			}
+		}
+	}
+	return b
+}
+
+// viperField returns the value of an unexported field of v, or nil if
+// there is no such field
+func viperField(v *viper.Viper, name string) interface{} {
+	field := reflect.ValueOf(v).Elem().FieldByName(name)
+	if !field.IsValid() {
+		return nil
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().Interface()
+}
+
+// boundFlag returns the flag behind a value bound with BindPFlag, or nil
+// for other viper.FlagValue implementations
+func boundFlag(value viper.FlagValue) *pflag.Flag {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	// Copy the value so that its fields are addressable
+	copied := reflect.New(rv.Type()).Elem()
+	copied.Set(rv)
+	field := copied.FieldByName("flag")
+	if !field.IsValid() || field.Type() != reflect.TypeOf((*pflag.Flag)(nil)) {
+		return nil
+	}
+	return *(**pflag.Flag)(unsafe.Pointer(field.UnsafeAddr()))
+}
+
+// envDefault renders a typed flag default as an environment variable value
+// Viper accepts: lists are comma-separated and maps are key=value pairs
+func envDefault(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	case map[string]interface{}:
+		entries := make([]string, 0, len(v))
+		for key, item := range v {
+			entries = append(entries, fmt.Sprintf("%s=%v", key, item))
+		}
+		sort.Strings(entries)
+		return strings.Join(entries, ",")
+	}
+	return fmt.Sprint(value)
+}
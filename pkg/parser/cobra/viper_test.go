@@ -0,0 +1,111 @@
+package cobra
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestCobraParser_ParseViper(t *testing.T) {
+	root := &cobra.Command{Use: "app"}
+	root.PersistentFlags().String("log-level", "info", "Log level")
+	serve := &cobra.Command{Use: "serve", Run: func(*cobra.Command, []string) {}}
+	serve.Flags().Int("port", 8080, "Port to listen on")
+	serve.Flags().Bool("debug", false, "Unbound flag")
+	root.AddCommand(serve)
+
+	v := viper.New()
+	v.SetEnvPrefix("app")
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	v.AutomaticEnv()
+	v.BindPFlag("log-level", root.PersistentFlags().Lookup("log-level"))
+	v.BindPFlag("server.port", serve.Flags().Lookup("port"))
+	v.BindEnv("server.port", "PORT")
+	v.SetDefault("cache.dir", "/tmp")
+
+	p := NewCobraParser()
+	if !p.Supports(&ViperSource{Command: root, Viper: v}) {
+		t.Fatal("Expected ViperSource to be supported")
+	}
+	parsed, err := p.Parse(&ViperSource{Command: root, Viper: v})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	flag := func(path, name string) *parser.FlagInfo {
+		info := parsed.Commands[path]
+		for _, flags := range [][]*parser.FlagInfo{info.Flags, info.PersistentFlags} {
+			for _, f := range flags {
+				if f.Name == name {
+					return f
+				}
+			}
+		}
+		t.Fatalf("%s: flag %q not found", path, name)
+		return nil
+	}
+
+	tests := []struct {
+		flag      *parser.FlagInfo
+		configKey string
+		envVars   string
+	}{
+		{flag("app", "log-level"), "log-level", "APP_LOG_LEVEL"},
+		{flag("app/serve", "port"), "server.port", "APP_SERVER_PORT,PORT"},
+		{flag("app/serve", "debug"), "", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.flag.Annotations["configKey"]; got != tt.configKey {
+			t.Errorf("--%s: expected config key %q, got %q", tt.flag.Name, tt.configKey, got)
+		}
+		if got := tt.flag.Annotations["envVars"]; got != tt.envVars {
+			t.Errorf("--%s: expected env vars %q, got %q", tt.flag.Name, tt.envVars, got)
+		}
+	}
+
+	want := []parser.EnvVarInfo{
+		{Name: "APP_CACHE_DIR", Description: "Sets the cache.dir configuration key"},
+		{Name: "APP_LOG_LEVEL", Description: "Log level", Default: "info"},
+		{Name: "APP_SERVER_PORT", Description: "Port to listen on", Default: "8080"},
+		{Name: "PORT", Description: "Port to listen on", Default: "8080"},
+	}
+	if !reflect.DeepEqual(parsed.Metadata.EnvVars, want) {
+		t.Errorf("Expected env vars %+v, got %+v", want, parsed.Metadata.EnvVars)
+	}
+
+	keys := parsed.RootCommand.Extensions["x-config-keys"]
+	if !reflect.DeepEqual(keys, []string{"cache.dir", "log-level", "server.port"}) {
+		t.Errorf("Expected config keys, got %v", keys)
+	}
+}
+
+func TestCobraParser_ParseViperWithoutAutomaticEnv(t *testing.T) {
+	root := &cobra.Command{Use: "app", Run: func(*cobra.Command, []string) {}}
+	root.Flags().String("token", "", "API token")
+	root.Flags().String("region", "", "Region")
+
+	v := viper.New()
+	v.BindPFlags(root.Flags())
+	v.BindEnv("token", "APP_TOKEN", "TOKEN")
+
+	parsed, err := NewCobraParser().Parse(&ViperSource{Command: root, Viper: v})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for _, f := range parsed.RootCommand.Flags {
+		want := map[string]string{"token": "APP_TOKEN,TOKEN"}[f.Name]
+		if got := f.Annotations["envVars"]; got != want {
+			t.Errorf("--%s: expected env vars %q, got %q", f.Name, want, got)
+		}
+		if got := f.Annotations["configKey"]; got != f.Name {
+			t.Errorf("--%s: expected config key %q, got %q", f.Name, f.Name, got)
+		}
+	}
+	if len(parsed.Metadata.EnvVars) != 2 {
+		t.Errorf("Expected 2 env vars, got %+v", parsed.Metadata.EnvVars)
+	}
+}
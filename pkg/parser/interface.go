@@ -29,6 +29,9 @@ type ParsedCLI struct {
 
 	// Framework-specific data
 	FrameworkData map[string]interface{}
+
+	// Non-fatal problems encountered while parsing
+	Warnings []string
 }
 
 // CommandInfo contains all information about a command
@@ -51,6 +54,7 @@ type CommandInfo struct {
 	Flags           []*FlagInfo
 	Args            []*ArgumentInfo
 	PersistentFlags []*FlagInfo // Flags inherited by subcommands
+	FlagGroups      []*FlagGroupInfo
 
 	// Behavior
 	Hidden     bool
@@ -65,10 +69,17 @@ type CommandInfo struct {
 	Extensions map[string]interface{}
 }
 
+// FlagGroupInfo constrains how flags of a command may be combined
+type FlagGroupInfo struct {
+	Kind  string   // spec.FlagGroupMutuallyExclusive, spec.FlagGroupRequiredTogether or spec.FlagGroupOneRequired
+	Flags []string // Flag names without dashes
+}
+
 // FlagInfo represents a command flag/option
 type FlagInfo struct {
 	Name         string
 	Shorthand    string
+	Aliases      []string // Other names of the flag besides Name and Shorthand
 	Usage        string
 	Type         string // string, bool, int, float, duration, etc.
 	DefaultValue interface{}
@@ -80,6 +91,9 @@ type FlagInfo struct {
 	// Validation
 	ValidValues []string // For enum-like flags
 
+	// Completion reported by the CLI, if any
+	Completion *CompletionInfo
+
 	// Extensions
 	Annotations map[string]string
 }
@@ -98,6 +112,20 @@ type ArgumentInfo struct {
 
 	// Validation
 	ValidValues []string
+
+	// Completion reported by the CLI, if any
+	Completion *CompletionInfo
+}
+
+// CompletionInfo describes how a shell completes a value
+type CompletionInfo struct {
+	Values         []string // Candidates for an empty word, without descriptions
+	FileExtensions []string // File completion restricted to these extensions
+	Directories    bool     // File completion restricted to directories
+	Directory      string   // Directory below which directories are completed
+	NoFiles        bool     // No file completion when there are no candidates
+	NoSpace        bool     // No space after a candidate
+	KeepOrder      bool     // Candidates are not sorted
 }
 
 // CLIMetadata contains global CLI information
@@ -0,0 +1,289 @@
+package kingpin
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+)
+
+// builtinFlags are the flags kingpin.New adds to every application
+var builtinFlags = map[string]bool{
+	"help":                   true,
+	"help-long":              true,
+	"help-man":               true,
+	"completion-bash":        true,
+	"completion-script-bash": true,
+	"completion-script-zsh":  true,
+}
+
+// KingpinParser implements the Parser interface for the Kingpin CLI framework
+type KingpinParser struct{}
+
+// NewKingpinParser creates a new Kingpin parser
+func NewKingpinParser() *KingpinParser {
+	return &KingpinParser{}
+}
+
+// Name returns the parser name
+func (p *KingpinParser) Name() string {
+	return "kingpin"
+}
+
+// Supports checks if the source is a *kingpin.Application of
+// github.com/alecthomas/kingpin/v2 or of gopkg.in/alecthomas/kingpin.v2
+func (p *KingpinParser) Supports(source interface{}) bool {
+	if _, ok := source.(*kingpin.Application); ok {
+		return true
+	}
+	_, ok := legacyApp(source)
+	return ok
+}
+
+// Parse extracts CLI structure from a Kingpin application
+func (p *KingpinParser) Parse(source interface{}) (*parser.ParsedCLI, error) {
+	if app, ok := legacyApp(source); ok {
+		if app.IsNil() {
+			return nil, parser.ErrInvalidSource
+		}
+		return p.parseModel(legacyModel(app), legacyHasHelpCommand(app)), nil
+	}
+
+	app, ok := source.(*kingpin.Application)
+	if !ok {
+		return nil, &parser.ParserError{
+			Message: "source is not a *kingpin.Application",
+			Cause:   parser.ErrInvalidSource,
+		}
+	}
+	if app == nil {
+		return nil, parser.ErrInvalidSource
+	}
+	return p.parseModel(app.Model(), app.HelpCommand != nil), nil
+}
+
+// parseModel converts the model of an application. The help command is
+// only present once the application has been parsed, and is left out.
+func (p *KingpinParser) parseModel(model *kingpin.ApplicationModel, hasHelpCommand bool) *parser.ParsedCLI {
+	parsed := &parser.ParsedCLI{
+		Commands:      make(map[string]*parser.CommandInfo),
+		FrameworkData: make(map[string]interface{}),
+	}
+
+	rootInfo := newCommandInfo(model.Name, nil)
+	rootInfo.Short = model.Help
+	rootInfo.Version = model.Version
+	parsed.RootCommand = rootInfo
+	parsed.Commands[rootInfo.Path] = rootInfo
+	p.parseGroups(rootInfo, model.FlagGroupModel, model.ArgGroupModel, model.CmdGroupModel, parsed.Commands)
+
+	if hasHelpCommand {
+		subcommands := make([]*parser.CommandInfo, 0, len(rootInfo.Subcommands))
+		for _, sub := range rootInfo.Subcommands {
+			if sub.Name == "help" {
+				delete(parsed.Commands, sub.Path)
+				continue
+			}
+			subcommands = append(subcommands, sub)
+		}
+		rootInfo.Subcommands = subcommands
+	}
+
+	parsed.Metadata = &parser.CLIMetadata{
+		Name:        model.Name,
+		Version:     model.Version,
+		Description: model.Help,
+		Author:      model.Author,
+		Tags:        make([]parser.TagInfo, 0),
+		EnvVars:     make([]parser.EnvVarInfo, 0),
+		Platforms:   make([]parser.PlatformInfo, 0),
+	}
+	parser.CollectMetadata(parsed)
+
+	parsed.FrameworkData["framework"] = "kingpin"
+
+	return parsed
+}
+
+// parseCommand converts a Kingpin command model and its subcommands
+func (p *KingpinParser) parseCommand(cmd *kingpin.CmdModel, parent *parser.CommandInfo, commands map[string]*parser.CommandInfo) *parser.CommandInfo {
+	info := newCommandInfo(cmd.Name, parent)
+	info.Short = cmd.Help
+	info.Long = cmd.HelpLong
+	info.Aliases = cmd.Aliases
+	info.Hidden = cmd.Hidden
+	commands[info.Path] = info
+
+	if cmd.Default {
+		parent.Extensions["kingpin_default_command"] = cmd.Name
+	}
+
+	p.parseGroups(info, cmd.FlagGroupModel, cmd.ArgGroupModel, cmd.CmdGroupModel, commands)
+	return info
+}
+
+// parseGroups fills flags, arguments and subcommands shared by applications and commands
+func (p *KingpinParser) parseGroups(info *parser.CommandInfo, flags *kingpin.FlagGroupModel, args *kingpin.ArgGroupModel, cmds *kingpin.CmdGroupModel, commands map[string]*parser.CommandInfo) {
+	hasSubcommands := cmds != nil && len(cmds.Commands) > 0
+	info.RunFunc = !hasSubcommands
+
+	// Flags of a command are also accepted after any of its subcommands
+	if flags != nil {
+		for _, flag := range flags.Flags {
+			if info.Parent == nil && builtinFlags[flag.Name] {
+				continue
+			}
+			flagInfo := p.parseFlag(flag, hasSubcommands)
+			if hasSubcommands {
+				info.PersistentFlags = append(info.PersistentFlags, flagInfo)
+			} else {
+				info.Flags = append(info.Flags, flagInfo)
+			}
+		}
+	}
+
+	usage := []string{info.Name}
+	if args != nil {
+		for i, arg := range args.Args {
+			info.Args = append(info.Args, p.parseArgument(arg, i+1))
+			placeholder := arg.PlaceHolder
+			if placeholder == "" {
+				placeholder = "<" + arg.Name + ">"
+			}
+			if isCumulative(arg.Value) {
+				placeholder += "..."
+			}
+			if !arg.Required {
+				placeholder = "[" + placeholder + "]"
+			}
+			usage = append(usage, placeholder)
+		}
+	}
+	if hasSubcommands {
+		usage = append(usage, "<command>")
+		for _, cmd := range cmds.Commands {
+			info.Subcommands = append(info.Subcommands, p.parseCommand(cmd, info, commands))
+		}
+	}
+	info.Use = strings.Join(usage, " ")
+}
+
+// parseFlag converts a Kingpin flag model to FlagInfo
+func (p *KingpinParser) parseFlag(flag *kingpin.FlagModel, persistent bool) *parser.FlagInfo {
+	info := &parser.FlagInfo{
+		Name:        flag.Name,
+		Usage:       flag.Help,
+		Type:        valueType(flag.Value),
+		Required:    flag.Required,
+		Hidden:      flag.Hidden,
+		Persistent:  persistent,
+		ValidValues: enumOptions(flag.Value),
+		Annotations: make(map[string]string),
+	}
+	if flag.Short != 0 {
+		info.Shorthand = string(flag.Short)
+	}
+	if len(flag.Default) > 0 {
+		info.DefaultValue = strings.Join(flag.Default, ",")
+	}
+	if flag.Envar != "" {
+		info.Annotations["envVars"] = flag.Envar
+	}
+	if flag.PlaceHolder != "" {
+		info.Annotations["placeholder"] = flag.PlaceHolder
+	}
+	return info
+}
+
+// parseArgument converts a Kingpin argument model to ArgumentInfo
+func (p *KingpinParser) parseArgument(arg *kingpin.ArgModel, position int) *parser.ArgumentInfo {
+	info := &parser.ArgumentInfo{
+		Name:        arg.Name,
+		Description: arg.Help,
+		Position:    position,
+		Required:    arg.Required,
+		Type:        valueType(arg.Value),
+		MaxArgs:     1,
+		ValidValues: enumOptions(arg.Value),
+	}
+	if arg.Required {
+		info.MinArgs = 1
+	}
+	if isCumulative(arg.Value) {
+		info.Type = strings.TrimSuffix(info.Type, "Slice")
+		info.MaxArgs = -1
+	}
+	return info
+}
+
+// valueType determines the type of a Kingpin value. The concrete values are
+// unexported, so the type comes from IsBoolFlag and the Getter result.
+func valueType(value kingpin.Value) string {
+	if value == nil {
+		return "string"
+	}
+	// Counters also report IsBoolFlag so they can be repeated without a value
+	if t := reflect.TypeOf(value); t.Kind() == reflect.Ptr && t.Elem().Name() == "counterValue" {
+		return "count"
+	}
+	if b, ok := value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return "bool"
+	}
+	if getter, ok := value.(kingpin.Getter); ok {
+		if v := getter.Get(); v != nil {
+			return parser.TypeName(reflect.TypeOf(v))
+		}
+	}
+	return "string"
+}
+
+// enumOptions returns the options of Enum and Enums values, which Kingpin
+// keeps in an unexported field
+func enumOptions(value kingpin.Value) []string {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() || rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	switch rv.Elem().Type().Name() {
+	case "enumValue", "enumsValue":
+	default:
+		return nil
+	}
+	options := rv.Elem().FieldByName("options")
+	if options.Kind() != reflect.Slice {
+		return nil
+	}
+	values := make([]string, 0, options.Len())
+	for i := 0; i < options.Len(); i++ {
+		values = append(values, options.Index(i).String())
+	}
+	return values
+}
+
+// isCumulative reports whether a value accepts repeated occurrences
+func isCumulative(value kingpin.Value) bool {
+	c, ok := value.(interface{ IsCumulative() bool })
+	return ok && c.IsCumulative()
+}
+
+// newCommandInfo creates an empty CommandInfo below parent
+func newCommandInfo(name string, parent *parser.CommandInfo) *parser.CommandInfo {
+	path := name
+	if parent != nil {
+		path = parent.Path + "/" + name
+	}
+	return &parser.CommandInfo{
+		Name:            name,
+		Path:            path,
+		Use:             name,
+		Parent:          parent,
+		Subcommands:     make([]*parser.CommandInfo, 0),
+		Flags:           make([]*parser.FlagInfo, 0),
+		Args:            make([]*parser.ArgumentInfo, 0),
+		PersistentFlags: make([]*parser.FlagInfo, 0),
+		Annotations:     make(map[string]string),
+		Tags:            make([]string, 0),
+		Extensions:      make(map[string]interface{}),
+	}
+}
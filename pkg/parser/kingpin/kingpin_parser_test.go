@@ -0,0 +1,128 @@
+package kingpin
+
+import (
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+)
+
+func newTestApp() *kingpin.Application {
+	app := kingpin.New("testapp", "A test application").Version("1.0.0").Author("Jane")
+	app.Flag("verbose", "Verbose output").Short('v').Counter()
+	app.Flag("config", "Config file").Envar("TESTAPP_CONFIG").Default("app.yaml").PlaceHolder("PATH").String()
+
+	user := app.Command("user", "User management")
+	create := user.Command("create", "Create a user").Alias("add")
+	create.Arg("username", "Name of the user").Required().String()
+	create.Arg("groups", "Groups to join").Strings()
+	create.Flag("role", "Role").Default("member").Enum("admin", "member")
+	create.Flag("timeout", "Timeout").Duration()
+	create.Flag("label", "Labels").StringMap()
+	create.Flag("token", "API token").Envar("TESTAPP_TOKEN").Required().Hidden().String()
+
+	app.Command("deploy", "Deploy a release").Default()
+	return app
+}
+
+func findFlag(flags []*parser.FlagInfo, name string) *parser.FlagInfo {
+	for _, flag := range flags {
+		if flag.Name == name {
+			return flag
+		}
+	}
+	return nil
+}
+
+func TestKingpinParser_Supports(t *testing.T) {
+	p := NewKingpinParser()
+	if p.Name() != "kingpin" {
+		t.Errorf("Expected parser name 'kingpin', got '%s'", p.Name())
+	}
+	if !p.Supports(newTestApp()) {
+		t.Error("Expected *kingpin.Application to be supported")
+	}
+	if p.Supports("not an app") || p.Supports(nil) {
+		t.Error("Expected string and nil to be unsupported")
+	}
+}
+
+func TestKingpinParser_Parse(t *testing.T) {
+	p := NewKingpinParser()
+
+	parsed, err := p.Parse(newTestApp())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	root := parsed.RootCommand
+	if root.Name != "testapp" || root.Version != "1.0.0" || root.RunFunc {
+		t.Errorf("Unexpected root command: %+v", root)
+	}
+	if root.Extensions["kingpin_default_command"] != "deploy" {
+		t.Errorf("Expected default command deploy, got %v", root.Extensions["kingpin_default_command"])
+	}
+	if findFlag(root.PersistentFlags, "help") != nil || findFlag(root.PersistentFlags, "help-man") != nil {
+		t.Error("Expected built-in flags to be skipped")
+	}
+	if f := findFlag(root.PersistentFlags, "verbose"); f == nil || f.Type != "count" || f.Shorthand != "v" {
+		t.Errorf("Expected counter verbose flag, got %+v", f)
+	}
+	if f := findFlag(root.PersistentFlags, "config"); f == nil || f.DefaultValue != "app.yaml" || f.Annotations["placeholder"] != "PATH" {
+		t.Errorf("Unexpected config flag: %+v", f)
+	}
+
+	create := parsed.Commands["testapp/user/create"]
+	if create == nil {
+		t.Fatal("Expected command testapp/user/create")
+	}
+	if !create.RunFunc || len(create.Aliases) != 1 || create.Aliases[0] != "add" {
+		t.Errorf("Unexpected create command: %+v", create)
+	}
+	if create.Use != "create <username> [<groups>...]" {
+		t.Errorf("Unexpected use string %q", create.Use)
+	}
+	if len(create.Args) != 2 || !create.Args[0].Required || create.Args[1].MaxArgs != -1 || create.Args[1].Type != "string" {
+		t.Errorf("Unexpected args: %+v %+v", create.Args[0], create.Args[1])
+	}
+
+	role := findFlag(create.Flags, "role")
+	if role == nil || len(role.ValidValues) != 2 || role.ValidValues[1] != "member" || role.DefaultValue != "member" {
+		t.Errorf("Unexpected role flag: %+v", role)
+	}
+	if f := findFlag(create.Flags, "timeout"); f == nil || f.Type != "duration" {
+		t.Errorf("Expected duration flag, got %+v", f)
+	}
+	if f := findFlag(create.Flags, "label"); f == nil || f.Type != "stringToString" {
+		t.Errorf("Expected map flag, got %+v", f)
+	}
+	if f := findFlag(create.Flags, "token"); f == nil || !f.Required || !f.Hidden {
+		t.Errorf("Expected required hidden token flag, got %+v", f)
+	}
+
+	if len(parsed.Metadata.EnvVars) != 2 || parsed.Metadata.EnvVars[0].Name != "TESTAPP_CONFIG" || !parsed.Metadata.EnvVars[1].Required {
+		t.Errorf("Unexpected env vars: %+v", parsed.Metadata.EnvVars)
+	}
+	if parsed.Metadata.Author != "Jane" {
+		t.Errorf("Unexpected author %q", parsed.Metadata.Author)
+	}
+}
+
+func TestKingpinParser_ParseAfterInit(t *testing.T) {
+	app := newTestApp()
+	app.Terminate(nil)
+	if _, err := app.Parse([]string{"deploy"}); err != nil {
+		t.Fatalf("app.Parse() error = %v", err)
+	}
+
+	parsed, err := NewKingpinParser().Parse(app)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if _, ok := parsed.Commands["testapp/help"]; ok {
+		t.Error("Expected the generated help command to be skipped")
+	}
+	if len(parsed.RootCommand.Subcommands) != 2 {
+		t.Errorf("Expected 2 subcommands, got %d", len(parsed.RootCommand.Subcommands))
+	}
+}
@@ -0,0 +1,97 @@
+package kingpin
+
+import (
+	"reflect"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+// legacyPackages are the import paths of Kingpin releases whose
+// applications are read through reflection. gopkg.in/alecthomas/kingpin.v2
+// predates the move to github.com/alecthomas/kingpin/v2 and declares
+// distinct types with the same model, so this module does not need to
+// depend on it.
+var legacyPackages = map[string]bool{
+	"gopkg.in/alecthomas/kingpin.v2": true,
+}
+
+// legacyApp returns source as a reflected *Application of a legacy Kingpin
+// package
+func legacyApp(source interface{}) (reflect.Value, bool) {
+	app := reflect.ValueOf(source)
+	if app.Kind() != reflect.Ptr || app.Type().Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	if !legacyPackages[app.Type().Elem().PkgPath()] {
+		return reflect.Value{}, false
+	}
+	model, ok := app.Type().MethodByName("Model")
+	if !ok || model.Type.NumIn() != 1 || model.Type.NumOut() != 1 {
+		return reflect.Value{}, false
+	}
+	return app, true
+}
+
+// legacyModel returns the model of a legacy application, copied into the
+// model types of github.com/alecthomas/kingpin/v2. Values keep their
+// legacy types, which have the same methods.
+func legacyModel(app reflect.Value) *kingpin.ApplicationModel {
+	model := &kingpin.ApplicationModel{}
+	copyModel(reflect.ValueOf(&model).Elem(), app.MethodByName("Model").Call(nil)[0])
+	return model
+}
+
+// legacyHasHelpCommand reports whether a legacy application has been
+// parsed and so has a help command
+func legacyHasHelpCommand(app reflect.Value) bool {
+	help := app.Elem().FieldByName("HelpCommand")
+	return help.IsValid() && help.Kind() == reflect.Ptr && !help.IsNil()
+}
+
+// copyModel copies src into dst, matching struct fields by name. Fields
+// missing from src are left zero, and values that do not fit are skipped.
+func copyModel(dst, src reflect.Value) {
+	if !src.IsValid() {
+		return
+	}
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if src.Kind() != reflect.Ptr || src.IsNil() {
+			return
+		}
+		dst.Set(reflect.New(dst.Type().Elem()))
+		copyModel(dst.Elem(), src.Elem())
+	case reflect.Struct:
+		if src.Kind() != reflect.Struct {
+			return
+		}
+		for i := 0; i < dst.NumField(); i++ {
+			field := dst.Type().Field(i)
+			if field.IsExported() {
+				copyModel(dst.Field(i), src.FieldByName(field.Name))
+			}
+		}
+	case reflect.Slice:
+		if src.Kind() != reflect.Slice || src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			copyModel(dst.Index(i), src.Index(i))
+		}
+	case reflect.Interface:
+		if src.Kind() == reflect.Interface {
+			if src.IsNil() {
+				return
+			}
+			src = src.Elem()
+		}
+		if src.Type().Implements(dst.Type()) {
+			dst.Set(src)
+		}
+	default:
+		if src.Kind() == dst.Kind() && src.Type().ConvertibleTo(dst.Type()) {
+			dst.Set(src.Convert(dst.Type()))
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package kingpin
+
+import (
+	"reflect"
+	"testing"
+)
+
+// The types below stand in for those of gopkg.in/alecthomas/kingpin.v2,
+// which declares the same model in a different package
+
+type legacyApplication struct {
+	HelpCommand *legacyCmdModel
+	model       *legacyApplicationModel
+}
+
+func (a *legacyApplication) Model() *legacyApplicationModel { return a.model }
+
+type legacyApplicationModel struct {
+	Name           string
+	Help           string
+	Version        string
+	Author         string
+	FlagGroupModel *legacyFlagGroupModel
+	ArgGroupModel  *legacyArgGroupModel
+	CmdGroupModel  *legacyCmdGroupModel
+}
+
+type legacyFlagGroupModel struct{ Flags []*legacyFlagModel }
+
+type legacyFlagModel struct {
+	Name    string
+	Help    string
+	Short   rune
+	Default []string
+	Envar   string
+	Value   legacyValue
+}
+
+type legacyArgGroupModel struct{ Args []*legacyArgModel }
+
+type legacyArgModel struct {
+	Name     string
+	Required bool
+	Value    legacyValue
+}
+
+type legacyCmdGroupModel struct{ Commands []*legacyCmdModel }
+
+type legacyCmdModel struct {
+	Name           string
+	Aliases        []string
+	Help           string
+	FlagGroupModel *legacyFlagGroupModel
+	ArgGroupModel  *legacyArgGroupModel
+	CmdGroupModel  *legacyCmdGroupModel
+}
+
+type legacyValue interface {
+	String() string
+	Set(string) error
+}
+
+type legacyBool bool
+
+func (b *legacyBool) String() string   { return "false" }
+func (b *legacyBool) Set(string) error { return nil }
+func (b *legacyBool) IsBoolFlag() bool { return true }
+func (b *legacyBool) Get() interface{} { return bool(*b) }
+
+type legacyString string
+
+func (s *legacyString) String() string   { return string(*s) }
+func (s *legacyString) Set(string) error { return nil }
+
+// allowLegacyPackage registers the package of the stand-in types as a
+// legacy Kingpin release until the end of the test
+func allowLegacyPackage(t *testing.T) {
+	t.Helper()
+	path := reflect.TypeOf(legacyApplication{}).PkgPath()
+	previous, ok := legacyPackages[path]
+	legacyPackages[path] = true
+	t.Cleanup(func() {
+		if ok {
+			legacyPackages[path] = previous
+		} else {
+			delete(legacyPackages, path)
+		}
+	})
+}
+
+func TestKingpinParser_LegacyImportPath(t *testing.T) {
+	allowLegacyPackage(t)
+
+	app := &legacyApplication{
+		HelpCommand: &legacyCmdModel{Name: "help"},
+		model: &legacyApplicationModel{
+			Name:    "legacy",
+			Help:    "A legacy application",
+			Version: "0.1.0",
+			FlagGroupModel: &legacyFlagGroupModel{Flags: []*legacyFlagModel{
+				{Name: "help", Value: new(legacyBool)},
+				{Name: "debug", Help: "Debug output", Short: 'd', Value: new(legacyBool)},
+			}},
+			CmdGroupModel: &legacyCmdGroupModel{Commands: []*legacyCmdModel{
+				{Name: "help"},
+				{
+					Name:    "get",
+					Aliases: []string{"g"},
+					Help:    "Get a key",
+					FlagGroupModel: &legacyFlagGroupModel{Flags: []*legacyFlagModel{
+						{Name: "format", Default: []string{"json"}, Envar: "LEGACY_FORMAT", Value: new(legacyString)},
+					}},
+					ArgGroupModel: &legacyArgGroupModel{Args: []*legacyArgModel{
+						{Name: "key", Required: true, Value: new(legacyString)},
+					}},
+				},
+			}},
+		},
+	}
+
+	p := NewKingpinParser()
+	if !p.Supports(app) {
+		t.Fatal("Expected the legacy application to be supported")
+	}
+	parsed, err := p.Parse(app)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	root := parsed.RootCommand
+	if root.Name != "legacy" || root.Version != "0.1.0" || len(root.Subcommands) != 1 {
+		t.Fatalf("Unexpected root command: %+v", root)
+	}
+	if findFlag(root.PersistentFlags, "help") != nil {
+		t.Error("Expected the built-in help flag to be skipped")
+	}
+	if f := findFlag(root.PersistentFlags, "debug"); f == nil || f.Type != "bool" || f.Shorthand != "d" {
+		t.Errorf("Unexpected debug flag: %+v", f)
+	}
+
+	get := parsed.Commands["legacy/get"]
+	if get == nil || get.Aliases[0] != "g" || len(get.Args) != 1 || !get.Args[0].Required {
+		t.Fatalf("Unexpected get command: %+v", get)
+	}
+	if f := findFlag(get.Flags, "format"); f == nil || f.DefaultValue != "json" || f.Annotations["envVars"] != "LEGACY_FORMAT" {
+		t.Errorf("Unexpected format flag: %+v", f)
+	}
+	if len(parsed.Metadata.EnvVars) != 1 {
+		t.Errorf("Expected one env var, got %+v", parsed.Metadata.EnvVars)
+	}
+}
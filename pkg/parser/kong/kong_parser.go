@@ -0,0 +1,312 @@
+package kong
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// KongParser implements the Parser interface for the Kong struct-tag CLI framework
+type KongParser struct{}
+
+// NewKongParser creates a new Kong parser
+func NewKongParser() *KongParser {
+	return &KongParser{}
+}
+
+// Name returns the parser name
+func (p *KongParser) Name() string {
+	return "kong"
+}
+
+// Supports checks if the source is a *kong.Kong or its *kong.Application model
+func (p *KongParser) Supports(source interface{}) bool {
+	switch source.(type) {
+	case *kong.Kong, *kong.Application:
+		return true
+	}
+	return false
+}
+
+// Parse extracts CLI structure from a Kong application
+func (p *KongParser) Parse(source interface{}) (*parser.ParsedCLI, error) {
+	var app *kong.Application
+	switch s := source.(type) {
+	case *kong.Kong:
+		if s != nil {
+			app = s.Model
+		}
+	case *kong.Application:
+		app = s
+	default:
+		return nil, &parser.ParserError{
+			Message: "source is not a *kong.Kong or *kong.Application",
+			Cause:   parser.ErrInvalidSource,
+		}
+	}
+	if app == nil || app.Node == nil {
+		return nil, parser.ErrInvalidSource
+	}
+
+	parsed := &parser.ParsedCLI{
+		Commands:      make(map[string]*parser.CommandInfo),
+		FrameworkData: make(map[string]interface{}),
+	}
+
+	parsed.RootCommand = p.parseNode(app, app.Node, nil, parsed.Commands)
+	parsed.Metadata = p.extractMetadata(app)
+	parser.CollectMetadata(parsed)
+
+	parsed.FrameworkData["framework"] = "kong"
+
+	return parsed, nil
+}
+
+// parseNode converts a Kong node and its children. Branching positional
+// arguments (`arg:""` fields with children) become subcommands named after
+// the argument.
+func (p *KongParser) parseNode(app *kong.Application, node *kong.Node, parent *parser.CommandInfo, commands map[string]*parser.CommandInfo) *parser.CommandInfo {
+	name := node.Name
+	if node.Type == kong.ArgumentNode {
+		name = "<" + node.Name + ">"
+	}
+
+	path := name
+	if parent != nil {
+		path = parent.Path + "/" + name
+	}
+
+	info := &parser.CommandInfo{
+		Name:            name,
+		Path:            path,
+		Short:           node.Help,
+		Long:            node.Detail,
+		Aliases:         node.Aliases,
+		Parent:          parent,
+		Subcommands:     make([]*parser.CommandInfo, 0),
+		Flags:           make([]*parser.FlagInfo, 0),
+		Args:            make([]*parser.ArgumentInfo, 0),
+		PersistentFlags: make([]*parser.FlagInfo, 0),
+		Hidden:          node.Hidden,
+		RunFunc:         node.Leaf() || hasRunMethod(node.Target),
+		Annotations:     make(map[string]string),
+		Tags:            make([]string, 0),
+		Extensions:      make(map[string]interface{}),
+	}
+	if node.Type == kong.ApplicationNode {
+		info.Version = node.Vars()["version"]
+	}
+	if node.Group != nil {
+		info.Tags = append(info.Tags, groupName(node.Group))
+	}
+	if node.Passthrough {
+		info.Extensions["kong_passthrough"] = true
+	}
+	if node.DefaultCmd != nil {
+		info.Extensions["kong_default_command"] = node.DefaultCmd.Name
+	}
+	commands[info.Path] = info
+
+	// Flags declared on a node are accepted by all of its descendants
+	persistent := len(node.Children) > 0
+	for _, flag := range node.Flags {
+		if flag == app.HelpFlag {
+			continue
+		}
+		flagInfo := p.parseFlag(flag, persistent)
+		if persistent {
+			info.PersistentFlags = append(info.PersistentFlags, flagInfo)
+		} else {
+			info.Flags = append(info.Flags, flagInfo)
+		}
+	}
+	info.FlagGroups = flagGroups(node)
+
+	usage := []string{name}
+	if node.Argument != nil {
+		info.Args = append(info.Args, p.parseArgument(node.Argument, 1))
+	}
+	for _, positional := range node.Positional {
+		arg := p.parseArgument(positional, len(info.Args)+1)
+		info.Args = append(info.Args, arg)
+		usage = append(usage, positional.Summary())
+	}
+	if len(node.Children) > 0 && len(node.Positional) == 0 {
+		usage = append(usage, "<command>")
+	}
+	info.Use = strings.Join(usage, " ")
+
+	for _, child := range node.Children {
+		childInfo := p.parseNode(app, child, info, commands)
+		info.Subcommands = append(info.Subcommands, childInfo)
+	}
+
+	return info
+}
+
+// parseFlag converts a Kong flag to FlagInfo
+func (p *KongParser) parseFlag(flag *kong.Flag, persistent bool) *parser.FlagInfo {
+	info := &parser.FlagInfo{
+		Name:        flag.Name,
+		Usage:       flag.Help,
+		Type:        valueType(flag.Value),
+		Required:    flag.Required,
+		Hidden:      flag.Hidden,
+		Persistent:  persistent,
+		ValidValues: enumValues(flag.Value),
+		Annotations: make(map[string]string),
+	}
+	if flag.Short != 0 {
+		info.Shorthand = string(flag.Short)
+	}
+	if flag.HasDefault {
+		info.DefaultValue = flag.Default
+	}
+
+	info.Aliases = append(info.Aliases, flag.Aliases...)
+	if len(flag.Envs) > 0 {
+		info.Annotations["envVars"] = strings.Join(flag.Envs, ",")
+	}
+	if flag.PlaceHolder != "" {
+		info.Annotations["placeholder"] = flag.PlaceHolder
+	}
+	if flag.Group != nil {
+		info.Annotations["group"] = groupName(flag.Group)
+	}
+	if flag.Tag != nil {
+		if flag.Tag.Negatable != "" {
+			info.Annotations["negatable"] = flag.Tag.Negatable
+		}
+		if flag.Tag.Type != "" {
+			info.Annotations["kongType"] = flag.Tag.Type
+		}
+		if flag.Tag.Format != "" {
+			info.Annotations["format"] = flag.Tag.Format
+		}
+	}
+
+	return info
+}
+
+// flagGroups returns the `xor:""` groups of node as mutually exclusive
+// groups and its `and:""` groups as required-together groups. Kong checks
+// groups across the flags of a command and its ancestors, so a group is
+// reported on the nodes that declare at least one of its flags.
+func flagGroups(node *kong.Node) []*parser.FlagGroupInfo {
+	groups := make([]*parser.FlagGroupInfo, 0)
+	for _, g := range []struct {
+		kind  string
+		names func(*kong.Flag) []string
+	}{
+		{spec.FlagGroupMutuallyExclusive, func(f *kong.Flag) []string { return f.Xor }},
+		{spec.FlagGroupRequiredTogether, func(f *kong.Flag) []string { return f.And }},
+	} {
+		order := make([]string, 0)
+		members := make(map[string][]string)
+		declared := make(map[string]bool)
+		for n := node; n != nil; n = n.Parent {
+			for _, flag := range n.Flags {
+				for _, name := range g.names(flag) {
+					if _, ok := members[name]; !ok {
+						order = append(order, name)
+					}
+					members[name] = append(members[name], flag.Name)
+					declared[name] = declared[name] || n == node
+				}
+			}
+		}
+		for _, name := range order {
+			if declared[name] && len(members[name]) > 1 {
+				groups = append(groups, &parser.FlagGroupInfo{Kind: g.kind, Flags: members[name]})
+			}
+		}
+	}
+	return groups
+}
+
+// parseArgument converts a Kong positional value to ArgumentInfo
+func (p *KongParser) parseArgument(value *kong.Value, position int) *parser.ArgumentInfo {
+	arg := &parser.ArgumentInfo{
+		Name:        value.Name,
+		Description: value.Help,
+		Position:    position,
+		Required:    value.Required,
+		Type:        valueType(value),
+		MaxArgs:     1,
+		ValidValues: enumValues(value),
+	}
+	if value.Required {
+		arg.MinArgs = 1
+	}
+	if value.IsCumulative() {
+		arg.Type = "string"
+		if elem := value.Target.Type(); elem.Kind() == reflect.Slice {
+			arg.Type = parser.TypeName(elem.Elem())
+		}
+		arg.MaxArgs = -1
+	}
+	return arg
+}
+
+// extractMetadata extracts global CLI metadata from the application node
+func (p *KongParser) extractMetadata(app *kong.Application) *parser.CLIMetadata {
+	metadata := &parser.CLIMetadata{
+		Name:        app.Name,
+		Version:     app.Vars()["version"],
+		Description: app.Help,
+		Tags:        make([]parser.TagInfo, 0),
+		EnvVars:     make([]parser.EnvVarInfo, 0),
+		Platforms:   make([]parser.PlatformInfo, 0),
+	}
+	if app.Detail != "" {
+		metadata.Description = app.Detail
+	}
+	return metadata
+}
+
+// valueType maps a Kong value to the type names used by other parsers
+func valueType(value *kong.Value) string {
+	switch {
+	case value.IsCounter():
+		return "count"
+	case value.IsBool():
+		return "bool"
+	}
+	return parser.TypeName(value.Target.Type())
+}
+
+// enumValues returns the allowed values of an `enum:""` tag
+func enumValues(value *kong.Value) []string {
+	if value.Enum == "" {
+		return nil
+	}
+	values := make([]string, 0)
+	for _, v := range value.EnumSlice() {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// groupName returns the display name of a Kong group
+func groupName(group *kong.Group) string {
+	if group.Title != "" {
+		return group.Title
+	}
+	return group.Key
+}
+
+// hasRunMethod reports whether a command struct implements Run
+func hasRunMethod(target reflect.Value) bool {
+	if !target.IsValid() {
+		return false
+	}
+	if target.CanAddr() {
+		target = target.Addr()
+	}
+	return target.MethodByName("Run").IsValid()
+}
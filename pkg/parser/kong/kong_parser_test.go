@@ -0,0 +1,204 @@
+package kong
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/harihs-330/gospec-cli/pkg/converter"
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+type testCLI struct {
+	Verbose int    `short:"v" type:"counter" help:"Increase verbosity"`
+	Config  string `env:"TESTAPP_CONFIG" default:"app.yaml" placeholder:"PATH" help:"Config file"`
+
+	User struct {
+		Create struct {
+			Username string   `arg:"" help:"Name of the user"`
+			Groups   []string `arg:"" optional:"" help:"Groups to join"`
+			Role     string   `enum:"admin,member" default:"member" help:"Role"`
+			Expires  time.Duration
+			Labels   map[string]string
+			Token    string `required:"" env:"TESTAPP_TOKEN" hidden:""`
+		} `cmd:"" aliases:"add" help:"Create a user"`
+	} `cmd:"" group:"accounts" help:"User management"`
+
+	Deploy deployCmd `cmd:"" help:"Deploy a release"`
+}
+
+type deployCmd struct {
+	Env string `arg:"" enum:"dev,prod" help:"Target environment"`
+}
+
+func (d *deployCmd) Run() error { return nil }
+
+func findFlag(flags []*parser.FlagInfo, name string) *parser.FlagInfo {
+	for _, flag := range flags {
+		if flag.Name == name {
+			return flag
+		}
+	}
+	return nil
+}
+
+func TestKongParser_Supports(t *testing.T) {
+	p := NewKongParser()
+	if p.Name() != "kong" {
+		t.Errorf("Expected parser name 'kong', got '%s'", p.Name())
+	}
+
+	k, err := kong.New(&testCLI{}, kong.Name("testapp"))
+	if err != nil {
+		t.Fatalf("kong.New() error = %v", err)
+	}
+	if !p.Supports(k) || !p.Supports(k.Model) {
+		t.Error("Expected *kong.Kong and *kong.Application to be supported")
+	}
+	if p.Supports(&testCLI{}) || p.Supports(nil) {
+		t.Error("Expected grammar structs and nil to be unsupported")
+	}
+}
+
+func TestKongParser_Parse(t *testing.T) {
+	p := NewKongParser()
+
+	k, err := kong.New(&testCLI{}, kong.Name("testapp"), kong.Vars{"version": "1.2.3"})
+	if err != nil {
+		t.Fatalf("kong.New() error = %v", err)
+	}
+
+	parsed, err := p.Parse(k)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	root := parsed.RootCommand
+	if root.Name != "testapp" || root.Version != "1.2.3" {
+		t.Errorf("Unexpected root command %q version %q", root.Name, root.Version)
+	}
+	if findFlag(root.PersistentFlags, "help") != nil {
+		t.Error("Expected the built-in help flag to be skipped")
+	}
+	if f := findFlag(root.PersistentFlags, "verbose"); f == nil || f.Type != "count" || f.Shorthand != "v" {
+		t.Errorf("Expected counter verbose flag, got %+v", f)
+	}
+	config := findFlag(root.PersistentFlags, "config")
+	if config == nil || config.DefaultValue != "app.yaml" || config.Annotations["placeholder"] != "PATH" {
+		t.Errorf("Unexpected config flag: %+v", config)
+	}
+
+	create := parsed.Commands["testapp/user/create"]
+	if create == nil {
+		t.Fatal("Expected command testapp/user/create")
+	}
+	if !create.RunFunc || len(create.Aliases) != 1 || create.Aliases[0] != "add" {
+		t.Errorf("Unexpected create command: %+v", create)
+	}
+	if len(create.Args) != 2 {
+		t.Fatalf("Expected 2 args, got %d", len(create.Args))
+	}
+	if username := create.Args[0]; username.Name != "username" || !username.Required || username.Position != 1 {
+		t.Errorf("Unexpected username arg: %+v", username)
+	}
+	if groups := create.Args[1]; groups.Required || groups.MaxArgs != -1 || groups.Type != "string" {
+		t.Errorf("Unexpected groups arg: %+v", groups)
+	}
+
+	role := findFlag(create.Flags, "role")
+	if role == nil || len(role.ValidValues) != 2 || role.ValidValues[0] != "admin" || role.DefaultValue != "member" {
+		t.Errorf("Unexpected role flag: %+v", role)
+	}
+	if f := findFlag(create.Flags, "expires"); f == nil || f.Type != "duration" {
+		t.Errorf("Expected duration flag, got %+v", f)
+	}
+	if f := findFlag(create.Flags, "labels"); f == nil || f.Type != "stringToString" {
+		t.Errorf("Expected map flag, got %+v", f)
+	}
+	if f := findFlag(create.Flags, "token"); f == nil || !f.Required || !f.Hidden {
+		t.Errorf("Expected required hidden token flag, got %+v", f)
+	}
+
+	user := parsed.Commands["testapp/user"]
+	if user.RunFunc || len(user.Tags) != 1 || user.Tags[0] != "accounts" {
+		t.Errorf("Unexpected user command: %+v", user)
+	}
+
+	deploy := parsed.Commands["testapp/deploy"]
+	if deploy == nil || !deploy.RunFunc {
+		t.Fatalf("Expected runnable deploy command, got %+v", deploy)
+	}
+	if len(deploy.Args) != 1 || len(deploy.Args[0].ValidValues) != 2 || deploy.Args[0].ValidValues[1] != "prod" {
+		t.Errorf("Expected enum argument, got %+v", deploy.Args)
+	}
+
+	envVars := make(map[string]parser.EnvVarInfo)
+	for _, env := range parsed.Metadata.EnvVars {
+		envVars[env.Name] = env
+	}
+	if env, ok := envVars["TESTAPP_CONFIG"]; !ok || env.Default != "app.yaml" {
+		t.Errorf("Expected TESTAPP_CONFIG env var, got %+v", parsed.Metadata.EnvVars)
+	}
+	if env, ok := envVars["TESTAPP_TOKEN"]; !ok || !env.Required {
+		t.Errorf("Expected required TESTAPP_TOKEN env var, got %+v", parsed.Metadata.EnvVars)
+	}
+	if len(parsed.Metadata.Tags) != 1 || parsed.Metadata.Tags[0].Name != "accounts" {
+		t.Errorf("Expected accounts tag, got %+v", parsed.Metadata.Tags)
+	}
+}
+
+type groupsCLI struct {
+	Config string `aliases:"cfg,conf" help:"Config file"`
+
+	Export struct {
+		JSON   bool   `xor:"format" help:"Write JSON"`
+		YAML   bool   `xor:"format" help:"Write YAML"`
+		User   string `and:"auth" help:"User name"`
+		Secret string `and:"auth" help:"Password"`
+	} `cmd:"" help:"Export data"`
+}
+
+func TestKongParser_AliasesAndFlagGroups(t *testing.T) {
+	k, err := kong.New(&groupsCLI{}, kong.Name("testapp"))
+	if err != nil {
+		t.Fatalf("kong.New() error = %v", err)
+	}
+	parsed, err := NewKongParser().Parse(k)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	export := parsed.Commands["testapp/export"]
+	if export == nil {
+		t.Fatal("Expected command testapp/export")
+	}
+	want := []*parser.FlagGroupInfo{
+		{Kind: spec.FlagGroupMutuallyExclusive, Flags: []string{"json", "yaml"}},
+		{Kind: spec.FlagGroupRequiredTogether, Flags: []string{"user", "secret"}},
+	}
+	if !reflect.DeepEqual(export.FlagGroups, want) {
+		t.Errorf("Expected flag groups %+v, got %+v", want, export.FlagGroups)
+	}
+	if len(parsed.RootCommand.FlagGroups) != 0 {
+		t.Errorf("Expected no flag groups on the root, got %+v", parsed.RootCommand.FlagGroups)
+	}
+
+	s, err := converter.NewDefaultConverter().Convert(parsed, nil)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	var alias []string
+	for _, param := range s.Commands["testapp"].Parameters {
+		if param.Name == "config" {
+			alias = param.Alias
+		}
+	}
+	if !reflect.DeepEqual(alias, []string{"cfg", "conf"}) {
+		t.Errorf("Expected --config aliases [cfg conf] in the spec, got %v", alias)
+	}
+	if groups := s.Commands["/testapp/export"].FlagGroups; len(groups) != 2 || groups[0].Kind != spec.FlagGroupMutuallyExclusive {
+		t.Errorf("Expected the flag groups of export in the spec, got %+v", groups)
+	}
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CollectMetadata fills the tags and environment variables of the metadata
+// of parsed from its commands: every command tag becomes a tag, and every
+// name in the comma-separated "envVars" annotation of a flag an environment
+// variable described by the flag. Parsers call it after building the
+// command tree and parsed.Metadata.
+func CollectMetadata(parsed *ParsedCLI) {
+	seenTags := make(map[string]bool)
+	seenEnv := make(map[string]bool)
+
+	var visit func(cmd *CommandInfo)
+	visit = func(cmd *CommandInfo) {
+		for _, tag := range cmd.Tags {
+			if !seenTags[tag] {
+				seenTags[tag] = true
+				parsed.Metadata.Tags = append(parsed.Metadata.Tags, TagInfo{Name: tag})
+			}
+		}
+		// Build a new slice, appending to PersistentFlags could write into
+		// its spare capacity and change the flags of the parsed command
+		flags := make([]*FlagInfo, 0, len(cmd.PersistentFlags)+len(cmd.Flags))
+		flags = append(append(flags, cmd.PersistentFlags...), cmd.Flags...)
+		for _, flag := range flags {
+			envVars := flag.Annotations["envVars"]
+			if envVars == "" {
+				continue
+			}
+			for _, name := range strings.Split(envVars, ",") {
+				if seenEnv[name] {
+					continue
+				}
+				seenEnv[name] = true
+				env := EnvVarInfo{
+					Name:        name,
+					Description: flag.Usage,
+					Required:    flag.Required,
+				}
+				if s, ok := flag.DefaultValue.(string); ok {
+					env.Default = s
+				}
+				parsed.Metadata.EnvVars = append(parsed.Metadata.EnvVars, env)
+			}
+		}
+		for _, sub := range cmd.Subcommands {
+			visit(sub)
+		}
+	}
+	if parsed.RootCommand != nil {
+		visit(parsed.RootCommand)
+	}
+}
+
+// TypeName maps the Go type of a flag or argument value to the type names
+// pflag uses, which the converter understands: "int", "stringSlice",
+// "stringToInt", "duration" and so on. Byte slices and named slices such as
+// net.IP decode a single value and are strings, as are types without a
+// better name.
+func TypeName(t reflect.Type) string {
+	if t == nil {
+		return "string"
+	}
+	switch t {
+	case reflect.TypeOf(time.Duration(0)):
+		return "duration"
+	case reflect.TypeOf(time.Time{}):
+		return "timestamp"
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return TypeName(t.Elem())
+	case reflect.Slice:
+		if t.Name() != "" || t.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return TypeName(t.Elem()) + "Slice"
+	case reflect.Map:
+		key, elem := TypeName(t.Key()), TypeName(t.Elem())
+		return key + "To" + strings.ToUpper(elem[:1]) + elem[1:]
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return t.Kind().String()
+	}
+	return "string"
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypeName(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{"", "string"},
+		{0, "int"},
+		{uint8(0), "uint8"},
+		{1.5, "float64"},
+		{true, "bool"},
+		{time.Second, "duration"},
+		{time.Time{}, "timestamp"},
+		{new(int), "int"},
+		{[]string{}, "stringSlice"},
+		{[]int{}, "intSlice"},
+		{[]byte{}, "string"},
+		{net.IP{}, "string"},
+		{map[string]int{}, "stringToInt"},
+		{struct{}{}, "string"},
+	}
+	for _, tt := range tests {
+		if got := TypeName(reflect.TypeOf(tt.value)); got != tt.want {
+			t.Errorf("TypeName(%T) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+	if got := TypeName(nil); got != "string" {
+		t.Errorf("TypeName(nil) = %q, want %q", got, "string")
+	}
+}
+
+func TestCollectMetadata(t *testing.T) {
+	parsed := &ParsedCLI{
+		Metadata: &CLIMetadata{},
+		RootCommand: &CommandInfo{
+			Name: "app",
+			Tags: []string{"admin"},
+			PersistentFlags: []*FlagInfo{{
+				Name:        "token",
+				Usage:       "API token",
+				Required:    true,
+				Annotations: map[string]string{"envVars": "APP_TOKEN,TOKEN"},
+			}},
+			Subcommands: []*CommandInfo{{
+				Name: "user",
+				Tags: []string{"admin", "users"},
+				Flags: []*FlagInfo{{
+					Name:         "region",
+					Usage:        "Region",
+					DefaultValue: "eu",
+					Annotations:  map[string]string{"envVars": "APP_REGION,TOKEN"},
+				}},
+			}},
+		},
+	}
+
+	CollectMetadata(parsed)
+
+	var tags []string
+	for _, tag := range parsed.Metadata.Tags {
+		tags = append(tags, tag.Name)
+	}
+	if want := []string{"admin", "users"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("Expected tags %v, got %v", want, tags)
+	}
+
+	want := []EnvVarInfo{
+		{Name: "APP_TOKEN", Description: "API token", Required: true},
+		{Name: "TOKEN", Description: "API token", Required: true},
+		{Name: "APP_REGION", Description: "Region", Default: "eu"},
+	}
+	if !reflect.DeepEqual(parsed.Metadata.EnvVars, want) {
+		t.Errorf("Expected env vars %+v, got %+v", want, parsed.Metadata.EnvVars)
+	}
+}
+
+func TestCollectMetadata_NoRoot(t *testing.T) {
+	parsed := &ParsedCLI{Metadata: &CLIMetadata{}}
+	CollectMetadata(parsed)
+	if len(parsed.Metadata.Tags) != 0 || len(parsed.Metadata.EnvVars) != 0 {
+		t.Errorf("Expected empty metadata, got %+v", parsed.Metadata)
+	}
+}
+
+func TestCollectMetadata_KeepsFlags(t *testing.T) {
+	token := &FlagInfo{Name: "token"}
+	spare := &FlagInfo{Name: "spare"}
+	persistent := make([]*FlagInfo, 1, 2)
+	persistent[0] = token
+	backing := persistent[:2]
+	backing[1] = spare
+
+	parsed := &ParsedCLI{
+		Metadata: &CLIMetadata{},
+		RootCommand: &CommandInfo{
+			Name:            "app",
+			PersistentFlags: persistent,
+			Flags:           []*FlagInfo{{Name: "region"}},
+		},
+	}
+	CollectMetadata(parsed)
+
+	if backing[1] != spare {
+		t.Errorf("Expected spare capacity of persistent flags untouched, got %+v", backing[1])
+	}
+}
@@ -0,0 +1,32 @@
+package parser
+
+import "time"
+
+// PackageSource identifies Go source code to be analyzed without executing it
+type PackageSource struct {
+	// Dir is the directory the package patterns are resolved from
+	Dir string
+
+	// Patterns are go/packages patterns (default "./...")
+	Patterns []string
+
+	// Root names the variable or function holding the root command.
+	// When empty the command with the largest subtree is used.
+	Root string
+}
+
+// BinarySource identifies a compiled executable whose structure is scraped
+// from its help output
+type BinarySource struct {
+	// Path is the executable, either a file path or a name looked up in PATH
+	Path string
+
+	// Timeout bounds each help invocation (default 10s)
+	Timeout time.Duration
+
+	// MaxDepth limits how deep subcommands are followed (default 5)
+	MaxDepth int
+
+	// Env holds additional KEY=VALUE pairs for the executed binary
+	Env []string
+}
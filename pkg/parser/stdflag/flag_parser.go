@@ -0,0 +1,260 @@
+package stdflag
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+)
+
+// FlagParser implements the Parser interface for the standard library flag package.
+//
+// A single *flag.FlagSet describes a command without subcommands. The common
+// hand-rolled dispatch pattern is described by a map[string]*flag.FlagSet keyed
+// by subcommand name; the "" key holds the global flags, and keys containing
+// spaces ("remote add") describe nested subcommands.
+type FlagParser struct{}
+
+// NewFlagParser creates a new standard library flag parser
+func NewFlagParser() *FlagParser {
+	return &FlagParser{}
+}
+
+// Name returns the parser name
+func (p *FlagParser) Name() string {
+	return "flag"
+}
+
+// Supports checks if the source is a *flag.FlagSet or map[string]*flag.FlagSet
+func (p *FlagParser) Supports(source interface{}) bool {
+	switch source.(type) {
+	case *flag.FlagSet, map[string]*flag.FlagSet:
+		return true
+	}
+	return false
+}
+
+// Parse extracts CLI structure from flag sets
+func (p *FlagParser) Parse(source interface{}) (*parser.ParsedCLI, error) {
+	var sets map[string]*flag.FlagSet
+	switch s := source.(type) {
+	case *flag.FlagSet:
+		if s == nil {
+			return nil, parser.ErrInvalidSource
+		}
+		sets = map[string]*flag.FlagSet{"": s}
+	case map[string]*flag.FlagSet:
+		if len(s) == 0 {
+			return nil, parser.ErrInvalidSource
+		}
+		sets = s
+	default:
+		return nil, &parser.ParserError{
+			Message: "source is not a *flag.FlagSet or map[string]*flag.FlagSet",
+			Cause:   parser.ErrInvalidSource,
+		}
+	}
+
+	parsed := &parser.ParsedCLI{
+		Commands:      make(map[string]*parser.CommandInfo),
+		FrameworkData: make(map[string]interface{}),
+	}
+
+	rootName := filepath.Base(os.Args[0])
+	if root, ok := sets[""]; ok && root != nil && root.Name() != "" {
+		rootName = root.Name()
+	}
+
+	rootInfo := newCommandInfo(rootName, nil)
+	parsed.RootCommand = rootInfo
+	parsed.Commands[rootInfo.Path] = rootInfo
+
+	// Sort keys so parents are created before their children
+	keys := make([]string, 0, len(sets))
+	for key := range sets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		info := rootInfo
+		for _, name := range strings.Fields(key) {
+			info = p.child(info, name, parsed.Commands)
+		}
+		if set := sets[key]; set != nil {
+			// Global flags are only accepted before the subcommand name, so
+			// they stay local to the root rather than being inherited
+			info.Flags = append(info.Flags, p.parseFlagSet(set)...)
+		}
+	}
+
+	for _, info := range parsed.Commands {
+		info.RunFunc = len(info.Subcommands) == 0
+	}
+
+	parsed.Metadata = &parser.CLIMetadata{
+		Name:      rootName,
+		Tags:      make([]parser.TagInfo, 0),
+		EnvVars:   make([]parser.EnvVarInfo, 0),
+		Platforms: make([]parser.PlatformInfo, 0),
+	}
+
+	parsed.FrameworkData["framework"] = "flag"
+
+	return parsed, nil
+}
+
+// child returns the named subcommand of info, creating it if needed
+func (p *FlagParser) child(info *parser.CommandInfo, name string, commands map[string]*parser.CommandInfo) *parser.CommandInfo {
+	for _, sub := range info.Subcommands {
+		if sub.Name == name {
+			return sub
+		}
+	}
+	sub := newCommandInfo(name, info)
+	info.Subcommands = append(info.Subcommands, sub)
+	commands[sub.Path] = sub
+	return sub
+}
+
+// parseFlagSet converts every flag of a set. Flags sharing one flag.Value,
+// the stdlib idiom for "-v" and "-verbose", are merged into a single flag
+// with a shorthand.
+func (p *FlagParser) parseFlagSet(set *flag.FlagSet) []*parser.FlagInfo {
+	groups := make([][]*flag.Flag, 0)
+	index := make(map[flag.Value]int)
+	set.VisitAll(func(f *flag.Flag) {
+		// Func values are not comparable and can never be shared
+		if reflect.TypeOf(f.Value).Comparable() {
+			if i, ok := index[f.Value]; ok {
+				groups[i] = append(groups[i], f)
+				return
+			}
+			index[f.Value] = len(groups)
+		}
+		groups = append(groups, []*flag.Flag{f})
+	})
+
+	flags := make([]*parser.FlagInfo, 0, len(groups))
+	for _, aliases := range groups {
+		flags = append(flags, p.parseFlag(aliases))
+	}
+	return flags
+}
+
+// parseFlag converts one or more aliases of the same flag to FlagInfo
+func (p *FlagParser) parseFlag(aliases []*flag.Flag) *parser.FlagInfo {
+	// The longest name is the primary one, the first single letter the shorthand
+	primary := aliases[0]
+	for _, f := range aliases[1:] {
+		if len(f.Name) > len(primary.Name) {
+			primary = f
+		}
+	}
+
+	placeholder, usage := flag.UnquoteUsage(primary)
+	flagType, goType := flagType(primary.Value)
+	info := &parser.FlagInfo{
+		Name:         primary.Name,
+		Usage:        usage,
+		Type:         flagType,
+		DefaultValue: primary.DefValue,
+		Annotations:  make(map[string]string),
+	}
+	if goType != "" {
+		info.Annotations["goType"] = goType
+	}
+
+	for _, f := range aliases {
+		switch {
+		case f == primary:
+		case info.Shorthand == "" && len(f.Name) == 1:
+			info.Shorthand = f.Name
+		default:
+			info.Aliases = append(info.Aliases, f.Name)
+		}
+	}
+	if placeholder != "" && placeholder != "value" {
+		info.Annotations["placeholder"] = placeholder
+	}
+
+	return info
+}
+
+// flagType determines the flag type from the concrete flag.Value. Values
+// whose Go type has no pflag equivalent are reported as strings together
+// with the Go type name.
+func flagType(value flag.Value) (string, string) {
+	if b, ok := value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return "bool", ""
+	}
+
+	getter, ok := value.(flag.Getter)
+	if !ok {
+		return "string", goTypeName(value)
+	}
+
+	switch v := getter.Get().(type) {
+	case bool:
+		return "bool", ""
+	case int:
+		return "int", ""
+	case int64:
+		return "int64", ""
+	case uint:
+		return "uint", ""
+	case uint64:
+		return "uint64", ""
+	case float64:
+		return "float64", ""
+	case time.Duration:
+		return "duration", ""
+	case string:
+		return "string", ""
+	case []string:
+		return "stringSlice", ""
+	case nil:
+		return "string", ""
+	default:
+		return "string", goTypeName(v)
+	}
+}
+
+// goTypeName returns the Go type of a user-defined value, or "" for the
+// flag package's own unexported values such as flag.Func
+func goTypeName(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.PkgPath() == "flag" {
+		return ""
+	}
+	return reflect.TypeOf(v).String()
+}
+
+// newCommandInfo creates an empty CommandInfo below parent
+func newCommandInfo(name string, parent *parser.CommandInfo) *parser.CommandInfo {
+	path := name
+	if parent != nil {
+		path = parent.Path + "/" + name
+	}
+	return &parser.CommandInfo{
+		Name:            name,
+		Path:            path,
+		Use:             name,
+		Parent:          parent,
+		Subcommands:     make([]*parser.CommandInfo, 0),
+		Flags:           make([]*parser.FlagInfo, 0),
+		Args:            make([]*parser.ArgumentInfo, 0),
+		PersistentFlags: make([]*parser.FlagInfo, 0),
+		Annotations:     make(map[string]string),
+		Tags:            make([]string, 0),
+		Extensions:      make(map[string]interface{}),
+	}
+}
@@ -0,0 +1,201 @@
+package stdflag
+
+import (
+	"flag"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+)
+
+func TestFlagParser_Name(t *testing.T) {
+	parser := NewFlagParser()
+	if parser.Name() != "flag" {
+		t.Errorf("Expected parser name 'flag', got '%s'", parser.Name())
+	}
+}
+
+func TestFlagParser_Supports(t *testing.T) {
+	parser := NewFlagParser()
+
+	tests := []struct {
+		name     string
+		source   interface{}
+		expected bool
+	}{
+		{
+			name:     "Valid FlagSet",
+			source:   flag.NewFlagSet("app", flag.ContinueOnError),
+			expected: true,
+		},
+		{
+			name:     "Valid FlagSet map",
+			source:   map[string]*flag.FlagSet{},
+			expected: true,
+		},
+		{
+			name:     "Invalid type - string",
+			source:   "not a flag set",
+			expected: false,
+		},
+		{
+			name:     "Invalid type - nil",
+			source:   nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parser.Supports(tt.source)
+			if result != tt.expected {
+				t.Errorf("Expected Supports() = %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func findFlag(flags []*parser.FlagInfo, name string) *parser.FlagInfo {
+	for _, flag := range flags {
+		if flag.Name == name {
+			return flag
+		}
+	}
+	return nil
+}
+
+func TestFlagParser_ParseFlagSet(t *testing.T) {
+	p := NewFlagParser()
+
+	fs := flag.NewFlagSet("testapp", flag.ContinueOnError)
+	var verbose bool
+	fs.BoolVar(&verbose, "verbose", false, "Enable verbose output")
+	fs.BoolVar(&verbose, "v", false, "Enable verbose output (shorthand)")
+	fs.BoolVar(&verbose, "verb", false, "Enable verbose output (alias)")
+	fs.String("config", "app.yaml", "Read configuration from `path`")
+	fs.Int("workers", 4, "Number of workers")
+	fs.Int64("limit", 0, "Limit")
+	fs.Uint("retries", 3, "Retries")
+	fs.Uint64("size", 0, "Size")
+	fs.Float64("ratio", 0.5, "Ratio")
+	fs.Duration("timeout", 30*time.Second, "Timeout")
+	fs.Func("tag", "Add a tag", func(string) error { return nil })
+	fs.TextVar(&net.IP{}, "addr", net.IPv4(127, 0, 0, 1), "Listen address")
+
+	parsed, err := p.Parse(fs)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	root := parsed.RootCommand
+	if root.Name != "testapp" || !root.RunFunc {
+		t.Errorf("Unexpected root command: %+v", root)
+	}
+	if len(root.Flags) != 10 {
+		t.Fatalf("Expected 10 flags after merging aliases, got %d", len(root.Flags))
+	}
+
+	verboseFlag := findFlag(root.Flags, "verbose")
+	if verboseFlag == nil || verboseFlag.Shorthand != "v" || verboseFlag.Type != "bool" {
+		t.Errorf("Expected verbose flag with shorthand v, got %+v", verboseFlag)
+	} else if len(verboseFlag.Aliases) != 1 || verboseFlag.Aliases[0] != "verb" {
+		t.Errorf("Expected verbose flag with alias verb, got %v", verboseFlag.Aliases)
+	}
+
+	config := findFlag(root.Flags, "config")
+	if config == nil || config.DefaultValue != "app.yaml" || config.Annotations["placeholder"] != "path" {
+		t.Errorf("Unexpected config flag: %+v", config)
+	}
+	if config.Usage != "Read configuration from path" {
+		t.Errorf("Expected unquoted usage, got %q", config.Usage)
+	}
+
+	types := map[string]string{
+		"workers": "int",
+		"limit":   "int64",
+		"retries": "uint",
+		"size":    "uint64",
+		"ratio":   "float64",
+		"timeout": "duration",
+		"tag":     "string",
+		"addr":    "string",
+	}
+	for name, want := range types {
+		if f := findFlag(root.Flags, name); f == nil || f.Type != want {
+			t.Errorf("Expected flag %s of type %s, got %+v", name, want, f)
+		}
+	}
+	if f := findFlag(root.Flags, "tag"); f.Annotations["goType"] != "" {
+		t.Errorf("Expected no goType for flag.Func, got %q", f.Annotations["goType"])
+	}
+	if f := findFlag(root.Flags, "addr"); f.Annotations["goType"] != "*net.IP" {
+		t.Errorf("Expected goType *net.IP, got %q", f.Annotations["goType"])
+	}
+}
+
+func TestFlagParser_ParseSubcommands(t *testing.T) {
+	p := NewFlagParser()
+
+	global := flag.NewFlagSet("git", flag.ExitOnError)
+	global.Bool("debug", false, "Debug output")
+
+	clone := flag.NewFlagSet("clone", flag.ExitOnError)
+	clone.Int("depth", 0, "Clone depth")
+
+	remoteAdd := flag.NewFlagSet("remote add", flag.ExitOnError)
+	remoteAdd.Bool("fetch", false, "Fetch after adding")
+
+	parsed, err := p.Parse(map[string]*flag.FlagSet{
+		"":           global,
+		"clone":      clone,
+		"remote add": remoteAdd,
+	})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	root := parsed.RootCommand
+	if root.Name != "git" || root.RunFunc {
+		t.Errorf("Unexpected root command: %+v", root)
+	}
+	if len(root.Subcommands) != 2 {
+		t.Errorf("Expected 2 subcommands, got %d", len(root.Subcommands))
+	}
+	// Global flags only apply before the subcommand, so they are not inherited
+	if f := findFlag(root.Flags, "debug"); f == nil || f.Persistent || len(root.PersistentFlags) != 0 {
+		t.Errorf("Expected local debug flag, got %+v", f)
+	}
+
+	if len(parsed.Commands) != 4 {
+		t.Errorf("Expected 4 commands in map, got %d", len(parsed.Commands))
+	}
+
+	remote := parsed.Commands["git/remote"]
+	if remote == nil || remote.RunFunc || len(remote.Flags) != 0 {
+		t.Fatalf("Expected intermediate command git/remote, got %+v", remote)
+	}
+
+	add := parsed.Commands["git/remote/add"]
+	if add == nil || !add.RunFunc || add.Parent != remote {
+		t.Fatalf("Expected command git/remote/add, got %+v", add)
+	}
+	if f := findFlag(add.Flags, "fetch"); f == nil || f.Type != "bool" {
+		t.Errorf("Expected fetch flag, got %+v", f)
+	}
+
+	if f := findFlag(parsed.Commands["git/clone"].Flags, "depth"); f == nil || f.Type != "int" || f.DefaultValue != "0" {
+		t.Errorf("Expected depth flag, got %+v", f)
+	}
+}
+
+func TestFlagParser_ParseInvalid(t *testing.T) {
+	p := NewFlagParser()
+
+	if _, err := p.Parse(map[string]*flag.FlagSet{}); err == nil {
+		t.Error("Expected error for empty map")
+	}
+	if _, err := p.Parse("invalid"); err == nil {
+		t.Error("Expected error for invalid source")
+	}
+}
@@ -0,0 +1,245 @@
+package urfave
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	cliv2 "github.com/urfave/cli/v2"
+	cliv3 "github.com/urfave/cli/v3"
+)
+
+// UrfaveParser implements the Parser interface for urfave/cli v2 and v3
+type UrfaveParser struct{}
+
+// NewUrfaveParser creates a new urfave/cli parser
+func NewUrfaveParser() *UrfaveParser {
+	return &UrfaveParser{}
+}
+
+// Name returns the parser name
+func (p *UrfaveParser) Name() string {
+	return "urfave-cli"
+}
+
+// Supports checks if the source is a v2 *cli.App or a v3 *cli.Command
+func (p *UrfaveParser) Supports(source interface{}) bool {
+	switch source.(type) {
+	case *cliv2.App, *cliv3.Command:
+		return true
+	}
+	return false
+}
+
+// Parse extracts CLI structure from a urfave/cli application
+func (p *UrfaveParser) Parse(source interface{}) (*parser.ParsedCLI, error) {
+	parsed := &parser.ParsedCLI{
+		Commands:      make(map[string]*parser.CommandInfo),
+		FrameworkData: make(map[string]interface{}),
+	}
+	parsed.FrameworkData["framework"] = "urfave-cli"
+
+	switch app := source.(type) {
+	case *cliv2.App:
+		if app == nil {
+			return nil, parser.ErrInvalidSource
+		}
+		parsed.RootCommand = p.parseV2App(app, parsed.Commands)
+		parsed.Metadata = p.extractV2Metadata(app)
+		parsed.FrameworkData["version"] = "v2"
+		if app.Copyright != "" {
+			parsed.FrameworkData["copyright"] = app.Copyright
+		}
+	case *cliv3.Command:
+		if app == nil {
+			return nil, parser.ErrInvalidSource
+		}
+		parsed.RootCommand = p.parseV3Command(app, nil, parsed.Commands)
+		parsed.Metadata = p.extractV3Metadata(app)
+		parsed.FrameworkData["version"] = "v3"
+		if app.Copyright != "" {
+			parsed.FrameworkData["copyright"] = app.Copyright
+		}
+	default:
+		return nil, &parser.ParserError{
+			Message: "source is not a urfave/cli v2 *cli.App or v3 *cli.Command",
+			Cause:   parser.ErrInvalidSource,
+		}
+	}
+
+	parser.CollectMetadata(parsed)
+
+	return parsed, nil
+}
+
+// newCommandInfo creates CommandInfo with the fields shared by both versions
+func newCommandInfo(name string, parent *parser.CommandInfo, argsUsage string) *parser.CommandInfo {
+	path := name
+	if parent != nil {
+		path = parent.Path + "/" + name
+	}
+
+	use := name
+	if argsUsage != "" {
+		use += " " + argsUsage
+	}
+
+	return &parser.CommandInfo{
+		Name:            name,
+		Path:            path,
+		Use:             use,
+		Parent:          parent,
+		Subcommands:     make([]*parser.CommandInfo, 0),
+		Flags:           make([]*parser.FlagInfo, 0),
+		Args:            make([]*parser.ArgumentInfo, 0),
+		PersistentFlags: make([]*parser.FlagInfo, 0),
+		Annotations:     make(map[string]string),
+		Tags:            make([]string, 0),
+		Extensions:      make(map[string]interface{}),
+	}
+}
+
+// docFlag is the documentation interface both versions implement for built-in flags
+type docFlag interface {
+	Names() []string
+	TakesValue() bool
+	GetUsage() string
+	GetValue() string
+	GetEnvVars() []string
+}
+
+type requiredFlag interface {
+	IsRequired() bool
+}
+
+type visibleFlag interface {
+	IsVisible() bool
+}
+
+type categorizableFlag interface {
+	GetCategory() string
+}
+
+// parseFlag converts a urfave flag to FlagInfo
+func parseFlag(flag interface{}, names []string, flagType string, persistent bool) *parser.FlagInfo {
+	info := &parser.FlagInfo{
+		Type:        flagType,
+		Persistent:  persistent,
+		Annotations: make(map[string]string),
+	}
+	if len(names) == 0 {
+		return info
+	}
+	info.Name = names[0]
+
+	// The first single-letter alias becomes the shorthand
+	for _, alias := range names[1:] {
+		if info.Shorthand == "" && len([]rune(alias)) == 1 {
+			info.Shorthand = alias
+			continue
+		}
+		info.Aliases = append(info.Aliases, alias)
+	}
+
+	if f, ok := flag.(docFlag); ok {
+		info.Usage = f.GetUsage()
+		// Prefer the raw default for scalars; GetValue quotes strings in v2
+		value := fieldValue(flag, "Value")
+		defaultText, _ := fieldValue(flag, "DefaultText").(string)
+		switch {
+		case defaultText != "":
+			info.DefaultValue = defaultText
+		case value != nil && isScalar(reflect.TypeOf(value)):
+			info.DefaultValue = fmt.Sprint(value)
+		default:
+			info.DefaultValue = f.GetValue()
+		}
+		if envVars := f.GetEnvVars(); len(envVars) > 0 {
+			info.Annotations["envVars"] = strings.Join(envVars, ",")
+		}
+	}
+	if f, ok := flag.(requiredFlag); ok {
+		info.Required = f.IsRequired()
+	}
+	if f, ok := flag.(visibleFlag); ok {
+		info.Hidden = !f.IsVisible()
+	}
+	if f, ok := flag.(categorizableFlag); ok && f.GetCategory() != "" {
+		info.Annotations["category"] = f.GetCategory()
+	}
+
+	return info
+}
+
+// parseArgsUsage derives positional arguments from an ArgsUsage string such
+// as "<source> [destination] [files...]": angle brackets and bare words are
+// required, square brackets optional and a trailing ellipsis repeatable.
+func parseArgsUsage(usage string) []*parser.ArgumentInfo {
+	args := make([]*parser.ArgumentInfo, 0)
+	for i, token := range strings.Fields(usage) {
+		required := !strings.HasPrefix(token, "[")
+		variadic := strings.Contains(token, "...")
+		name := strings.Trim(token, "[]<>.")
+		if name == "" {
+			continue
+		}
+
+		arg := &parser.ArgumentInfo{
+			Name:     name,
+			Position: i + 1,
+			Required: required,
+			Type:     "string",
+			MaxArgs:  1,
+		}
+		if required {
+			arg.MinArgs = 1
+		}
+		if variadic {
+			arg.MaxArgs = -1
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+// isScalar reports whether values of t print as plain default values
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// fieldValue returns an exported struct field of a flag or argument, if present
+func fieldValue(v interface{}, name string) interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	field := rv.FieldByName(name)
+	if !field.IsValid() || !field.CanInterface() {
+		return nil
+	}
+	return field.Interface()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
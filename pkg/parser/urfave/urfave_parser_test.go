@@ -0,0 +1,280 @@
+package urfave
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harihs-330/gospec-cli/pkg/converter"
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	cliv2 "github.com/urfave/cli/v2"
+	cliv3 "github.com/urfave/cli/v3"
+)
+
+func TestUrfaveParser_Name(t *testing.T) {
+	parser := NewUrfaveParser()
+	if parser.Name() != "urfave-cli" {
+		t.Errorf("Expected parser name 'urfave-cli', got '%s'", parser.Name())
+	}
+}
+
+func TestUrfaveParser_Supports(t *testing.T) {
+	parser := NewUrfaveParser()
+
+	tests := []struct {
+		name     string
+		source   interface{}
+		expected bool
+	}{
+		{
+			name:     "Valid v2 App",
+			source:   &cliv2.App{},
+			expected: true,
+		},
+		{
+			name:     "Valid v3 Command",
+			source:   &cliv3.Command{},
+			expected: true,
+		},
+		{
+			name:     "Invalid type - v2 Command",
+			source:   &cliv2.Command{},
+			expected: false,
+		},
+		{
+			name:     "Invalid type - string",
+			source:   "not a command",
+			expected: false,
+		},
+		{
+			name:     "Invalid type - nil",
+			source:   nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parser.Supports(tt.source)
+			if result != tt.expected {
+				t.Errorf("Expected Supports() = %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func findFlag(flags []*parser.FlagInfo, name string) *parser.FlagInfo {
+	for _, flag := range flags {
+		if flag.Name == name {
+			return flag
+		}
+	}
+	return nil
+}
+
+func TestUrfaveParser_ParseV2(t *testing.T) {
+	p := NewUrfaveParser()
+
+	app := &cliv2.App{
+		Name:        "testapp",
+		Usage:       "A test application",
+		Description: "This is a test application for unit testing",
+		Version:     "1.0.0",
+		Authors:     []*cliv2.Author{{Name: "Jane", Email: "jane@example.com"}},
+		Flags: []cliv2.Flag{
+			&cliv2.BoolFlag{Name: "verbose", Aliases: []string{"v"}, Usage: "Enable verbose output"},
+			&cliv2.StringFlag{Name: "config", Aliases: []string{"cfg", "c"}, Usage: "Config file path", EnvVars: []string{"TESTAPP_CONFIG"}},
+		},
+		Commands: []*cliv2.Command{
+			{
+				Name:     "user",
+				Usage:    "User management",
+				Category: "accounts",
+				Subcommands: []*cliv2.Command{
+					{
+						Name:      "create",
+						Aliases:   []string{"add"},
+						Usage:     "Create a user",
+						ArgsUsage: "<username> [groups...]",
+						Action:    func(*cliv2.Context) error { return nil },
+						Flags: []cliv2.Flag{
+							&cliv2.IntSliceFlag{Name: "ids", Usage: "User IDs"},
+							&cliv2.TimestampFlag{Name: "expires", Usage: "Expiry", Layout: time.RFC3339},
+							&cliv2.StringFlag{Name: "role", Usage: "Role", Required: true, Value: "member"},
+							&cliv2.StringFlag{Name: "debug", Usage: "Debug", Hidden: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	parsed, err := p.Parse(app)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if parsed.RootCommand.Name != "testapp" || parsed.RootCommand.Version != "1.0.0" {
+		t.Errorf("Unexpected root command %q version %q", parsed.RootCommand.Name, parsed.RootCommand.Version)
+	}
+	// Global flags only apply before the subcommand, so they are not inherited
+	if len(parsed.RootCommand.Flags) != 2 || len(parsed.RootCommand.PersistentFlags) != 0 {
+		t.Errorf("Expected 2 local global flags, got %d local and %d persistent",
+			len(parsed.RootCommand.Flags), len(parsed.RootCommand.PersistentFlags))
+	}
+	if len(parsed.Commands) != 3 {
+		t.Errorf("Expected 3 commands in map, got %d", len(parsed.Commands))
+	}
+
+	config := findFlag(parsed.RootCommand.Flags, "config")
+	if config == nil || config.Shorthand != "c" || len(config.Aliases) != 1 || config.Aliases[0] != "cfg" {
+		t.Fatalf("Unexpected config flag: %+v", config)
+	}
+
+	s, err := converter.NewDefaultConverter().Convert(parsed, nil)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	var alias []string
+	for _, param := range s.Commands["testapp"].Parameters {
+		if param.Name == "config" {
+			alias = param.Alias
+		}
+	}
+	if len(alias) != 2 || alias[0] != "c" || alias[1] != "cfg" {
+		t.Errorf("Expected --config aliases [c cfg] in the spec, got %v", alias)
+	}
+
+	create := parsed.Commands["testapp/user/create"]
+	if create == nil {
+		t.Fatal("Expected command testapp/user/create")
+	}
+	if !create.RunFunc || create.Aliases[0] != "add" {
+		t.Errorf("Unexpected create command: %+v", create)
+	}
+	if len(create.Args) != 2 || !create.Args[0].Required || create.Args[1].Required || create.Args[1].MaxArgs != -1 {
+		t.Errorf("Unexpected args from ArgsUsage: %+v %+v", create.Args[0], create.Args[1])
+	}
+
+	if f := findFlag(create.Flags, "ids"); f == nil || f.Type != "intSlice" {
+		t.Errorf("Expected intSlice flag, got %+v", f)
+	}
+	if f := findFlag(create.Flags, "expires"); f == nil || f.Type != "timestamp" {
+		t.Errorf("Expected timestamp flag, got %+v", f)
+	}
+	if f := findFlag(create.Flags, "role"); f == nil || !f.Required || f.DefaultValue != "member" {
+		t.Errorf("Expected required role flag, got %+v", f)
+	}
+	if f := findFlag(create.Flags, "debug"); f == nil || !f.Hidden {
+		t.Errorf("Expected hidden debug flag, got %+v", f)
+	}
+
+	user := parsed.Commands["testapp/user"]
+	if len(user.Tags) != 1 || user.Tags[0] != "accounts" {
+		t.Errorf("Expected category tag, got %v", user.Tags)
+	}
+
+	if len(parsed.Metadata.EnvVars) != 1 || parsed.Metadata.EnvVars[0].Name != "TESTAPP_CONFIG" {
+		t.Errorf("Expected TESTAPP_CONFIG env var, got %+v", parsed.Metadata.EnvVars)
+	}
+	if len(parsed.Metadata.Tags) != 1 || parsed.Metadata.Tags[0].Name != "accounts" {
+		t.Errorf("Expected accounts tag, got %+v", parsed.Metadata.Tags)
+	}
+	if parsed.Metadata.Author != "Jane <jane@example.com>" {
+		t.Errorf("Unexpected author %q", parsed.Metadata.Author)
+	}
+}
+
+func TestUrfaveParser_ParseV3(t *testing.T) {
+	p := NewUrfaveParser()
+
+	root := &cliv3.Command{
+		Name:    "testapp",
+		Usage:   "A test application",
+		Version: "1.0.0",
+		Flags: []cliv3.Flag{
+			&cliv3.BoolFlag{Name: "verbose", Aliases: []string{"v"}, Usage: "Enable verbose output"},
+			&cliv3.StringFlag{Name: "token", Usage: "API token", Local: true, Sources: cliv3.EnvVars("TESTAPP_TOKEN")},
+		},
+		Commands: []*cliv3.Command{
+			{
+				Name:     "deploy",
+				Usage:    "Deploy a release",
+				Category: "release",
+				Hidden:   true,
+				Arguments: []cliv3.Argument{
+					&cliv3.StringArg{Name: "env"},
+					&cliv3.StringArgs{Name: "services", Min: 1, Max: -1},
+				},
+				Action: func(context.Context, *cliv3.Command) error { return nil },
+				Flags: []cliv3.Flag{
+					&cliv3.DurationFlag{Name: "timeout", Value: 5 * time.Second},
+					&cliv3.StringSliceFlag{Name: "label"},
+					&cliv3.StringMapFlag{Name: "set"},
+					&cliv3.IntFlag{Name: "replicas", Required: true},
+				},
+			},
+		},
+	}
+
+	parsed, err := p.Parse(root)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if f := findFlag(parsed.RootCommand.PersistentFlags, "verbose"); f == nil || f.Shorthand != "v" || f.Type != "bool" {
+		t.Errorf("Expected persistent verbose flag, got %+v", f)
+	}
+	if f := findFlag(parsed.RootCommand.Flags, "token"); f == nil || f.Annotations["envVars"] != "TESTAPP_TOKEN" {
+		t.Errorf("Expected local token flag with env var, got %+v", f)
+	}
+
+	deploy := parsed.Commands["testapp/deploy"]
+	if deploy == nil {
+		t.Fatal("Expected command testapp/deploy")
+	}
+	if !deploy.Hidden || !deploy.RunFunc || deploy.Tags[0] != "release" {
+		t.Errorf("Unexpected deploy command: %+v", deploy)
+	}
+	if len(deploy.Args) != 2 || deploy.Args[0].Required || !deploy.Args[1].Required || deploy.Args[1].MaxArgs != -1 {
+		t.Errorf("Unexpected arguments: %+v %+v", deploy.Args[0], deploy.Args[1])
+	}
+
+	types := map[string]string{
+		"timeout":  "duration",
+		"label":    "stringSlice",
+		"set":      "stringToString",
+		"replicas": "int",
+	}
+	for name, want := range types {
+		if f := findFlag(deploy.Flags, name); f == nil || f.Type != want {
+			t.Errorf("Expected flag %s of type %s, got %+v", name, want, f)
+		}
+	}
+	if f := findFlag(deploy.Flags, "timeout"); f.DefaultValue != "5s" {
+		t.Errorf("Expected default 5s, got %v", f.DefaultValue)
+	}
+	if f := findFlag(deploy.Flags, "replicas"); !f.Required {
+		t.Error("Expected replicas to be required")
+	}
+
+	if parsed.FrameworkData["version"] != "v3" {
+		t.Errorf("Expected framework version v3, got %v", parsed.FrameworkData["version"])
+	}
+}
+
+func TestParseArgsUsage(t *testing.T) {
+	args := parseArgsUsage("<src> dst [opts...]")
+	if len(args) != 3 {
+		t.Fatalf("Expected 3 args, got %d", len(args))
+	}
+	if !args[0].Required || args[0].Name != "src" {
+		t.Errorf("Unexpected first arg: %+v", args[0])
+	}
+	if !args[1].Required || args[1].Position != 2 {
+		t.Errorf("Unexpected second arg: %+v", args[1])
+	}
+	if args[2].Required || args[2].MaxArgs != -1 || args[2].Name != "opts" {
+		t.Errorf("Unexpected third arg: %+v", args[2])
+	}
+}
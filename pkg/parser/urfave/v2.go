@@ -0,0 +1,110 @@
+package urfave
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	cliv2 "github.com/urfave/cli/v2"
+)
+
+// parseV2App converts a v2 *cli.App into the root CommandInfo
+func (p *UrfaveParser) parseV2App(app *cliv2.App, commands map[string]*parser.CommandInfo) *parser.CommandInfo {
+	info := newCommandInfo(app.Name, nil, app.ArgsUsage)
+	info.Short = app.Usage
+	info.Long = app.Description
+	info.Version = app.Version
+	info.RunFunc = app.Action != nil
+	info.Args = parseArgsUsage(app.ArgsUsage)
+	if app.UsageText != "" {
+		info.Extensions["urfave_usage_text"] = app.UsageText
+	}
+	if app.DefaultCommand != "" {
+		info.Extensions["urfave_default_command"] = app.DefaultCommand
+	}
+	commands[info.Path] = info
+
+	// App flags are global options that only apply before the subcommand,
+	// so they are local to the root rather than inherited by subcommands
+	for _, flag := range app.Flags {
+		info.Flags = append(info.Flags, p.parseV2Flag(flag, false))
+	}
+
+	for _, sub := range app.Commands {
+		subInfo := p.parseV2Command(sub, info, commands)
+		info.Subcommands = append(info.Subcommands, subInfo)
+	}
+
+	return info
+}
+
+// parseV2Command converts a v2 *cli.Command and its subcommands
+func (p *UrfaveParser) parseV2Command(cmd *cliv2.Command, parent *parser.CommandInfo, commands map[string]*parser.CommandInfo) *parser.CommandInfo {
+	info := newCommandInfo(cmd.Name, parent, cmd.ArgsUsage)
+	info.Short = cmd.Usage
+	info.Long = cmd.Description
+	info.Aliases = cmd.Aliases
+	info.Hidden = cmd.Hidden
+	info.RunFunc = cmd.Action != nil
+	info.Args = parseArgsUsage(cmd.ArgsUsage)
+	if cmd.Category != "" {
+		info.Tags = append(info.Tags, cmd.Category)
+	}
+	if cmd.UsageText != "" {
+		info.Extensions["urfave_usage_text"] = cmd.UsageText
+	}
+	if cmd.SkipFlagParsing {
+		info.Extensions["urfave_skip_flag_parsing"] = true
+	}
+	commands[info.Path] = info
+
+	for _, flag := range cmd.Flags {
+		info.Flags = append(info.Flags, p.parseV2Flag(flag, false))
+	}
+
+	for _, sub := range cmd.Subcommands {
+		subInfo := p.parseV2Command(sub, info, commands)
+		info.Subcommands = append(info.Subcommands, subInfo)
+	}
+
+	return info
+}
+
+// parseV2Flag converts a v2 flag; its type comes from the flag's struct name
+func (p *UrfaveParser) parseV2Flag(flag cliv2.Flag, persistent bool) *parser.FlagInfo {
+	flagType := "string"
+	if t := reflect.TypeOf(flag); t != nil {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if name := strings.TrimSuffix(t.Name(), "Flag"); name != "" && name != t.Name() {
+			flagType = lowerFirst(name)
+		}
+	}
+	return parseFlag(flag, flag.Names(), flagType, persistent)
+}
+
+// extractV2Metadata extracts global CLI metadata from a v2 app
+func (p *UrfaveParser) extractV2Metadata(app *cliv2.App) *parser.CLIMetadata {
+	metadata := &parser.CLIMetadata{
+		Name:        app.Name,
+		Version:     app.Version,
+		Description: app.Description,
+		Tags:        make([]parser.TagInfo, 0),
+		EnvVars:     make([]parser.EnvVarInfo, 0),
+		Platforms:   make([]parser.PlatformInfo, 0),
+	}
+	if metadata.Description == "" {
+		metadata.Description = app.Usage
+	}
+
+	authors := make([]string, 0, len(app.Authors))
+	for _, author := range app.Authors {
+		if author != nil {
+			authors = append(authors, author.String())
+		}
+	}
+	metadata.Author = strings.Join(authors, ", ")
+
+	return metadata
+}
@@ -0,0 +1,137 @@
+package urfave
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	cliv3 "github.com/urfave/cli/v3"
+)
+
+type localFlag interface {
+	IsLocal() bool
+}
+
+// parseV3Command converts a v3 *cli.Command and its subcommands
+func (p *UrfaveParser) parseV3Command(cmd *cliv3.Command, parent *parser.CommandInfo, commands map[string]*parser.CommandInfo) *parser.CommandInfo {
+	info := newCommandInfo(cmd.Name, parent, cmd.ArgsUsage)
+	info.Short = cmd.Usage
+	info.Long = cmd.Description
+	info.Aliases = cmd.Aliases
+	info.Version = cmd.Version
+	info.Hidden = cmd.Hidden
+	info.RunFunc = cmd.Action != nil
+	if cmd.Category != "" {
+		info.Tags = append(info.Tags, cmd.Category)
+	}
+	if cmd.UsageText != "" {
+		info.Extensions["urfave_usage_text"] = cmd.UsageText
+	}
+	if cmd.DefaultCommand != "" {
+		info.Extensions["urfave_default_command"] = cmd.DefaultCommand
+	}
+	if cmd.SkipFlagParsing {
+		info.Extensions["urfave_skip_flag_parsing"] = true
+	}
+
+	if len(cmd.Arguments) > 0 {
+		info.Args = parseV3Arguments(cmd.Arguments)
+		if cmd.ArgsUsage == "" {
+			usages := make([]string, 0, len(cmd.Arguments))
+			for _, arg := range cmd.Arguments {
+				usages = append(usages, arg.Usage())
+			}
+			info.Use = strings.TrimSpace(info.Name + " " + strings.Join(usages, " "))
+		}
+	} else {
+		info.Args = parseArgsUsage(cmd.ArgsUsage)
+	}
+	commands[info.Path] = info
+
+	// v3 flags are inherited by subcommands unless marked Local
+	for _, flag := range cmd.Flags {
+		persistent := len(cmd.Commands) > 0
+		if f, ok := flag.(localFlag); ok && f.IsLocal() {
+			persistent = false
+		}
+		flagInfo := p.parseV3Flag(flag, persistent)
+		if persistent {
+			info.PersistentFlags = append(info.PersistentFlags, flagInfo)
+		} else {
+			info.Flags = append(info.Flags, flagInfo)
+		}
+	}
+
+	for _, sub := range cmd.Commands {
+		subInfo := p.parseV3Command(sub, info, commands)
+		info.Subcommands = append(info.Subcommands, subInfo)
+	}
+
+	return info
+}
+
+// parseV3Flag converts a v3 flag; its type comes from the flag's Value field
+func (p *UrfaveParser) parseV3Flag(flag cliv3.Flag, persistent bool) *parser.FlagInfo {
+	flagType := "string"
+	if value := fieldValue(flag, "Value"); value != nil {
+		flagType = parser.TypeName(reflect.TypeOf(value))
+	}
+	return parseFlag(flag, flag.Names(), flagType, persistent)
+}
+
+// parseV3Arguments converts declared v3 arguments. Single-value arguments are
+// optional; multi-value ones carry their own Min and Max.
+func parseV3Arguments(arguments []cliv3.Argument) []*parser.ArgumentInfo {
+	args := make([]*parser.ArgumentInfo, 0, len(arguments))
+	for i, argument := range arguments {
+		name, _ := fieldValue(argument, "Name").(string)
+		if name == "" {
+			name = argument.Usage()
+		}
+		description, _ := fieldValue(argument, "UsageText").(string)
+
+		arg := &parser.ArgumentInfo{
+			Name:        name,
+			Description: description,
+			Position:    i + 1,
+			Type:        "string",
+			MaxArgs:     1,
+		}
+		if value := fieldValue(argument, "Value"); value != nil {
+			arg.Type = parser.TypeName(reflect.TypeOf(value))
+		}
+		if min, ok := fieldValue(argument, "Min").(int); ok {
+			arg.MinArgs = min
+			arg.Required = min > 0
+		}
+		if max, ok := fieldValue(argument, "Max").(int); ok {
+			arg.MaxArgs = max
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+// extractV3Metadata extracts global CLI metadata from a v3 root command
+func (p *UrfaveParser) extractV3Metadata(cmd *cliv3.Command) *parser.CLIMetadata {
+	metadata := &parser.CLIMetadata{
+		Name:        cmd.Name,
+		Version:     cmd.Version,
+		Description: cmd.Description,
+		Tags:        make([]parser.TagInfo, 0),
+		EnvVars:     make([]parser.EnvVarInfo, 0),
+		Platforms:   make([]parser.PlatformInfo, 0),
+	}
+	if metadata.Description == "" {
+		metadata.Description = cmd.Usage
+	}
+
+	authors := make([]string, 0, len(cmd.Authors))
+	for _, author := range cmd.Authors {
+		authors = append(authors, fmt.Sprint(author))
+	}
+	metadata.Author = strings.Join(authors, ", ")
+
+	return metadata
+}
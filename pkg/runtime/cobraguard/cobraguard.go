@@ -0,0 +1,338 @@
+// Package cobraguard enforces an OpenCLI specification when a Cobra
+// application runs. Attached to the root command, it checks every
+// invocation against the constraints of the spec that Cobra itself does not
+// know about: enum values, patterns, numeric bounds, string lengths and the
+// number of positional arguments. Using a flag the spec marks deprecated
+// prints a warning.
+//
+//	//go:embed opencli.yaml
+//	var specData []byte
+//
+//	s, err := spec.Parse(specData)
+//	...
+//	guard, err := cobraguard.New(s, nil)
+//	...
+//	guard.Attach(rootCmd)
+package cobraguard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Options controls a Guard
+type Options struct {
+	// Warnings receives deprecation warnings. It defaults to the error
+	// output of the command being run.
+	Warnings io.Writer
+}
+
+// Guard validates invocations of a Cobra application against a spec
+type Guard struct {
+	opts     Options
+	commands map[string]spec.Command
+	// patterns holds the compiled Schema.Pattern of the spec
+	patterns map[string]*regexp.Regexp
+}
+
+// New returns a guard for the commands of s. It fails when a pattern of
+// the spec is not a valid regular expression.
+func New(s *spec.OpenCLISpec, opts *Options) (*Guard, error) {
+	g := &Guard{
+		commands: make(map[string]spec.Command),
+		patterns: make(map[string]*regexp.Regexp),
+	}
+	if opts != nil {
+		g.opts = *opts
+	}
+
+	for key, cmd := range s.Commands {
+		words := strings.Split(strings.Trim(key, "/"), "/")
+		// Commands are matched without the root name, which may differ
+		// from the name of the binary
+		g.commands[strings.Join(words[1:], " ")] = cmd
+		for _, p := range cmd.Parameters {
+			if err := g.compile(p.Schema); err != nil {
+				return nil, fmt.Errorf("command %q, parameter %q: %w", key, p.Name, err)
+			}
+		}
+	}
+	return g, nil
+}
+
+// compile compiles the patterns of schema and its items
+func (g *Guard) compile(schema *spec.Schema) error {
+	for ; schema != nil; schema = schema.Items {
+		if schema.Pattern == "" {
+			continue
+		}
+		if _, ok := g.patterns[schema.Pattern]; ok {
+			continue
+		}
+		re, err := regexp.Compile(schema.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", schema.Pattern, err)
+		}
+		g.patterns[schema.Pattern] = re
+	}
+	return nil
+}
+
+// Attach makes root validate every invocation before running it. An
+// existing PersistentPreRun or PersistentPreRunE of root runs after the
+// validation. Cobra only runs the persistent hook nearest to the executed
+// command, so the hooks of descendants are wrapped as well unless
+// cobra.EnableTraverseRunHooks is set.
+func (g *Guard) Attach(root *cobra.Command) {
+	g.wrap(root)
+	if cobra.EnableTraverseRunHooks {
+		return
+	}
+
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, child := range cmd.Commands() {
+			if child.PersistentPreRunE != nil || child.PersistentPreRun != nil {
+				g.wrap(child)
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+}
+
+// wrap installs the validation as the PersistentPreRunE of cmd
+func (g *Guard) wrap(cmd *cobra.Command) {
+	preRunE, preRun := cmd.PersistentPreRunE, cmd.PersistentPreRun
+	cmd.PersistentPreRun = nil
+	cmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
+		if err := g.Validate(c, args); err != nil {
+			return err
+		}
+		switch {
+		case preRunE != nil:
+			return preRunE(c, args)
+		case preRun != nil:
+			preRun(c, args)
+		}
+		return nil
+	}
+}
+
+// Validate checks the parsed flags and the positional arguments of cmd
+// against the spec. Commands missing from the spec are not checked.
+func (g *Guard) Validate(cmd *cobra.Command, args []string) error {
+	words := strings.Fields(cmd.CommandPath())[1:]
+	command, ok := g.commands[strings.Join(words, " ")]
+	if !ok {
+		return nil
+	}
+
+	warnings := g.opts.Warnings
+	if warnings == nil {
+		warnings = cmd.ErrOrStderr()
+	}
+
+	var errs []error
+	for _, p := range g.flags(words, command) {
+		flag := cmd.Flags().Lookup(p.Name)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+		// pflag already warns about flags marked deprecated in the binary
+		if p.Deprecated && flag.Deprecated == "" {
+			fmt.Fprintf(warnings, "Flag --%s has been deprecated\n", p.Name)
+		}
+		for _, value := range flagValues(flag) {
+			if err := g.check(p.Schema, value); err != nil {
+				errs = append(errs, fmt.Errorf("invalid argument %q for \"--%s\" flag: %w", value, p.Name, err))
+			}
+		}
+	}
+
+	if err := g.checkArgs(command, args, warnings); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// flags returns the flags of command and those its ancestors pass down
+func (g *Guard) flags(words []string, command spec.Command) []spec.Parameter {
+	seen := map[string]bool{}
+	flags := make([]spec.Parameter, 0, len(command.Parameters))
+	for _, p := range command.Parameters {
+		if p.In != "argument" {
+			seen[p.Name] = true
+			flags = append(flags, p)
+		}
+	}
+	for i := len(words) - 1; i >= 0; i-- {
+		for _, p := range g.commands[strings.Join(words[:i], " ")].Parameters {
+			if p.In == "argument" || seen[p.Name] || (p.Scope != "inherited" && p.Scope != "global") {
+				continue
+			}
+			seen[p.Name] = true
+			flags = append(flags, p)
+		}
+	}
+	return flags
+}
+
+// checkArgs checks the number and the values of the positional arguments.
+// Commands declaring no arguments in the spec are not checked.
+func (g *Guard) checkArgs(command spec.Command, args []string, warnings io.Writer) error {
+	params := make([]spec.Parameter, 0)
+	for _, p := range command.Parameters {
+		if p.In == "argument" {
+			params = append(params, p)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	sort.SliceStable(params, func(i, j int) bool { return params[i].Position < params[j].Position })
+
+	var errs []error
+	minArgs, maxArgs := 0, 0
+	i := 0
+	for _, p := range params {
+		lo, hi := argRange(p)
+		minArgs += lo
+		if hi < 0 || maxArgs < 0 {
+			maxArgs = -1
+		} else {
+			maxArgs += hi
+		}
+
+		n := 0
+		for ; (hi < 0 || n < hi) && i < len(args); n, i = n+1, i+1 {
+			if p.Deprecated && n == 0 {
+				fmt.Fprintf(warnings, "Argument %s has been deprecated\n", p.Name)
+			}
+			schema := p.Schema
+			if schema != nil && schema.Type == "array" && schema.Items != nil {
+				schema = schema.Items
+			}
+			if err := g.check(schema, args[i]); err != nil {
+				errs = append(errs, fmt.Errorf("invalid argument %q for %q: %w", args[i], p.Name, err))
+			}
+		}
+	}
+
+	switch {
+	case len(args) < minArgs:
+		errs = append([]error{fmt.Errorf("requires at least %d arg(s), only received %d", minArgs, len(args))}, errs...)
+	case maxArgs >= 0 && len(args) > maxArgs:
+		errs = append([]error{fmt.Errorf("accepts at most %d arg(s), received %d", maxArgs, len(args))}, errs...)
+	}
+	return errors.Join(errs...)
+}
+
+// argRange returns the minimum and maximum number of values of an
+// argument; the maximum is -1 when unlimited
+func argRange(p spec.Parameter) (int, int) {
+	lo, hi := 0, 1
+	if p.Arity != nil {
+		lo, hi = p.Arity.Min, -1
+		if p.Arity.Max != nil {
+			hi = *p.Arity.Max
+		}
+	}
+	if p.Required && lo == 0 {
+		lo = 1
+	}
+	return lo, hi
+}
+
+// flagValues returns the values given to a flag, one per element for
+// slices and one per entry for maps
+func flagValues(flag *pflag.Flag) []string {
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		return slice.GetSlice()
+	}
+	value := flag.Value.String()
+	if strings.HasPrefix(flag.Value.Type(), "stringTo") {
+		// Map values are printed as "[k1=v1,k2=v2]"; only values are checked
+		values := make([]string, 0)
+		for _, entry := range strings.Split(strings.Trim(value, "[]"), ",") {
+			if _, v, ok := strings.Cut(entry, "="); ok {
+				values = append(values, v)
+			}
+		}
+		return values
+	}
+	return []string{value}
+}
+
+// check checks a single value against schema. The constraints of an
+// array schema apply to its items, and those of a map to its values.
+func (g *Guard) check(schema *spec.Schema, value string) error {
+	if schema == nil {
+		return nil
+	}
+	if schema.Type == "object" && schema.AdditionalProperties != nil {
+		return g.check(schema.AdditionalProperties, value)
+	}
+	if schema.Type == "array" || schema.Type == "object" {
+		if schema.Items != nil {
+			return g.check(schema.Items, value)
+		}
+		if schema.Type == "object" {
+			return nil
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		allowed := make([]string, len(schema.Enum))
+		found := false
+		for i, v := range schema.Enum {
+			allowed[i] = fmt.Sprint(v)
+			found = found || allowed[i] == value
+		}
+		if !found {
+			return fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+		}
+	}
+
+	if schema.Pattern != "" && !g.patterns[schema.Pattern].MatchString(value) {
+		return fmt.Errorf("must match %s", schema.Pattern)
+	}
+
+	length := utf8.RuneCountInString(value)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		return fmt.Errorf("must be at least %d character(s) long", *schema.MinLength)
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		return fmt.Errorf("must be at most %d character(s) long", *schema.MaxLength)
+	}
+
+	if schema.Minimum != nil || schema.Maximum != nil {
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(n) {
+			return errors.New("must be a number")
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			return fmt.Errorf("must be at least %s", formatNumber(*schema.Minimum))
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			return fmt.Errorf("must be at most %s", formatNumber(*schema.Maximum))
+		}
+	}
+	return nil
+}
+
+// formatNumber formats a bound without a needless fraction
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -0,0 +1,196 @@
+package cobraguard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+	"github.com/spf13/cobra"
+)
+
+const testSpec = `
+opencli: 1.0.0
+info:
+  title: app
+  version: 1.0.0
+commands:
+  app:
+    parameters:
+      - name: region
+        in: flag
+        scope: inherited
+        schema:
+          type: string
+          pattern: "^[a-z]{2}-[a-z]+$"
+  /app/create:
+    parameters:
+      - name: role
+        in: flag
+        schema:
+          type: string
+          enum: [member, admin]
+      - name: replicas
+        in: flag
+        schema:
+          type: integer
+          minimum: 1
+          maximum: 5
+      - name: tag
+        in: flag
+        schema:
+          type: array
+          items:
+            type: string
+            maxLength: 3
+      - name: label
+        in: flag
+        schema:
+          type: object
+          additionalProperties:
+            type: string
+            enum: [low, high]
+      - name: legacy
+        in: flag
+        deprecated: true
+        schema:
+          type: boolean
+      - name: name
+        in: argument
+        position: 1
+        required: true
+        schema:
+          type: string
+          minLength: 2
+      - name: extra
+        in: argument
+        position: 2
+        arity:
+          min: 0
+          max: 2
+`
+
+// newApp returns a CLI which the spec above describes, without a guard
+func newApp(t *testing.T, stderr *bytes.Buffer) (*cobra.Command, *bool) {
+	t.Helper()
+	ran := false
+	// The binary is named differently from the spec's root on purpose
+	root := &cobra.Command{Use: "myapp", SilenceUsage: true, SilenceErrors: true}
+	root.PersistentFlags().String("region", "eu-west", "")
+	create := &cobra.Command{
+		Use: "create",
+		Run: func(cmd *cobra.Command, args []string) { ran = true },
+	}
+	create.Flags().String("role", "member", "")
+	create.Flags().Int("replicas", 1, "")
+	create.Flags().StringSlice("tag", nil, "")
+	create.Flags().StringToString("label", nil, "")
+	create.Flags().Bool("legacy", false, "")
+	root.AddCommand(create)
+	root.SetErr(stderr)
+	root.SetOut(stderr)
+
+	return root, &ran
+}
+
+func TestGuard(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		err  string
+		warn string
+	}{
+		{name: "valid", args: []string{"create", "--role", "admin", "--replicas", "5", "--tag", "a,bcd", "--label", "a=high", "alice", "x", "y"}},
+		{name: "enum", args: []string{"create", "--role", "root", "alice"}, err: `invalid argument "root" for "--role" flag: must be one of member, admin`},
+		{name: "inherited pattern", args: []string{"create", "--region", "EU", "alice"}, err: `invalid argument "EU" for "--region" flag: must match ^[a-z]{2}-[a-z]+$`},
+		{name: "minimum", args: []string{"create", "--replicas", "0", "alice"}, err: "must be at least 1"},
+		{name: "maximum", args: []string{"create", "--replicas", "6", "alice"}, err: "must be at most 5"},
+		{name: "slice items", args: []string{"create", "--tag", "ok,toolong", "alice"}, err: `invalid argument "toolong" for "--tag" flag: must be at most 3 character(s) long`},
+		{name: "map values", args: []string{"create", "--label", "a=low,b=mid", "alice"}, err: `invalid argument "mid" for "--label" flag: must be one of low, high`},
+		{name: "argument length", args: []string{"create", "a"}, err: `invalid argument "a" for "name": must be at least 2 character(s) long`},
+		{name: "too few arguments", args: []string{"create"}, err: "requires at least 1 arg(s), only received 0"},
+		{name: "too many arguments", args: []string{"create", "alice", "x", "y", "z"}, err: "accepts at most 3 arg(s), received 4"},
+		{name: "deprecated flag", args: []string{"create", "--legacy", "alice"}, warn: "Flag --legacy has been deprecated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			root, ran := newApp(t, &stderr)
+			s, err := spec.Parse([]byte(testSpec))
+			if err != nil {
+				t.Fatalf("Failed to parse spec: %v", err)
+			}
+			guard, err := New(s, nil)
+			if err != nil {
+				t.Fatalf("Failed to create guard: %v", err)
+			}
+			guard.Attach(root)
+
+			root.SetArgs(tt.args)
+			err = root.Execute()
+
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected error containing %q, got %v", tt.err, err)
+				}
+				if *ran {
+					t.Error("Expected the command not to run")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !*ran {
+				t.Error("Expected the command to run")
+			}
+			if !strings.Contains(stderr.String(), tt.warn) {
+				t.Errorf("Expected warning %q, got %q", tt.warn, stderr.String())
+			}
+		})
+	}
+}
+
+func TestGuard_ExistingHooks(t *testing.T) {
+	for _, tt := range []struct {
+		args  []string
+		calls string
+	}{
+		{args: []string{"create", "--role", "root", "alice"}, calls: ""},
+		{args: []string{"create", "alice"}, calls: "create"},
+	} {
+		var stderr bytes.Buffer
+		root, _ := newApp(t, &stderr)
+		calls := []string{}
+		root.PersistentPreRun = func(cmd *cobra.Command, args []string) { calls = append(calls, "root") }
+		create, _, _ := root.Find([]string{"create"})
+		// Cobra runs only this hook for "create", so it is guarded too
+		create.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+			calls = append(calls, "create")
+			return nil
+		}
+
+		s, _ := spec.Parse([]byte(testSpec))
+		guard, _ := New(s, nil)
+		guard.Attach(root)
+
+		root.SetArgs(tt.args)
+		err := root.Execute()
+		if (err != nil) != (tt.calls == "") {
+			t.Errorf("%v: unexpected error %v", tt.args, err)
+		}
+		if got := strings.Join(calls, ","); got != tt.calls {
+			t.Errorf("%v: expected hooks %q to run, got %q", tt.args, tt.calls, got)
+		}
+	}
+}
+
+func TestNew_InvalidPattern(t *testing.T) {
+	s := &spec.OpenCLISpec{Commands: map[string]spec.Command{
+		"app": {Parameters: []spec.Parameter{{Name: "id", In: "flag", Schema: &spec.Schema{Type: "string", Pattern: "("}}}},
+	}}
+	if _, err := New(s, nil); err == nil || !strings.Contains(err.Error(), `parameter "id"`) {
+		t.Errorf("Expected an invalid pattern error, got %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+package scaffold
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// ClientOptions controls the generated client package
+type ClientOptions struct {
+	// Package is the name of the generated package. It defaults to the name
+	// of the root command followed by "client".
+	Package string
+
+	// Binary is the executable the client runs by default. It defaults to
+	// the name of the root command.
+	Binary string
+}
+
+// clientCommand is the template data of one client method
+type clientCommand struct {
+	Path string
+	// Method is the name of the client method, e.g. "UserCreate"
+	Method     string
+	Summary    string
+	Deprecated bool
+	// Words are the words following the binary that select the command
+	Words  []string
+	Fields []clientField
+}
+
+// clientField is a field of an options struct, for a flag or an argument
+type clientField struct {
+	Name        string
+	GoType      string
+	Description string
+	Deprecated  bool
+	// Flag is the flag name, or empty for positional arguments
+	Flag string
+	// Required fields are passed even when they hold the zero value
+	Required bool
+	// Pointer fields are omitted when nil; used when a flag's default is
+	// not the zero value, so the zero value can still be passed
+	Pointer bool
+}
+
+// GenerateClient writes a Go package that runs the CLI described by s
+// through os/exec. Every command becomes a method of Client taking an
+// options struct, so invocations are type-checked against the spec.
+func GenerateClient(s *spec.OpenCLISpec, w io.Writer, opts *ClientOptions) error {
+	if opts == nil {
+		opts = &ClientOptions{}
+	}
+
+	commands, err := buildCommands(s)
+	if err != nil {
+		return err
+	}
+	root := commands[0].Path
+
+	data := struct {
+		Package  string
+		Binary   string
+		Root     string
+		Commands []*clientCommand
+		Imports  []string
+		Sort     bool
+	}{
+		Package: opts.Package,
+		Binary:  opts.Binary,
+		Root:    root,
+	}
+	if data.Package == "" {
+		data.Package = strings.ReplaceAll(fileName([]string{root}), "_", "") + "client"
+	}
+	if data.Binary == "" {
+		data.Binary = root
+	}
+
+	data.Commands = buildClientCommands(s)
+	data.Imports = clientImports(data.Commands)
+	for _, path := range data.Imports {
+		data.Sort = data.Sort || path == "sort"
+	}
+
+	src, err := render(clientTemplate, data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// buildClientCommands converts the commands of a spec, root first. Flags
+// inherited from ancestors are part of every descendant's options.
+func buildClientCommands(s *spec.OpenCLISpec) []*clientCommand {
+	byPath := make(map[string]spec.Command)
+	paths := make([]string, 0, len(s.Commands))
+	for key, cmd := range s.Commands {
+		path := strings.Join(strings.Split(strings.Trim(key, "/"), "/"), " ")
+		byPath[path] = cmd
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	methods := map[string]bool{}
+	commands := make([]*clientCommand, 0, len(paths))
+	for _, path := range paths {
+		cmd := byPath[path]
+		words := strings.Fields(path)[1:]
+
+		c := &clientCommand{
+			Path:       path,
+			Summary:    cmd.Summary,
+			Deprecated: cmd.Deprecated,
+			Words:      words,
+		}
+		if len(words) == 0 {
+			c.Method = "Root"
+		} else {
+			c.Method = unique(exported(words), methods)
+		}
+		methods[c.Method] = true
+
+		fields := map[string]bool{}
+		seen := map[string]bool{}
+		args := make([]spec.Parameter, 0)
+		for _, p := range cmd.Parameters {
+			if p.In == "argument" {
+				args = append(args, p)
+				continue
+			}
+			seen[p.Name] = true
+			c.Fields = append(c.Fields, newFlagField(p, fields))
+		}
+
+		// Flags passed down by ancestors, nearest first
+		for i := len(words) - 1; i >= 0; i-- {
+			ancestor := strings.Join(strings.Fields(path)[:i+1], " ")
+			for _, p := range byPath[ancestor].Parameters {
+				if p.In == "argument" || seen[p.Name] || (p.Scope != "inherited" && p.Scope != "global") {
+					continue
+				}
+				seen[p.Name] = true
+				c.Fields = append(c.Fields, newFlagField(p, fields))
+			}
+		}
+
+		sort.SliceStable(args, func(i, j int) bool { return args[i].Position < args[j].Position })
+		for _, arg := range args {
+			f := clientField{
+				Name:        unique(exported(strings.FieldsFunc(arg.Name, isSeparator)), fields),
+				GoType:      "string",
+				Description: arg.Description,
+				Deprecated:  arg.Deprecated,
+				Required:    arg.Required,
+			}
+			if t := goType(arg.Schema); arg.Schema != nil && t != "bool" && !strings.HasPrefix(t, "map") {
+				f.GoType = t
+			}
+			if arg.Arity != nil && (arg.Arity.Max == nil || *arg.Arity.Max > 1) && !strings.HasPrefix(f.GoType, "[]") {
+				f.GoType = "[]" + f.GoType
+			}
+			fields[f.Name] = true
+			c.Fields = append(c.Fields, f)
+		}
+
+		commands = append(commands, c)
+	}
+	return commands
+}
+
+// newFlagField converts a flag parameter; fields holds the field names in use
+func newFlagField(p spec.Parameter, fields map[string]bool) clientField {
+	f := clientField{
+		Name:        unique(exported(strings.FieldsFunc(p.Name, isSeparator)), fields),
+		GoType:      goType(p.Schema),
+		Description: p.Description,
+		Deprecated:  p.Deprecated,
+		Flag:        p.Name,
+		Required:    p.Required,
+	}
+	fields[f.Name] = true
+
+	if p.Schema != nil && !f.Required && !strings.HasPrefix(f.GoType, "[]") && !strings.HasPrefix(f.GoType, "map") {
+		f.Pointer = defaultLiteral(f.GoType, p.Schema.Default) != defaultLiteral(f.GoType, nil)
+	}
+	return f
+}
+
+// exported joins words into an exported Go identifier
+func exported(words []string) string {
+	// Keywords are lower case, so the suffix avoiding them is not needed
+	name := strings.TrimSuffix(identifier(words), "_")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// Type returns the declared type of the field
+func (f clientField) Type() string {
+	if f.Pointer {
+		return "*" + f.GoType
+	}
+	return f.GoType
+}
+
+// Append returns the statements appending the field to the arguments
+// named args (flags) or positional (arguments)
+func (f clientField) Append() string {
+	value := "opts." + f.Name
+	if f.Flag == "" {
+		if strings.HasPrefix(f.GoType, "[]") {
+			return fmt.Sprintf("for _, v := range %s {\npositional = append(positional, %s)\n}", value, formatValue(strings.TrimPrefix(f.GoType, "[]"), "v"))
+		}
+		stmt := fmt.Sprintf("positional = append(positional, %s)", formatValue(f.GoType, value))
+		if f.Required {
+			return stmt
+		}
+		return fmt.Sprintf("if %s != %s {\n%s\n}", value, defaultLiteral(f.GoType, nil), stmt)
+	}
+
+	flag := fmt.Sprintf("%q", "--"+f.Flag+"=")
+	switch {
+	case f.GoType == "map[string]string":
+		return fmt.Sprintf("for _, k := range sortedKeys(%s) {\nargs = append(args, %s+k+\"=\"+%s[k])\n}", value, flag, value)
+	case strings.HasPrefix(f.GoType, "[]"):
+		return fmt.Sprintf("for _, v := range %s {\nargs = append(args, %s+%s)\n}", value, flag, formatValue(strings.TrimPrefix(f.GoType, "[]"), "v"))
+	case f.Pointer:
+		return fmt.Sprintf("if %s != nil {\nargs = append(args, %s+%s)\n}", value, flag, formatValue(f.GoType, "*"+value))
+	case f.GoType == "bool" && f.Required:
+		return fmt.Sprintf("args = append(args, %s+strconv.FormatBool(%s))", flag, value)
+	case f.GoType == "bool":
+		return fmt.Sprintf("if %s {\nargs = append(args, %q)\n}", value, "--"+f.Flag)
+	default:
+		stmt := fmt.Sprintf("args = append(args, %s+%s)", flag, formatValue(f.GoType, value))
+		if f.Required {
+			return stmt
+		}
+		return fmt.Sprintf("if %s != %s {\n%s\n}", value, defaultLiteral(f.GoType, nil), stmt)
+	}
+}
+
+// formatValue returns the expression formatting value of goType as a string
+func formatValue(goType, value string) string {
+	switch goType {
+	case "int":
+		return "strconv.Itoa(" + value + ")"
+	case "float64":
+		return "strconv.FormatFloat(" + value + ", 'g', -1, 64)"
+	case "bool":
+		return "strconv.FormatBool(" + value + ")"
+	case "time.Duration":
+		if strings.HasPrefix(value, "*") {
+			value = "(" + value + ")"
+		}
+		return value + ".String()"
+	default:
+		return value
+	}
+}
+
+// clientImports returns the packages the generated client needs
+func clientImports(commands []*clientCommand) []string {
+	imports := map[string]bool{"bytes": true, "context": true, "errors": true, "os": true, "os/exec": true, "strings": true}
+	for _, c := range commands {
+		for _, f := range c.Fields {
+			src := f.Append()
+			if strings.Contains(src, "strconv.") {
+				imports["strconv"] = true
+			}
+			if strings.Contains(src, "sortedKeys(") {
+				imports["sort"] = true
+			}
+			if strings.Contains(f.GoType, "time.") {
+				imports["time"] = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(imports))
+	for path := range imports {
+		result = append(result, path)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,131 @@
+package scaffold
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateClient(t *testing.T) {
+	var out bytes.Buffer
+	if err := GenerateClient(loadSpec(t), &out, nil); err != nil {
+		t.Fatalf("Failed to generate client: %v", err)
+	}
+	src := out.String()
+
+	for _, want := range []string{
+		"// Code generated by gospec-cli client. DO NOT EDIT.",
+		"package appclient",
+		`const DefaultBinary = "app"`,
+		"func (c *Client) Root(ctx context.Context, opts RootOptions) (*Result, error) {",
+		"func (c *Client) UserCreate(ctx context.Context, opts UserCreateOptions) (*Result, error) {",
+		`args := []string{"user", "create"}`,
+		"\t// Role of the user\n\tRole    string\n",
+		"\tQuota   *int\n",
+		"\tGroups  []string\n",
+		"\tTimeout *time.Duration\n\t// Deprecated: the --type flag is deprecated.\n\tType string\n",
+		"\t// Config file\n\tConfig  string\n",
+		"\tVerbose bool\n",
+		"\tName    string\n",
+		`args = append(args, "--role="+opts.Role)`,
+		"if opts.Quota != nil {\n\t\targs = append(args, \"--quota=\"+strconv.Itoa(*opts.Quota))",
+		"if opts.Verbose {\n\t\targs = append(args, \"--verbose\")",
+		"// UserDelete runs \"app user delete\": Delete users\n//\n// Deprecated: the command is deprecated.",
+		"\tNames   []string\n",
+		"func (c *Client) UserSetState(",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("Expected client to contain %q, got:\n%s", want, src)
+		}
+	}
+
+	out.Reset()
+	if err := GenerateClient(loadSpec(t), &out, &ClientOptions{Package: "apps", Binary: "/usr/bin/app"}); err != nil {
+		t.Fatalf("Failed to generate client: %v", err)
+	}
+	if !strings.Contains(out.String(), "package apps\n") || !strings.Contains(out.String(), `const DefaultBinary = "/usr/bin/app"`) {
+		t.Errorf("Expected options to be applied, got:\n%s", out.String())
+	}
+}
+
+// TestGenerateClient_Runs compiles the client and checks the command lines
+// it runs, using echo as the binary
+func TestGenerateClient_Runs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a Go module")
+	}
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo not available")
+	}
+
+	dir := t.TempDir()
+	var client bytes.Buffer
+	if err := GenerateClient(loadSpec(t), &client, nil); err != nil {
+		t.Fatalf("Failed to generate client: %v", err)
+	}
+	files := map[string]string{
+		"go.mod":              "module example.com/clienttest\n\ngo 1.22\n",
+		"appclient/client.go": client.String(),
+		"main.go": `package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"example.com/clienttest/appclient"
+)
+
+func main() {
+	c := appclient.New("echo")
+	for _, run := range []func() (*appclient.Result, error){
+		func() (*appclient.Result, error) {
+			return c.UserCreate(context.Background(), appclient.UserCreateOptions{
+				Role:    "admin",
+				Quota:   appclient.Ptr(0),
+				Groups:  []string{"a", "b"},
+				Timeout: appclient.Ptr(time.Minute),
+				Verbose: true,
+				Name:    "-alice",
+			})
+		},
+		func() (*appclient.Result, error) {
+			return c.UserDelete(context.Background(), appclient.UserDeleteOptions{Names: []string{"bob", "carol"}})
+		},
+	} {
+		result, err := run()
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("%d %s", result.ExitCode, result.Stdout)
+	}
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Client does not run: %v\n%s", err, out)
+	}
+
+	want := "0 user create --role=admin --quota=0 --groups=a --groups=b --timeout=1m0s --verbose -- -alice\n" +
+		"0 user delete bob carol\n"
+	if string(out) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out)
+	}
+}
@@ -0,0 +1,493 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/token"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// command is the template data of one command
+type command struct {
+	// Path is the command line that invokes the command, e.g. "app user create"
+	Path string
+	// Name is the identifier of the command, e.g. "userCreate"; File is the
+	// base of its file names, e.g. "user_create"
+	Name string
+	File string
+
+	Use        string
+	Aliases    []string
+	Short      string
+	Long       string
+	Example    string
+	Version    string
+	Deprecated bool
+	Hidden     bool
+
+	// Args is the positional argument validator expression
+	Args      string
+	ValidArgs []string
+
+	Flags    []flag
+	Children []*command
+	Root     bool
+}
+
+// flag is the template data of one flag
+type flag struct {
+	Name       string
+	Field      string
+	Shorthand  string
+	Usage      string
+	GoType     string
+	Setter     string
+	Default    string
+	Persistent bool
+	Required   bool
+	Hidden     bool
+	Deprecated bool
+	Enum       []string
+}
+
+// Title returns the exported form of the command identifier
+func (c *command) Title() string {
+	return strings.ToUpper(c.Name[:1]) + c.Name[1:]
+}
+
+// NeedsTime reports whether the command's flags use the time package
+func (c *command) NeedsTime() bool {
+	for _, f := range c.Flags {
+		if f.GoType == "time.Duration" {
+			return true
+		}
+	}
+	return false
+}
+
+// flagSetters maps Go flag types to the pflag functions defining them
+var flagSetters = map[string]string{
+	"string":            "String",
+	"bool":              "Bool",
+	"int":               "Int",
+	"float64":           "Float64",
+	"time.Duration":     "Duration",
+	"[]string":          "StringSlice",
+	"[]int":             "IntSlice",
+	"[]float64":         "Float64Slice",
+	"[]bool":            "BoolSlice",
+	"map[string]string": "StringToString",
+}
+
+// buildCommands arranges the commands of a spec into a tree, root first.
+// Command keys are paths such as "/app/user/create".
+func buildCommands(s *spec.OpenCLISpec) ([]*command, error) {
+	byPath := make(map[string]*command)
+	for key, cmd := range s.Commands {
+		path := strings.Join(strings.Split(strings.Trim(key, "/"), "/"), " ")
+		byPath[path] = newCommand(path, cmd)
+	}
+
+	commands := make([]*command, 0, len(byPath))
+	for _, cmd := range byPath {
+		commands = append(commands, cmd)
+	}
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Path < commands[j].Path })
+
+	roots := 0
+	names := make(map[string]bool)
+	files := make(map[string]bool)
+	for _, cmd := range commands {
+		i := strings.LastIndex(cmd.Path, " ")
+		if i < 0 {
+			roots++
+			cmd.Root = true
+			cmd.Version = s.Info.Version
+			cmd.Name, cmd.File = "root", "root"
+		} else {
+			parent, ok := byPath[cmd.Path[:i]]
+			if !ok {
+				return nil, fmt.Errorf("command %q has no parent command %q", cmd.Path, cmd.Path[:i])
+			}
+			parent.Children = append(parent.Children, cmd)
+
+			words := strings.Fields(cmd.Path)[1:]
+			cmd.Name = unique(identifier(words), names)
+			cmd.File = unique(fileName(words), files)
+		}
+		names[cmd.Name] = true
+		files[cmd.File] = true
+	}
+
+	switch {
+	case roots == 0:
+		return nil, fmt.Errorf("specification has no commands")
+	case roots > 1:
+		return nil, fmt.Errorf("specification has %d root commands, expected one", roots)
+	}
+
+	// The root sorts first since it is a prefix of every other path
+	return commands, nil
+}
+
+// newCommand converts a spec command
+func newCommand(path string, cmd spec.Command) *command {
+	c := &command{
+		Path:       path,
+		Aliases:    cmd.Aliases,
+		Short:      cmd.Summary,
+		Long:       cmd.Description,
+		Deprecated: cmd.Deprecated,
+		Hidden:     cmd.Hidden,
+	}
+	if c.Long == c.Short {
+		c.Long = ""
+	}
+	if example, ok := cmd.Extensions[spec.ExtensionExamples].(string); ok {
+		c.Example = example
+	}
+
+	args := make([]spec.Parameter, 0)
+	fields := map[string]bool{}
+	for _, p := range cmd.Parameters {
+		if p.In == "argument" {
+			args = append(args, p)
+			continue
+		}
+		c.Flags = append(c.Flags, newFlag(p, fields))
+	}
+	sort.SliceStable(args, func(i, j int) bool { return args[i].Position < args[j].Position })
+	c.Args, c.ValidArgs = argsValidator(args)
+
+	c.Use = path[strings.LastIndex(path, " ")+1:]
+	if usage, ok := cmd.Extensions[spec.ExtensionUsage].(string); ok && usage != "" {
+		c.Use = usage
+	} else {
+		for _, arg := range args {
+			if arg.Required {
+				c.Use += " <" + arg.Name + ">"
+			} else {
+				c.Use += " [" + arg.Name + "]"
+			}
+		}
+	}
+	return c
+}
+
+// newFlag converts a flag parameter; fields holds the field names in use
+func newFlag(p spec.Parameter, fields map[string]bool) flag {
+	f := flag{
+		Name:       p.Name,
+		Field:      unique(identifier(strings.FieldsFunc(p.Name, isSeparator)), fields),
+		Usage:      p.Description,
+		GoType:     goType(p.Schema),
+		Persistent: p.Scope == "inherited" || p.Scope == "global",
+		Required:   p.Required,
+		Hidden:     p.Hidden,
+		Deprecated: p.Deprecated,
+	}
+	fields[f.Field] = true
+	f.Setter = flagSetters[f.GoType]
+
+	for _, alias := range p.Alias {
+		if len(alias) == 1 {
+			f.Shorthand = alias
+			break
+		}
+	}
+
+	if p.Schema != nil {
+		f.Default = defaultLiteral(f.GoType, p.Schema.Default)
+		f.Enum = spec.AllowedValues(p.Schema)
+	} else {
+		f.Default = defaultLiteral(f.GoType, nil)
+	}
+	return f
+}
+
+// goType returns the Go type of a flag with the given schema
+func goType(schema *spec.Schema) string {
+	if schema == nil {
+		return "bool"
+	}
+	switch schema.Type {
+	case "boolean":
+		return "bool"
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "object":
+		return "map[string]string"
+	case "array":
+		if schema.Items != nil {
+			if t := goType(schema.Items); !strings.HasPrefix(t, "[]") && !strings.HasPrefix(t, "map") && t != "time.Duration" {
+				return "[]" + t
+			}
+		}
+		return "[]string"
+	case "string":
+		if schema.Format == "duration" {
+			return "time.Duration"
+		}
+		return "string"
+	case "":
+		if len(schema.Enum) > 0 {
+			return "string"
+		}
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// defaultLiteral renders a schema default as a Go expression of goType.
+// Defaults that do not fit the type fall back to the zero value.
+func defaultLiteral(goType string, value interface{}) string {
+	switch goType {
+	case "bool":
+		if b, ok := toBool(value); ok {
+			return strconv.FormatBool(b)
+		}
+		return "false"
+	case "int":
+		if n, ok := toInt(value); ok {
+			return strconv.FormatInt(n, 10)
+		}
+		return "0"
+	case "float64":
+		if f, ok := toFloat(value); ok {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		return "0"
+	case "string":
+		if value == nil {
+			return `""`
+		}
+		return strconv.Quote(fmt.Sprint(value))
+	case "time.Duration":
+		if d, ok := toDuration(value); ok {
+			return durationLiteral(d)
+		}
+		return "0"
+	case "[]string", "[]int", "[]float64", "[]bool":
+		elems := toList(value)
+		if len(elems) == 0 {
+			return "nil"
+		}
+		elemType := strings.TrimPrefix(goType, "[]")
+		literals := make([]string, len(elems))
+		for i, elem := range elems {
+			literals[i] = defaultLiteral(elemType, elem)
+		}
+		return goType + "{" + strings.Join(literals, ", ") + "}"
+	default:
+		return "nil"
+	}
+}
+
+// argsValidator returns the cobra.PositionalArgs expression checking the
+// number of arguments, and the values accepted when arguments are enums
+func argsValidator(args []spec.Parameter) (string, []string) {
+	if len(args) == 0 {
+		return "cobra.NoArgs", nil
+	}
+
+	min, max, unlimited := 0, 0, false
+	allEnums := true
+	validArgs := make([]string, 0)
+	for _, arg := range args {
+		switch {
+		case arg.Arity != nil:
+			min += arg.Arity.Min
+			if arg.Arity.Max == nil {
+				unlimited = true
+			} else {
+				max += *arg.Arity.Max
+			}
+		case arg.Required:
+			min++
+			max++
+		default:
+			max++
+		}
+
+		values := spec.AllowedValues(arg.Schema)
+		if len(values) == 0 {
+			allEnums = false
+			continue
+		}
+		validArgs = append(validArgs, values...)
+	}
+
+	var validator string
+	switch {
+	case unlimited && min == 0:
+		validator = "cobra.ArbitraryArgs"
+	case unlimited:
+		validator = fmt.Sprintf("cobra.MinimumNArgs(%d)", min)
+	case min == max:
+		validator = fmt.Sprintf("cobra.ExactArgs(%d)", min)
+	default:
+		validator = fmt.Sprintf("cobra.RangeArgs(%d, %d)", min, max)
+	}
+
+	if len(validArgs) == 0 {
+		return validator, nil
+	}
+	if allEnums {
+		validator = "cobra.MatchAll(" + validator + ", cobra.OnlyValidArgs)"
+	}
+	return validator, validArgs
+}
+
+// identifier joins words into a lowerCamelCase Go identifier
+func identifier(words []string) string {
+	var b strings.Builder
+	for _, word := range words {
+		for _, part := range strings.FieldsFunc(word, isSeparator) {
+			runes := []rune(strings.ToLower(part))
+			if b.Len() > 0 {
+				runes[0] = unicode.ToUpper(runes[0])
+			}
+			b.WriteString(string(runes))
+		}
+	}
+
+	name := b.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "x" + name
+	}
+	if token.IsKeyword(name) {
+		name += "_"
+	}
+	return name
+}
+
+// fileName joins words into a snake_case file name
+func fileName(words []string) string {
+	parts := make([]string, 0, len(words))
+	for _, word := range words {
+		parts = append(parts, strings.FieldsFunc(strings.ToLower(word), isSeparator)...)
+	}
+	return strings.Join(parts, "_")
+}
+
+// isSeparator reports whether r cannot appear in an identifier
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// unique appends a number to name until it is not in used
+func unique(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	return candidate
+}
+
+// toBool converts a decoded or string default to a bool
+func toBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
+}
+
+// toInt converts a decoded or string default to an integer
+func toInt(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		if v == math.Trunc(v) {
+			return int64(v), true
+		}
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// toFloat converts a decoded or string default to a float
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toDuration converts a default such as "30s" to a duration
+func toDuration(value interface{}) (time.Duration, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return 0, false
+	}
+	d, err := time.ParseDuration(s)
+	return d, err == nil
+}
+
+// toList converts a default list, or its string form "[a,b]", to elements
+func toList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case string:
+		v = strings.TrimSuffix(strings.TrimPrefix(v, "["), "]")
+		if v == "" {
+			return nil
+		}
+		elems := make([]interface{}, 0)
+		for _, elem := range strings.Split(v, ",") {
+			elems = append(elems, strings.TrimSpace(elem))
+		}
+		return elems
+	}
+	return nil
+}
+
+// durationLiteral renders d in the largest unit that divides it exactly,
+// e.g. 90 * time.Second
+func durationLiteral(d time.Duration) string {
+	if d == 0 {
+		return "0"
+	}
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("%d", int64(d))
+}
@@ -0,0 +1,310 @@
+// Package scaffold generates Go source from an OpenCLI specification: the
+// CLI application itself, so that a CLI can be designed spec-first, and a
+// typed client package running the CLI from other Go programs.
+//
+// Regenerating an application is safe: files holding the command definitions are marked as
+// generated and rewritten every time, while the files holding the RunE
+// implementations, go.mod and main.go are only created when missing.
+// Generated files of commands removed from the specification are deleted;
+// scaffold refuses to run while their RunE implementations remain.
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/version"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// CobraModule and CobraVersion are the Cobra release the generated module requires
+const (
+	CobraModule  = "github.com/spf13/cobra"
+	CobraVersion = "v1.10.2"
+)
+
+// cobraRequires are the requirements of a tidy module depending on Cobra
+var cobraRequires = []struct {
+	Path     string
+	Version  string
+	Indirect bool
+}{
+	{CobraModule, CobraVersion, false},
+	{"github.com/inconshreveable/mousetrap", "v1.1.0", true},
+	{"github.com/spf13/pflag", "v1.0.10", true},
+}
+
+// cobraSums are the go.sum lines for cobraRequires, so the generated module
+// builds without a network round trip
+const cobraSums = `github.com/inconshreveable/mousetrap v1.1.0 h1:wN+x4NVGpMsO7ErUn/mUI3vEoE6Jt13X2s0bqwp9tc8=
+github.com/inconshreveable/mousetrap v1.1.0/go.mod h1:vpF70FUmC8bwa3OWnCshd2FqLfsEA9PFc4w1p2J65bw=
+github.com/spf13/cobra v1.10.2 h1:DMTTonx5m65Ic0GOoRY2c16WCbHxOOw6xxezuLaBpcU=
+github.com/spf13/cobra v1.10.2/go.mod h1:7C1pvHqHw5A4vrJfjNwvOdzYu0Gml16OCs2GRiTUUS4=
+github.com/spf13/pflag v1.0.9/go.mod h1:McXfInJRrz4CZXVZOBLb0bTZqETkiAhM9Iw0y3An2Bg=
+github.com/spf13/pflag v1.0.10 h1:4EBh2KAYBwaONj6b2Ye1GiHfwjqyROoF4RwYO+vPwFk=
+github.com/spf13/pflag v1.0.10/go.mod h1:McXfInJRrz4CZXVZOBLb0bTZqETkiAhM9Iw0y3An2Bg=
+`
+
+// Options controls the generated application
+type Options struct {
+	// Framework of the generated code ("cobra" if empty)
+	Framework string
+
+	// Module is the module path written to go.mod. It defaults to the name
+	// of the root command.
+	Module string
+
+	// GoVersion is the go directive of go.mod. It defaults to the language
+	// version of the toolchain gospec-cli was built with.
+	GoVersion string
+}
+
+// Result lists the files of a scaffold run
+type Result struct {
+	// Written are the files created or regenerated
+	Written []string
+	// Kept are the hand-editable files that already existed
+	Kept []string
+	// Removed are the generated files of commands no longer in the spec
+	Removed []string
+}
+
+// generatedHeader starts every command file written by scaffold
+const generatedHeader = "// Code generated by gospec-cli scaffold. DO NOT EDIT."
+
+// fallbackGoVersion is the go directive used when the toolchain version is
+// not a release, such as a development build
+const fallbackGoVersion = "1.22"
+
+// Generate writes a Go module implementing the CLI described by s into dir
+func Generate(s *spec.OpenCLISpec, dir string, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	framework := opts.Framework
+	if framework == "" {
+		framework = "cobra"
+	}
+	if framework != "cobra" {
+		return nil, fmt.Errorf("framework %q is not supported by scaffold", framework)
+	}
+
+	commands, err := buildCommands(s)
+	if err != nil {
+		return nil, err
+	}
+
+	module := opts.Module
+	if module == "" {
+		module = commands[0].Path
+	}
+	goVersion := opts.GoVersion
+	if goVersion == "" {
+		goVersion = toolchainGoVersion()
+	}
+
+	stale, err := staleFiles(filepath.Join(dir, "cmd"), commands)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "cmd"), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	result := &Result{
+		Written: make([]string, 0),
+		Kept:    make([]string, 0),
+		Removed: make([]string, 0),
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		result.Removed = append(result.Removed, path)
+	}
+
+	goMod, err := renderGoMod(module, goVersion)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.create(filepath.Join(dir, "go.mod"), goMod); err != nil {
+		return nil, err
+	}
+	if err := result.create(filepath.Join(dir, "go.sum"), []byte(cobraSums)); err != nil {
+		return nil, err
+	}
+	mainSrc, err := render(mainTemplate, struct{ Module string }{module})
+	if err != nil {
+		return nil, err
+	}
+	if err := result.create(filepath.Join(dir, "main.go"), mainSrc); err != nil {
+		return nil, err
+	}
+
+	for _, cmd := range commands {
+		src, err := render(commandTemplate, cmd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", cmd.Path, err)
+		}
+		if err := result.write(filepath.Join(dir, "cmd", cmd.File+"_cmd.go"), src); err != nil {
+			return nil, err
+		}
+
+		src, err = render(runTemplate, cmd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", cmd.Path, err)
+		}
+		if err := result.create(filepath.Join(dir, "cmd", cmd.File+"_run.go"), src); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// staleFiles returns the generated command files in cmdDir that belong to
+// no command of the specification. It fails when such a command still has
+// its RunE file, which would no longer compile and may hold work.
+func staleFiles(cmdDir string, commands []*command) ([]string, error) {
+	current := make(map[string]bool, len(commands))
+	for _, cmd := range commands {
+		current[cmd.File] = true
+	}
+
+	paths, err := filepath.Glob(filepath.Join(cmdDir, "*_cmd.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	stale := make([]string, 0)
+	implemented := make([]string, 0)
+	for _, path := range paths {
+		file := strings.TrimSuffix(filepath.Base(path), "_cmd.go")
+		if current[file] {
+			continue
+		}
+		generated, err := isGenerated(path)
+		if err != nil {
+			return nil, err
+		}
+		if !generated {
+			continue
+		}
+		stale = append(stale, path)
+		run := filepath.Join(cmdDir, file+"_run.go")
+		if _, err := os.Stat(run); err == nil {
+			implemented = append(implemented, run)
+		}
+	}
+	if len(implemented) > 0 {
+		return nil, fmt.Errorf("commands removed from the specification still have implementations in %s; delete or move them and run scaffold again", strings.Join(implemented, ", "))
+	}
+	return stale, nil
+}
+
+// isGenerated reports whether the file at path starts with the scaffold
+// header
+func isGenerated(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && line == "" {
+		return false, nil
+	}
+	return strings.TrimSpace(line) == generatedHeader, nil
+}
+
+// toolchainGoVersion returns the language version of the Go toolchain that
+// built this program, such as "1.24"
+func toolchainGoVersion() string {
+	if lang := version.Lang(runtime.Version()); lang != "" {
+		return strings.TrimPrefix(lang, "go")
+	}
+	return fallbackGoVersion
+}
+
+// write writes a generated file, replacing any previous version
+func (r *Result) write(path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	r.Written = append(r.Written, path)
+	return nil
+}
+
+// create writes a hand-editable file unless it already exists
+func (r *Result) create(path string, data []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		r.Kept = append(r.Kept, path)
+		return nil
+	}
+	return r.write(path, data)
+}
+
+// renderGoMod returns a go.mod requiring Cobra
+func renderGoMod(modulePath, goVersion string) ([]byte, error) {
+	mod := &modfile.File{}
+	if err := mod.AddModuleStmt(modulePath); err != nil {
+		return nil, err
+	}
+	if err := mod.AddGoStmt(goVersion); err != nil {
+		return nil, err
+	}
+	// The requirements are set at once, so that direct and indirect ones
+	// each get a single block
+	requires := make([]*modfile.Require, len(cobraRequires))
+	for i, req := range cobraRequires {
+		requires[i] = &modfile.Require{Mod: module.Version{Path: req.Path, Version: req.Version}, Indirect: req.Indirect}
+	}
+	mod.SetRequireSeparateIndirect(requires)
+	collapseBlocks(mod)
+
+	data, err := mod.Format()
+	if err != nil {
+		return nil, fmt.Errorf("failed to format go.mod: %w", err)
+	}
+	return data, nil
+}
+
+// collapseBlocks writes the blocks of go.mod holding a single entry on one
+// line, as go mod tidy does
+func collapseBlocks(mod *modfile.File) {
+	for i, stmt := range mod.Syntax.Stmt {
+		block, ok := stmt.(*modfile.LineBlock)
+		if !ok || len(block.Line) != 1 {
+			continue
+		}
+		line := block.Line[0]
+		line.Token = append(append([]string{}, block.Token...), line.Token...)
+		line.InBlock = false
+		mod.Syntax.Stmt[i] = line
+	}
+}
+
+// render executes a template and formats the resulting Go source
+func render(tmpl *template.Template, data interface{}) ([]byte, error) {
+	var src bytes.Buffer
+	if err := tmpl.Execute(&src, data); err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid Go source: %w", err)
+	}
+	return formatted, nil
+}
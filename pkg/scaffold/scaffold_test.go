@@ -0,0 +1,274 @@
+package scaffold
+
+import (
+	"go/version"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+func loadSpec(t *testing.T) *spec.OpenCLISpec {
+	t.Helper()
+	s, err := spec.Load(filepath.Join("testdata", "app.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+	return s
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	result, err := Generate(loadSpec(t), dir, &Options{Module: "example.com/app"})
+	if err != nil {
+		t.Fatalf("Failed to scaffold: %v", err)
+	}
+	if len(result.Kept) != 0 {
+		t.Errorf("Expected no kept files, got %v", result.Kept)
+	}
+
+	for _, name := range []string{
+		"go.mod", "go.sum", "main.go",
+		"cmd/root_cmd.go", "cmd/root_run.go",
+		"cmd/user_cmd.go", "cmd/user_run.go",
+		"cmd/user_create_cmd.go", "cmd/user_create_run.go",
+		"cmd/user_delete_cmd.go", "cmd/user_set_state_cmd.go",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s to be written: %v", name, err)
+		}
+	}
+
+	if mod := readFile(t, filepath.Join(dir, "go.mod")); !strings.Contains(mod, "module example.com/app") || !strings.Contains(mod, CobraModule+" "+CobraVersion) {
+		t.Errorf("Unexpected go.mod:\n%s", mod)
+	}
+
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{"cmd/root_cmd.go", []string{
+			"// Code generated by gospec-cli scaffold. DO NOT EDIT.",
+			"func Execute() error {",
+			`Version: "1.2.0",`,
+			"Args:    cobra.NoArgs,",
+			`cmd.PersistentFlags().StringVarP(&flags.config, "config", "c", "", "Config file")`,
+			`cmd.PersistentFlags().BoolVar(&flags.verbose, "verbose", false, "")`,
+			"cmd.AddCommand(newUserCmd())",
+		}},
+		{"cmd/user_cmd.go", []string{
+			`Aliases: []string{"users"},`,
+			"cmd.AddCommand(newUserCreateCmd())",
+			"cmd.AddCommand(newUserSetStateCmd())",
+		}},
+		{"cmd/user_create_cmd.go", []string{
+			"\"time\"",
+			"type userCreateFlags struct {",
+			"type_   string",
+			`Use:   "create <name>",`,
+			"Long: `Create a user account.\n\nThe account is active immediately.`,",
+			`Example: "app user create alice --role admin",`,
+			"Args:    cobra.ExactArgs(1),",
+			`cmd.Flags().StringVarP(&flags.role, "role", "r", "member", "Role of the user")`,
+			`_ = cmd.MarkFlagRequired("role")`,
+			`_ = cmd.RegisterFlagCompletionFunc("role", cobra.FixedCompletions([]string{"member", "admin"}, cobra.ShellCompDirectiveNoFileComp))`,
+			`cmd.Flags().IntVar(&flags.quota, "quota", 10, "")`,
+			`cmd.Flags().Float64Var(&flags.ratio, "ratio", 0.5, "")`,
+			`cmd.Flags().StringSliceVar(&flags.groups, "groups", []string{"staff", "dev"}, "")`,
+			`_ = cmd.RegisterFlagCompletionFunc("groups", cobra.FixedCompletions([]string{"staff", "dev", "ops"}, cobra.ShellCompDirectiveNoFileComp))`,
+			`cmd.Flags().DurationVar(&flags.timeout, "timeout", 90*time.Second, "")`,
+			`_ = cmd.Flags().MarkHidden("type")`,
+			`_ = cmd.Flags().MarkDeprecated("type", "this flag is deprecated")`,
+		}},
+		{"cmd/user_delete_cmd.go", []string{
+			`Deprecated: "this command is deprecated",`,
+			"Args:       cobra.MinimumNArgs(1),",
+		}},
+		{"cmd/user_set_state_cmd.go", []string{
+			`Use:       "set-state <name> [state]",`,
+			"Args:      cobra.MatchAll(cobra.RangeArgs(1, 2), cobra.OnlyValidArgs),",
+			`ValidArgs: []string{"alice", "bob", "active", "locked"},`,
+		}},
+		{"cmd/user_run.go", []string{
+			"func runUser(cmd *cobra.Command, args []string, flags *userFlags) error {\n\treturn cmd.Help()",
+		}},
+		{"cmd/user_create_run.go", []string{
+			"func runUserCreate(cmd *cobra.Command, args []string, flags *userCreateFlags) error {",
+			"is not implemented",
+		}},
+	}
+	for _, tt := range tests {
+		src := readFile(t, filepath.Join(dir, tt.file))
+		for _, want := range tt.want {
+			if !strings.Contains(src, want) {
+				t.Errorf("Expected %s to contain %q, got:\n%s", tt.file, want, src)
+			}
+		}
+	}
+}
+
+func TestRenderGoMod(t *testing.T) {
+	data, err := renderGoMod("example.com/app", "1.22")
+	if err != nil {
+		t.Fatalf("Failed to render go.mod: %v", err)
+	}
+	want := `module example.com/app
+
+go 1.22
+
+require ` + CobraModule + ` ` + CobraVersion + `
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.10 // indirect
+)
+`
+	if string(data) != want {
+		t.Errorf("Expected go.mod:\n%s\ngot:\n%s", want, data)
+	}
+}
+
+func TestGenerate_KeepsHandWrittenFiles(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Generate(loadSpec(t), dir, nil); err != nil {
+		t.Fatalf("Failed to scaffold: %v", err)
+	}
+
+	run := filepath.Join(dir, "cmd", "user_create_run.go")
+	if err := os.WriteFile(run, []byte("package cmd\n\n// hand-written\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	generated := filepath.Join(dir, "cmd", "user_create_cmd.go")
+	if err := os.WriteFile(generated, []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Generate(loadSpec(t), dir, nil)
+	if err != nil {
+		t.Fatalf("Failed to regenerate: %v", err)
+	}
+	if !strings.Contains(readFile(t, run), "hand-written") {
+		t.Error("Expected run file to be kept")
+	}
+	if readFile(t, generated) == "stale" {
+		t.Error("Expected command file to be regenerated")
+	}
+	if len(result.Kept) != 3+5 {
+		t.Errorf("Expected go.mod, go.sum, main.go and 5 run files to be kept, got %v", result.Kept)
+	}
+}
+
+func TestGenerate_GoVersion(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Generate(loadSpec(t), dir, nil); err != nil {
+		t.Fatalf("Failed to scaffold: %v", err)
+	}
+	want := "go " + strings.TrimPrefix(version.Lang(runtime.Version()), "go") + "\n"
+	if mod := readFile(t, filepath.Join(dir, "go.mod")); !strings.Contains(mod, want) {
+		t.Errorf("Expected go.mod with %q, got:\n%s", want, mod)
+	}
+}
+
+func TestGenerate_RemovesStaleFiles(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Generate(loadSpec(t), dir, nil); err != nil {
+		t.Fatalf("Failed to scaffold: %v", err)
+	}
+	handWritten := filepath.Join(dir, "cmd", "helpers_cmd.go")
+	if err := os.WriteFile(handWritten, []byte("package cmd\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := loadSpec(t)
+	delete(s.Commands, "/app/user/set-state")
+	generated := filepath.Join(dir, "cmd", "user_set_state_cmd.go")
+	run := filepath.Join(dir, "cmd", "user_set_state_run.go")
+
+	if _, err := Generate(s, dir, nil); err == nil || !strings.Contains(err.Error(), run) {
+		t.Fatalf("Expected refusal naming %s, got %v", run, err)
+	}
+	if _, err := os.Stat(generated); err != nil {
+		t.Errorf("Expected nothing to be removed after a refusal: %v", err)
+	}
+
+	if err := os.Remove(run); err != nil {
+		t.Fatal(err)
+	}
+	result, err := Generate(s, dir, nil)
+	if err != nil {
+		t.Fatalf("Failed to regenerate: %v", err)
+	}
+	if len(result.Removed) != 1 || result.Removed[0] != generated {
+		t.Errorf("Expected %s to be removed, got %v", generated, result.Removed)
+	}
+	if _, err := os.Stat(generated); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be deleted, got %v", generated, err)
+	}
+	if _, err := os.Stat(handWritten); err != nil {
+		t.Errorf("Expected files without the generated header to be kept: %v", err)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	if _, err := Generate(loadSpec(t), t.TempDir(), &Options{Framework: "urfave-cli"}); err == nil {
+		t.Error("Expected error for unsupported framework")
+	}
+
+	orphan := &spec.OpenCLISpec{Commands: map[string]spec.Command{"app": {}, "/app/user/create": {}}}
+	if _, err := Generate(orphan, t.TempDir(), nil); err == nil {
+		t.Error("Expected error for command without parent")
+	}
+}
+
+// TestGenerate_Builds compiles the generated module and runs the result
+func TestGenerate_Builds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a Go module")
+	}
+
+	dir := t.TempDir()
+	if _, err := Generate(loadSpec(t), dir, &Options{Module: "example.com/app"}); err != nil {
+		t.Fatalf("Failed to scaffold: %v", err)
+	}
+
+	bin := filepath.Join(dir, "app")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = dir
+	build.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=readonly")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Generated module does not build: %v\n%s", err, out)
+	}
+
+	vet := exec.Command("go", "vet", "./...")
+	vet.Dir = dir
+	vet.Env = build.Env
+	if out, err := vet.CombinedOutput(); err != nil {
+		t.Fatalf("Generated module fails vet: %v\n%s", err, out)
+	}
+
+	out, err := exec.Command(bin, "user", "create", "alice", "--role", "admin").CombinedOutput()
+	if err == nil || !strings.Contains(string(out), "app user create is not implemented") {
+		t.Errorf("Expected stub error, got %v: %s", err, out)
+	}
+	out, err = exec.Command(bin, "user", "set-state", "carol").CombinedOutput()
+	if err == nil || !strings.Contains(string(out), `invalid argument "carol"`) {
+		t.Errorf("Expected invalid argument error, got %v: %s", err, out)
+	}
+	out, err = exec.Command(bin, "user", "create", "alice").CombinedOutput()
+	if err == nil || !strings.Contains(string(out), `required flag(s) "role" not set`) {
+		t.Errorf("Expected required flag error, got %v: %s", err, out)
+	}
+}
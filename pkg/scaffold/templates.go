@@ -0,0 +1,266 @@
+package scaffold
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var funcs = template.FuncMap{
+	"str": goString,
+	// line keeps text on one line, as in a // comment
+	"line": func(s string) string { return strings.Join(strings.Fields(s), " ") },
+	"strs": func(values []string) string {
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = strconv.Quote(v)
+		}
+		return "[]string{" + strings.Join(quoted, ", ") + "}"
+	},
+}
+
+// goString returns s as a Go string literal, using a raw string for text
+// spanning several lines
+func goString(s string) string {
+	if strings.Contains(s, "\n") && !strings.ContainsAny(s, "`\r") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+var mainTemplate = template.Must(template.New("main").Funcs(funcs).Parse(`package main
+
+import (
+	"os"
+
+	"{{.Module}}/cmd"
+)
+
+func main() {
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+`))
+
+var commandTemplate = template.Must(template.New("command").Funcs(funcs).Parse(`// Code generated by gospec-cli scaffold. DO NOT EDIT.
+
+package cmd
+
+import (
+{{- if .NeedsTime}}
+	"time"
+{{end}}
+	"github.com/spf13/cobra"
+)
+
+// {{.Name}}Flags holds the flags of {{printf "%q" .Path}}
+type {{.Name}}Flags struct {
+{{- range .Flags}}
+	{{.Field}} {{.GoType}}
+{{- end}}
+}
+{{if .Root}}
+// Execute runs the {{printf "%q" .Path}} command
+func Execute() error {
+	return newRootCmd().Execute()
+}
+{{end}}
+// new{{.Title}}Cmd creates the {{printf "%q" .Path}} command
+func new{{.Title}}Cmd() *cobra.Command {
+	flags := &{{.Name}}Flags{}
+	cmd := &cobra.Command{
+		Use: {{str .Use}},
+{{- if .Aliases}}
+		Aliases: {{strs .Aliases}},
+{{- end}}
+{{- if .Short}}
+		Short: {{str .Short}},
+{{- end}}
+{{- if .Long}}
+		Long: {{str .Long}},
+{{- end}}
+{{- if .Example}}
+		Example: {{str .Example}},
+{{- end}}
+{{- if .Version}}
+		Version: {{str .Version}},
+{{- end}}
+{{- if .Deprecated}}
+		Deprecated: "this command is deprecated",
+{{- end}}
+{{- if .Hidden}}
+		Hidden: true,
+{{- end}}
+		Args: {{.Args}},
+{{- if .ValidArgs}}
+		ValidArgs: {{strs .ValidArgs}},
+{{- end}}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run{{.Title}}(cmd, args, flags)
+		},
+	}
+{{range .Flags}}
+	{{if .Persistent}}cmd.PersistentFlags(){{else}}cmd.Flags(){{end}}.{{.Setter}}{{if .Shorthand}}VarP{{else}}Var{{end}}(&flags.{{.Field}}, {{str .Name}}, {{if .Shorthand}}{{str .Shorthand}}, {{end}}{{.Default}}, {{str .Usage}})
+{{- if .Required}}
+	_ = cmd.{{if .Persistent}}MarkPersistentFlagRequired{{else}}MarkFlagRequired{{end}}({{str .Name}})
+{{- end}}
+{{- if .Hidden}}
+	_ = {{if .Persistent}}cmd.PersistentFlags(){{else}}cmd.Flags(){{end}}.MarkHidden({{str .Name}})
+{{- end}}
+{{- if .Deprecated}}
+	_ = {{if .Persistent}}cmd.PersistentFlags(){{else}}cmd.Flags(){{end}}.MarkDeprecated({{str .Name}}, "this flag is deprecated")
+{{- end}}
+{{- if .Enum}}
+	_ = cmd.RegisterFlagCompletionFunc({{str .Name}}, cobra.FixedCompletions({{strs .Enum}}, cobra.ShellCompDirectiveNoFileComp))
+{{- end}}
+{{- end}}
+{{range .Children}}
+	cmd.AddCommand(new{{.Title}}Cmd())
+{{- end}}
+
+	return cmd
+}
+`))
+
+var runTemplate = template.Must(template.New("run").Funcs(funcs).Parse(`package cmd
+
+import (
+{{- if not .Children}}
+	"fmt"
+{{end}}
+	"github.com/spf13/cobra"
+)
+
+// run{{.Title}} implements {{printf "%q" .Path}}. This file was created by
+// gospec-cli scaffold and is not overwritten when regenerating.
+func run{{.Title}}(cmd *cobra.Command, args []string, flags *{{.Name}}Flags) error {
+{{- if .Children}}
+	return cmd.Help()
+{{- else}}
+	return fmt.Errorf("%s is not implemented", cmd.CommandPath())
+{{- end}}
+}
+`))
+
+var clientTemplate = template.Must(template.New("client").Funcs(funcs).Parse(`// Code generated by gospec-cli client. DO NOT EDIT.
+
+// Package {{.Package}} runs the {{.Root}} CLI. Every command is a method of
+// Client taking an options struct generated from the OpenCLI specification.
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{printf "%q" .}}
+{{- end}}
+)
+
+// DefaultBinary is the executable run by a Client without Binary
+const DefaultBinary = {{str .Binary}}
+
+// Client runs the {{.Root}} CLI
+type Client struct {
+	// Binary is the path or name of the executable (default DefaultBinary)
+	Binary string
+	// Dir is the working directory of the command
+	Dir string
+	// Env holds variables added to the environment of the command
+	Env []string
+}
+
+// New returns a client running binary
+func New(binary string) *Client {
+	return &Client{Binary: binary}
+}
+
+// Result is the outcome of a command that ran
+type Result struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// Ptr returns a pointer to v, for optional fields
+func Ptr[T any](v T) *T {
+	return &v
+}
+{{range .Commands}}
+// {{.Method}}Options holds the parameters of {{printf "%q" .Path}}
+type {{.Method}}Options struct {
+{{- range .Fields}}
+{{- if .Description}}
+	// {{line .Description}}
+{{- end}}
+{{- if and .Description .Deprecated}}
+	//
+{{- end}}
+{{- if .Deprecated}}
+	// Deprecated: {{if .Flag}}the --{{.Flag}} flag{{else}}this argument{{end}} is deprecated.
+{{- end}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+
+// {{.Method}} runs {{printf "%q" .Path}}{{if .Summary}}: {{line .Summary}}{{end}}
+{{- if .Deprecated}}
+//
+// Deprecated: the command is deprecated.
+{{- end}}
+func (c *Client) {{.Method}}(ctx context.Context, opts {{.Method}}Options) (*Result, error) {
+	args := []string{ {{- range $i, $w := .Words}}{{if $i}}, {{end}}{{printf "%q" $w}}{{end -}} }
+	positional := []string{}
+{{- range .Fields}}
+	{{.Append}}
+{{- end}}
+	return c.run(ctx, args, positional)
+}
+{{end}}
+// run runs the binary with args followed by the positional arguments. The
+// error is nil when the command ran, whatever its exit code.
+func (c *Client) run(ctx context.Context, args, positional []string) (*Result, error) {
+	for _, arg := range positional {
+		if strings.HasPrefix(arg, "-") {
+			args = append(args, "--")
+			break
+		}
+	}
+	args = append(args, positional...)
+
+	binary := c.Binary
+	if binary == "" {
+		binary = DefaultBinary
+	}
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = c.Dir
+	if len(c.Env) > 0 {
+		cmd.Env = append(os.Environ(), c.Env...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	result := &Result{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && ctx.Err() == nil {
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+{{- if .Sort}}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+{{- end}}
+`))
@@ -0,0 +1,48 @@
+package spec
+
+import "gopkg.in/yaml.v3"
+
+// Completion describes how a shell completes the value of a parameter. It
+// is stored in the ExtensionCompletion extension of the parameter.
+type Completion struct {
+	// Values are the candidates offered for an empty word. They are
+	// suggestions, such as the names of existing resources, not a
+	// restriction like Schema.Enum.
+	Values []string `yaml:"values,omitempty" json:"values,omitempty"`
+	// FileExtensions restricts file completion to these extensions
+	FileExtensions []string `yaml:"fileExtensions,omitempty" json:"fileExtensions,omitempty"`
+	// Directories restricts file completion to directories, optionally
+	// below Directory
+	Directories bool   `yaml:"directories,omitempty" json:"directories,omitempty"`
+	Directory   string `yaml:"directory,omitempty" json:"directory,omitempty"`
+	// NoFiles disables the shell's file completion when there are no
+	// candidates
+	NoFiles bool `yaml:"noFiles,omitempty" json:"noFiles,omitempty"`
+	// NoSpace keeps the shell from adding a space after a candidate
+	NoSpace bool `yaml:"noSpace,omitempty" json:"noSpace,omitempty"`
+	// KeepOrder keeps the shell from sorting the candidates
+	KeepOrder bool `yaml:"keepOrder,omitempty" json:"keepOrder,omitempty"`
+}
+
+// ParameterCompletion returns the completion of p, or nil if it has none.
+// It accepts both the Completion set by the converter and the generic
+// value a loaded spec holds.
+func ParameterCompletion(p Parameter) *Completion {
+	switch v := p.Extensions[ExtensionCompletion].(type) {
+	case Completion:
+		return &v
+	case *Completion:
+		return v
+	case map[string]interface{}:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return nil
+		}
+		var c Completion
+		if err := yaml.Unmarshal(data, &c); err != nil {
+			return nil
+		}
+		return &c
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Command, Parameter and Response keep unknown keys such as x- extensions in
+// an Extensions map. YAML inlines the map through the ",inline" tag; the JSON
+// methods below do the same, since encoding/json has no inline support.
+
+// MarshalJSON encodes the command with its extensions inlined
+func (c Command) MarshalJSON() ([]byte, error) {
+	type plain Command
+	return marshalInline(plain(c), c.Extensions)
+}
+
+// UnmarshalJSON decodes the command, collecting unknown keys as extensions
+func (c *Command) UnmarshalJSON(data []byte) error {
+	type plain Command
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	ext, err := unmarshalInline(data, reflect.TypeOf(p))
+	if err != nil {
+		return err
+	}
+	*c = Command(p)
+	c.Extensions = ext
+	return nil
+}
+
+// MarshalJSON encodes the parameter with its extensions inlined
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	type plain Parameter
+	return marshalInline(plain(p), p.Extensions)
+}
+
+// UnmarshalJSON decodes the parameter, collecting unknown keys as extensions
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	type plain Parameter
+	var v plain
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	ext, err := unmarshalInline(data, reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+	*p = Parameter(v)
+	p.Extensions = ext
+	return nil
+}
+
+// MarshalJSON encodes the response with its extensions inlined
+func (r Response) MarshalJSON() ([]byte, error) {
+	type plain Response
+	return marshalInline(plain(r), r.Extensions)
+}
+
+// UnmarshalJSON decodes the response, collecting unknown keys as extensions
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type plain Response
+	var v plain
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	ext, err := unmarshalInline(data, reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+	*r = Response(v)
+	r.Extensions = ext
+	return nil
+}
+
+// marshalInline encodes v and appends the extensions as further members of
+// the resulting object, in key order
+func marshalInline(v interface{}, extensions map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return data, err
+	}
+
+	fields := jsonFields(reflect.TypeOf(v))
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		if fields[key] {
+			return nil, fmt.Errorf("extension %q conflicts with a field of %s", key, reflect.TypeOf(v).Name())
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, key := range keys {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		value, err := json.Marshal(extensions[key])
+		if err != nil {
+			return nil, fmt.Errorf("extension %q: %w", key, err)
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// unmarshalInline returns the members of a JSON object that are not fields
+// of t, or nil if there are none
+func unmarshalInline(data []byte, t reflect.Type) (map[string]interface{}, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	fields := jsonFields(t)
+	var extensions map[string]interface{}
+	for key, raw := range members {
+		if fields[key] {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		if extensions == nil {
+			extensions = make(map[string]interface{})
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+
+// jsonFields returns the JSON member names of a struct's fields
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
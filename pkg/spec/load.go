@@ -0,0 +1,151 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Supported document formats
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Load reads a specification file in either format
+func Load(path string) (*OpenCLISpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse decodes a specification, detecting whether it is JSON or YAML.
+//
+// Both formats decode free-form values such as defaults and extensions to the
+// same Go types: integral numbers become int, other numbers float64, objects
+// map[string]interface{} and arrays []interface{}. A spec built from those
+// types is returned unchanged by a generate and parse round trip.
+func Parse(data []byte) (*OpenCLISpec, error) {
+	var s OpenCLISpec
+	switch DetectFormat(data) {
+	case FormatJSON:
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		normalizeSpec(&s)
+	default:
+		if err := yaml.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+	}
+	return &s, nil
+}
+
+// DetectFormat reports FormatJSON if data holds a JSON object and FormatYAML
+// otherwise
+func DetectFormat(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed) {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// normalizeSpec converts the free-form values of a JSON-decoded spec to the
+// types YAML decoding produces
+func normalizeSpec(s *OpenCLISpec) {
+	for key, cmd := range s.Commands {
+		normalizeMap(cmd.Extensions)
+		for i := range cmd.Parameters {
+			normalizeParameter(&cmd.Parameters[i])
+		}
+		for code, resp := range cmd.Responses {
+			normalizeResponse(&resp)
+			cmd.Responses[code] = resp
+		}
+		s.Commands[key] = cmd
+	}
+
+	if s.Components != nil {
+		for _, schema := range s.Components.Schemas {
+			normalizeSchema(schema)
+		}
+		for _, param := range s.Components.Parameters {
+			if param != nil {
+				normalizeParameter(param)
+			}
+		}
+		for _, resp := range s.Components.Responses {
+			if resp != nil {
+				normalizeResponse(resp)
+			}
+		}
+	}
+}
+
+func normalizeParameter(p *Parameter) {
+	normalizeMap(p.Extensions)
+	normalizeSchema(p.Schema)
+}
+
+func normalizeResponse(r *Response) {
+	normalizeMap(r.Extensions)
+	for mediaType, content := range r.Content {
+		content.Example = normalizeValue(content.Example)
+		normalizeSchema(content.Schema)
+		r.Content[mediaType] = content
+	}
+}
+
+func normalizeSchema(s *Schema) {
+	if s == nil {
+		return
+	}
+	s.Default = normalizeValue(s.Default)
+	s.Example = normalizeValue(s.Example)
+	for i, value := range s.Enum {
+		s.Enum[i] = normalizeValue(value)
+	}
+	normalizeSchema(s.Items)
+	normalizeSchema(s.AdditionalProperties)
+	for _, property := range s.Properties {
+		normalizeSchema(property)
+	}
+}
+
+func normalizeMap(m map[string]interface{}) {
+	for key, value := range m {
+		m[key] = normalizeValue(value)
+	}
+}
+
+// normalizeValue converts integral JSON numbers, including those nested in
+// objects and arrays, to int. encoding/json decodes numbers as float64, so
+// integers beyond 2^53 are not exact and are left as they are.
+func normalizeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int(v)
+		}
+		return v
+	case map[string]interface{}:
+		normalizeMap(v)
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeValue(item)
+		}
+	}
+	return v
+}
@@ -0,0 +1,346 @@
+package spec_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/harihs-330/gospec-cli/pkg/generator"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+)
+
+// randomSpec generates specs whose free-form values only use the types both
+// decoders produce
+type randomSpec struct {
+	*spec.OpenCLISpec
+}
+
+func (randomSpec) Generate(r *rand.Rand, size int) reflect.Value {
+	g := &specGen{r: r, size: size%8 + 1}
+	return reflect.ValueOf(randomSpec{g.spec()})
+}
+
+type specGen struct {
+	r    *rand.Rand
+	size int
+}
+
+const alphabet = "abcdefXYZ019 -_:#'\"/é"
+
+func (g *specGen) string() string {
+	n := g.r.Intn(10)
+	runes := []rune(alphabet)
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteRune(runes[g.r.Intn(len(runes))])
+	}
+	return b.String()
+}
+
+func (g *specGen) strings() []string {
+	n := g.r.Intn(3)
+	if n == 0 {
+		return nil
+	}
+	values := make([]string, n)
+	for i := range values {
+		values[i] = g.string()
+	}
+	return values
+}
+
+func (g *specGen) chance() bool {
+	return g.r.Intn(2) == 0
+}
+
+func (g *specGen) intPtr() *int {
+	if g.chance() {
+		return nil
+	}
+	v := g.r.Intn(100)
+	return &v
+}
+
+// value returns a free-form value nested at most depth levels deep
+func (g *specGen) value(depth int) interface{} {
+	kinds := 6
+	if depth <= 0 {
+		kinds = 4
+	}
+	switch g.r.Intn(kinds + 1) {
+	case 0:
+		return g.string()
+	case 1:
+		return g.r.Intn(20000) - 10000
+	case 2:
+		return float64(g.r.Intn(1000)) + 0.25
+	case 3:
+		return g.chance()
+	case 4:
+		items := make([]interface{}, g.r.Intn(3))
+		for i := range items {
+			items[i] = g.value(depth - 1)
+		}
+		return items
+	case 5:
+		m := make(map[string]interface{})
+		for i := g.r.Intn(3); i > 0; i-- {
+			m[g.string()] = g.value(depth - 1)
+		}
+		return m
+	}
+	return nil
+}
+
+func (g *specGen) extensions() map[string]interface{} {
+	n := g.r.Intn(3)
+	if n == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		m["x-"+g.string()] = g.value(2)
+	}
+	return m
+}
+
+func (g *specGen) spec() *spec.OpenCLISpec {
+	s := &spec.OpenCLISpec{
+		OpenCLI: "1.0.0",
+		Info: spec.Info{
+			Title:       g.string(),
+			Description: g.string(),
+			Version:     g.string(),
+		},
+		Commands: make(map[string]spec.Command),
+	}
+	if g.chance() {
+		s.Info.Contact = &spec.Contact{Name: g.string(), URL: g.string()}
+		s.Info.License = &spec.License{Name: g.string()}
+		s.ExternalDocs = &spec.ExternalDocs{URL: g.string()}
+	}
+	for i := g.r.Intn(3); i > 0; i-- {
+		s.Platforms = append(s.Platforms, spec.Platform{Name: g.string(), Architectures: g.strings()})
+		s.Environment = append(s.Environment, spec.EnvironmentVariable{Name: g.string(), Required: g.chance(), Default: g.string()})
+		s.Tags = append(s.Tags, spec.Tag{Name: g.string(), Description: g.string()})
+	}
+	for i := g.r.Intn(g.size); i >= 0; i-- {
+		s.Commands["/app/"+strconv.Itoa(i)] = g.command()
+	}
+	if g.chance() {
+		s.Components = &spec.Components{
+			Schemas:    map[string]*spec.Schema{g.string(): g.schema(2)},
+			Parameters: map[string]*spec.Parameter{g.string(): g.parameter()},
+			Responses:  map[string]*spec.Response{g.string(): g.response()},
+		}
+	}
+	return s
+}
+
+func (g *specGen) command() spec.Command {
+	cmd := spec.Command{
+		Summary:     g.string(),
+		Description: g.string(),
+		OperationID: g.string(),
+		Aliases:     g.strings(),
+		Tags:        g.strings(),
+		Deprecated:  g.chance(),
+		Hidden:      g.chance(),
+		Extensions:  g.extensions(),
+	}
+	for i := g.r.Intn(4); i > 0; i-- {
+		cmd.Parameters = append(cmd.Parameters, *g.parameter())
+	}
+	if g.chance() {
+		cmd.Responses = map[string]spec.Response{"0": *g.response(), "1": *g.response()}
+	}
+	return cmd
+}
+
+func (g *specGen) parameter() *spec.Parameter {
+	p := &spec.Parameter{
+		Name:        g.string(),
+		In:          []string{"argument", "flag", "option"}[g.r.Intn(3)],
+		Alias:       g.strings(),
+		Description: g.string(),
+		Required:    g.chance(),
+		Scope:       []string{"", "local", "inherited"}[g.r.Intn(3)],
+		Position:    g.r.Intn(3),
+		Deprecated:  g.chance(),
+		Hidden:      g.chance(),
+		Extensions:  g.extensions(),
+	}
+	if g.chance() {
+		p.Schema = g.schema(2)
+	}
+	if g.chance() {
+		p.Arity = &spec.Arity{Min: g.r.Intn(3), Max: g.intPtr()}
+	}
+	return p
+}
+
+func (g *specGen) schema(depth int) *spec.Schema {
+	s := &spec.Schema{
+		Type:      []string{"string", "integer", "boolean", "array"}[g.r.Intn(4)],
+		Format:    g.string(),
+		Pattern:   g.string(),
+		MinLength: g.intPtr(),
+		MaxLength: g.intPtr(),
+	}
+	if g.chance() {
+		s.Default = g.value(1)
+		s.Example = g.value(1)
+	}
+	if g.chance() {
+		s.Enum = []interface{}{g.string(), g.r.Intn(10)}
+	}
+	if g.chance() {
+		min := float64(g.r.Intn(10)) + 0.5
+		s.Minimum = &min
+	}
+	if depth > 0 && g.chance() {
+		s.Items = g.schema(depth - 1)
+		s.Properties = map[string]*spec.Schema{g.string(): g.schema(depth - 1)}
+	}
+	return s
+}
+
+func (g *specGen) response() *spec.Response {
+	r := &spec.Response{
+		Description: g.string(),
+		Extensions:  g.extensions(),
+	}
+	if g.chance() {
+		r.Content = map[string]spec.MediaType{
+			"text/plain": {Example: g.value(1)},
+		}
+		if g.chance() {
+			r.Content["application/json"] = spec.MediaType{Schema: g.schema(1)}
+		}
+	}
+	return r
+}
+
+func TestRoundTrip_YAML(t *testing.T) {
+	roundTrip := func(s randomSpec) bool {
+		var buf bytes.Buffer
+		if err := generator.NewYAMLGenerator().Generate(s.OpenCLISpec, &buf); err != nil {
+			t.Logf("generate: %v", err)
+			return false
+		}
+		return parsesTo(t, buf.Bytes(), s.OpenCLISpec)
+	}
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRoundTrip_JSON(t *testing.T) {
+	roundTrip := func(s randomSpec) bool {
+		var buf bytes.Buffer
+		if err := generator.NewJSONGenerator().Generate(s.OpenCLISpec, &buf); err != nil {
+			t.Logf("generate: %v", err)
+			return false
+		}
+		return parsesTo(t, buf.Bytes(), s.OpenCLISpec)
+	}
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+// parsesTo reports whether data parses back into want
+func parsesTo(t *testing.T, data []byte, want *spec.OpenCLISpec) bool {
+	got, err := spec.Parse(data)
+	if err != nil {
+		t.Logf("parse: %v\n%s", err, data)
+		return false
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Logf("round trip mismatch:\n%s", data)
+		return false
+	}
+	return true
+}
+
+func TestCommand_JSONExtensions(t *testing.T) {
+	cmd := spec.Command{
+		Summary: "Deploy",
+		Extensions: map[string]interface{}{
+			"x-audit":   true,
+			"cobra_use": "deploy",
+		},
+	}
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	want := `{"summary":"Deploy","cobra_use":"deploy","x-audit":true}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var decoded spec.Command
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if decoded.Summary != "Deploy" || decoded.Extensions["x-audit"] != true || decoded.Extensions["cobra_use"] != "deploy" {
+		t.Errorf("Unexpected command: %+v", decoded)
+	}
+
+	// Extensions must not shadow fields
+	cmd.Extensions["summary"] = "other"
+	if _, err := json.Marshal(cmd); err == nil {
+		t.Error("Expected error for extension conflicting with a field")
+	}
+}
+
+func TestJSONExtensions_NoOtherFields(t *testing.T) {
+	data, err := json.Marshal(spec.Response{Extensions: map[string]interface{}{"x-a": 1}})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if string(data) != `{"description":"","x-a":1}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	data, err = json.Marshal(spec.Parameter{Extensions: map[string]interface{}{"x-a": 1}})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if string(data) != `{"name":"","x-a":1}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`{"opencli": "1.0.0"}`, spec.FormatJSON},
+		{"\xef\xbb\xbf\n  {\"opencli\": \"1.0.0\"}\n", spec.FormatJSON},
+		{"opencli: 1.0.0\n", spec.FormatYAML},
+		{"{opencli: 1.0.0}", spec.FormatYAML},
+		{"", spec.FormatYAML},
+	}
+	for _, tt := range tests {
+		if got := spec.DetectFormat([]byte(tt.data)); got != tt.want {
+			t.Errorf("DetectFormat(%q) = %s, want %s", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := spec.Parse([]byte("opencli: [")); err == nil {
+		t.Error("Expected error for malformed YAML")
+	}
+	if _, err := spec.Load("testdata/missing.yaml"); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -0,0 +1,24 @@
+package spec
+
+import "fmt"
+
+// AllowedValues returns the values a parameter with schema s accepts, as
+// they are typed on the command line: the enum of the schema, or for arrays
+// the enum of their items. It returns nil when any value is accepted.
+func AllowedValues(s *Schema) []string {
+	if s == nil {
+		return nil
+	}
+	enum := s.Enum
+	if len(enum) == 0 && s.Type == "array" && s.Items != nil {
+		enum = s.Items.Enum
+	}
+	if len(enum) == 0 {
+		return nil
+	}
+	values := make([]string, len(enum))
+	for i, v := range enum {
+		values[i] = fmt.Sprint(v)
+	}
+	return values
+}
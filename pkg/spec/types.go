@@ -61,6 +61,26 @@ type Tag struct {
 	ExternalDocs *ExternalDocs `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
 }
 
+// Extension keys for command details that have no field in Command
+const (
+	// ExtensionUsage holds the usage line without the parent path, e.g. "create <name>"
+	ExtensionUsage = "x-usage"
+	// ExtensionExamples holds example invocations as free text
+	ExtensionExamples = "x-examples"
+	// ExtensionRunnable is false for commands that only group subcommands
+	// and true for commands that do something when invoked
+	ExtensionRunnable = "x-runnable"
+	// ExtensionCompletion holds the Completion of a parameter, as reported
+	// by the CLI's own completion functions
+	ExtensionCompletion = "x-completion"
+	// ExtensionConfigKey holds the configuration file key a parameter is
+	// bound to
+	ExtensionConfigKey = "x-config-key"
+	// ExtensionConfigKeys lists, on the root command, every key the
+	// configuration file of the CLI may set
+	ExtensionConfigKeys = "x-config-keys"
+)
+
 // Command represents a CLI command
 type Command struct {
 	Summary     string                 `yaml:"summary,omitempty" json:"summary,omitempty"`
@@ -72,9 +92,27 @@ type Command struct {
 	Responses   map[string]Response    `yaml:"responses,omitempty" json:"responses,omitempty"`
 	Deprecated  bool                   `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
 	Hidden      bool                   `yaml:"hidden,omitempty" json:"hidden,omitempty"`
+	FlagGroups  []FlagGroup            `yaml:"flagGroups,omitempty" json:"flagGroups,omitempty"`
 	Extensions  map[string]interface{} `yaml:",inline" json:"-"`
 }
 
+// Kinds of flag groups
+const (
+	// FlagGroupMutuallyExclusive allows at most one flag of the group
+	FlagGroupMutuallyExclusive = "mutually-exclusive"
+	// FlagGroupRequiredTogether requires all flags of the group once one is set
+	FlagGroupRequiredTogether = "required-together"
+	// FlagGroupOneRequired requires at least one flag of the group
+	FlagGroupOneRequired = "one-required"
+)
+
+// FlagGroup constrains how the flags of a command may be combined. Flags
+// are named without dashes and may be inherited from parent commands.
+type FlagGroup struct {
+	Kind  string   `yaml:"kind" json:"kind"`
+	Flags []string `yaml:"flags" json:"flags"`
+}
+
 // Parameter represents a command parameter/flag
 type Parameter struct {
 	Name        string                 `yaml:"name" json:"name"`
@@ -88,6 +126,7 @@ type Parameter struct {
 	Arity       *Arity                 `yaml:"arity,omitempty" json:"arity,omitempty"`
 	Deprecated  bool                   `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
 	Hidden      bool                   `yaml:"hidden,omitempty" json:"hidden,omitempty"`
+	Env         []string               `yaml:"env,omitempty" json:"env,omitempty"` // environment variables setting the parameter
 	Extensions  map[string]interface{} `yaml:",inline" json:"-"`
 }
 
@@ -99,18 +138,19 @@ type Arity struct {
 
 // Schema defines the data type and validation rules
 type Schema struct {
-	Type       string             `yaml:"type,omitempty" json:"type,omitempty"`
-	Format     string             `yaml:"format,omitempty" json:"format,omitempty"`
-	Enum       []interface{}      `yaml:"enum,omitempty" json:"enum,omitempty"`
-	Default    interface{}        `yaml:"default,omitempty" json:"default,omitempty"`
-	Example    interface{}        `yaml:"example,omitempty" json:"example,omitempty"`
-	Pattern    string             `yaml:"pattern,omitempty" json:"pattern,omitempty"`
-	MinLength  *int               `yaml:"minLength,omitempty" json:"minLength,omitempty"`
-	MaxLength  *int               `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
-	Minimum    *float64           `yaml:"minimum,omitempty" json:"minimum,omitempty"`
-	Maximum    *float64           `yaml:"maximum,omitempty" json:"maximum,omitempty"`
-	Items      *Schema            `yaml:"items,omitempty" json:"items,omitempty"`
-	Properties map[string]*Schema `yaml:"properties,omitempty" json:"properties,omitempty"`
+	Type                 string             `yaml:"type,omitempty" json:"type,omitempty"`
+	Format               string             `yaml:"format,omitempty" json:"format,omitempty"`
+	Enum                 []interface{}      `yaml:"enum,omitempty" json:"enum,omitempty"`
+	Default              interface{}        `yaml:"default,omitempty" json:"default,omitempty"`
+	Example              interface{}        `yaml:"example,omitempty" json:"example,omitempty"`
+	Pattern              string             `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	MinLength            *int               `yaml:"minLength,omitempty" json:"minLength,omitempty"`
+	MaxLength            *int               `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+	Minimum              *float64           `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+	Maximum              *float64           `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+	Items                *Schema            `yaml:"items,omitempty" json:"items,omitempty"`
+	Properties           map[string]*Schema `yaml:"properties,omitempty" json:"properties,omitempty"`
+	AdditionalProperties *Schema            `yaml:"additionalProperties,omitempty" json:"additionalProperties,omitempty"`
 }
 
 // Response represents a command response
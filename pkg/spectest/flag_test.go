@@ -0,0 +1,21 @@
+package spectest_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli/pkg/spectest"
+)
+
+// update is defined as test packages commonly do; importing spectest must
+// not make its definition panic with "flag redefined"
+var update = flag.Bool("update", false, "update golden files")
+
+func TestUpdateFlag_CoexistsWithLocalUpdate(t *testing.T) {
+	if flag.Lookup("update") == nil || *update {
+		t.Fatal("Expected the local -update flag to be defined and unset")
+	}
+	if flag.Lookup(spectest.UpdateFlag) == nil {
+		t.Errorf("Expected spectest to define -%s", spectest.UpdateFlag)
+	}
+}
@@ -0,0 +1,318 @@
+// Package spectest checks in tests that the OpenCLI specification of a CLI
+// matches a golden file, so that changes to the command line interface are
+// reviewed like any other change:
+//
+//	func TestCLISpec(t *testing.T) {
+//		spectest.AssertGolden(t, cmd.NewRootCmd(), "testdata/cli.golden.yaml")
+//	}
+//
+// Run the tests with -spectest.update, or with SPECTEST_UPDATE=1 in the
+// environment, to create or refresh the golden files:
+//
+//	SPECTEST_UPDATE=1 go test ./...
+//
+// The flag is -spectest.update rather than the customary -update because
+// test packages often define -update themselves, and a second definition
+// panics when the test binary starts. Use the environment variable for
+// "go test ./...", since packages that do not import spectest reject the
+// flag.
+//
+// Specifications are compared after putting them in a canonical order, so
+// the order in which a framework reports flags, aliases or tags does not
+// cause spurious failures. Differences are reported per field, with
+// parameters identified by name rather than by index.
+package spectest
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli"
+	"github.com/harihs-330/gospec-cli/pkg/generator"
+	"github.com/harihs-330/gospec-cli/pkg/parser"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// UpdateFlag is the test flag rewriting golden files instead of comparing
+const UpdateFlag = "spectest.update"
+
+// UpdateEnv is the environment variable rewriting golden files when set to
+// a true value, for runs such as "go test ./..." where only some packages
+// import spectest and so know UpdateFlag
+const UpdateEnv = "SPECTEST_UPDATE"
+
+// goldenHeader starts every golden file written by this package
+const goldenHeader = "# Golden OpenCLI specification. Regenerate with: go test -spectest.update\n"
+
+// update is the value of UpdateFlag
+var update = flag.Bool(UpdateFlag, false, "rewrite spectest golden files")
+
+// updating reports whether golden files are to be rewritten
+func updating() bool {
+	if *update {
+		return true
+	}
+	env, _ := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return env
+}
+
+// AssertGolden converts source, usually a root *cobra.Command, with the
+// default GoSpec parsers and options and compares the result with the
+// golden file at path
+func AssertGolden(t testing.TB, source interface{}, path string) {
+	t.Helper()
+	AssertGoldenWith(t, source, path, gospec.DefaultOptions())
+}
+
+// AssertGoldenWith is AssertGolden with custom conversion options
+func AssertGoldenWith(t testing.TB, source interface{}, path string, options *parser.ConvertOptions) {
+	t.Helper()
+	s, err := gospec.New().Convert(source, options)
+	if err != nil {
+		t.Fatalf("spectest: failed to convert CLI: %v", err)
+	}
+	AssertGoldenSpec(t, s, path)
+}
+
+// AssertGoldenSpec compares s with the golden file at path, or rewrites the
+// file when the tests run with -spectest.update
+func AssertGoldenSpec(t testing.TB, s *spec.OpenCLISpec, path string) {
+	t.Helper()
+
+	got, err := marshal(s)
+	if err != nil {
+		t.Fatalf("spectest: %v", err)
+	}
+
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("spectest: failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, append([]byte(goldenHeader), got...), 0644); err != nil {
+			t.Fatalf("spectest: failed to write golden file: %v", err)
+		}
+		t.Logf("spectest: updated %s", path)
+		return
+	}
+
+	golden, err := spec.Load(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("spectest: golden file %s does not exist; run the tests with -spectest.update to create it", path)
+		}
+		t.Fatalf("spectest: %v", err)
+	}
+	want, err := marshal(golden)
+	if err != nil {
+		t.Fatalf("spectest: %v", err)
+	}
+	if bytes.Equal(got, want) {
+		return
+	}
+
+	differences, err := Diff(golden, s)
+	if err != nil {
+		t.Fatalf("spectest: %v", err)
+	}
+	t.Errorf("spectest: specification differs from %s (-golden +got):\n%s\nRun the tests with -spectest.update to accept the changes.",
+		path, strings.Join(differences, "\n"))
+}
+
+// Canonicalize returns a copy of s in canonical order: parameters are
+// sorted with flags by name before arguments by position, and aliases and
+// tags are sorted. Enum values keep their order, which is meaningful.
+func Canonicalize(s *spec.OpenCLISpec) (*spec.OpenCLISpec, error) {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy spec: %w", err)
+	}
+	c, err := spec.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy spec: %w", err)
+	}
+
+	for key, cmd := range c.Commands {
+		sort.Strings(cmd.Aliases)
+		sort.Strings(cmd.Tags)
+		sort.SliceStable(cmd.Parameters, func(i, j int) bool {
+			a, b := cmd.Parameters[i], cmd.Parameters[j]
+			if (a.In == "argument") != (b.In == "argument") {
+				return b.In == "argument"
+			}
+			if a.In == "argument" && a.Position != b.Position {
+				return a.Position < b.Position
+			}
+			return a.Name < b.Name
+		})
+		for i := range cmd.Parameters {
+			sort.Strings(cmd.Parameters[i].Alias)
+		}
+		c.Commands[key] = cmd
+	}
+	sort.SliceStable(c.Tags, func(i, j int) bool { return c.Tags[i].Name < c.Tags[j].Name })
+	sort.SliceStable(c.Environment, func(i, j int) bool { return c.Environment[i].Name < c.Environment[j].Name })
+	return c, nil
+}
+
+// marshal returns the canonical YAML form of s
+func marshal(s *spec.OpenCLISpec) ([]byte, error) {
+	c, err := Canonicalize(s)
+	if err != nil {
+		return nil, err
+	}
+	// Golden files look like the output of gospec-cli generate
+	var b bytes.Buffer
+	if err := generator.NewYAMLGenerator().Generate(c, &b); err != nil {
+		return nil, fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// Diff returns the differences between two specifications in canonical
+// order, one line per changed field. Lines start with "-" for values only
+// in want and "+" for values only in got.
+func Diff(want, got *spec.OpenCLISpec) ([]string, error) {
+	wantTree, err := tree(want)
+	if err != nil {
+		return nil, err
+	}
+	gotTree, err := tree(got)
+	if err != nil {
+		return nil, err
+	}
+
+	differences := make([]string, 0)
+	diffValues("", wantTree, gotTree, &differences)
+	return differences, nil
+}
+
+// tree returns the canonical form of s as generic YAML values
+func tree(s *spec.OpenCLISpec) (interface{}, error) {
+	data, err := marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to decode spec: %w", err)
+	}
+	return v, nil
+}
+
+// diffValues appends the differences between want and got at path
+func diffValues(path string, want, got interface{}, differences *[]string) {
+	wantMap, wantIsMap := want.(map[string]interface{})
+	gotMap, gotIsMap := got.(map[string]interface{})
+	if wantIsMap && gotIsMap {
+		keys := make([]string, 0, len(wantMap)+len(gotMap))
+		for k := range wantMap {
+			keys = append(keys, k)
+		}
+		for k := range gotMap {
+			if _, ok := wantMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			w, inWant := wantMap[k]
+			g, inGot := gotMap[k]
+			child := joinPath(path, k)
+			switch {
+			case !inGot:
+				*differences = append(*differences, fmt.Sprintf("- %s: %s", child, format(w)))
+			case !inWant:
+				*differences = append(*differences, fmt.Sprintf("+ %s: %s", child, format(g)))
+			default:
+				diffValues(child, w, g, differences)
+			}
+		}
+		return
+	}
+
+	wantList, wantIsList := want.([]interface{})
+	gotList, gotIsList := got.([]interface{})
+	if wantIsList && gotIsList {
+		if named(wantList) && named(gotList) {
+			diffValues(path, byName(wantList), byName(gotList), differences)
+			return
+		}
+		if !reflect.DeepEqual(want, got) {
+			*differences = append(*differences, fmt.Sprintf("- %s: %s", path, format(want)), fmt.Sprintf("+ %s: %s", path, format(got)))
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		*differences = append(*differences, fmt.Sprintf("- %s: %s", path, format(want)), fmt.Sprintf("+ %s: %s", path, format(got)))
+	}
+}
+
+// named reports whether every element of list is a map with a unique name,
+// as parameters, tags and environment variables are
+func named(list []interface{}) bool {
+	seen := map[string]bool{}
+	for _, v := range list {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		name, ok := m["name"].(string)
+		if !ok || seen[name] {
+			return false
+		}
+		seen[name] = true
+	}
+	return len(list) > 0
+}
+
+// byName returns the elements of a named list keyed by "[name]"
+func byName(list []interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(list))
+	for _, v := range list {
+		m["["+v.(map[string]interface{})["name"].(string)+"]"] = v
+	}
+	return m
+}
+
+// joinPath appends a key to a dotted path; list elements keyed by name
+// are appended without a dot
+func joinPath(path, key string) string {
+	if path == "" || strings.HasPrefix(key, "[") {
+		return path + key
+	}
+	return path + "." + key
+}
+
+// format returns a value as compact YAML flow text
+func format(v interface{}) string {
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	node := &yaml.Node{}
+	if err := node.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	setFlow(node)
+	if err := encoder.Encode(node); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// setFlow makes a YAML node and its children print on one line
+func setFlow(node *yaml.Node) {
+	node.Style |= yaml.FlowStyle
+	for _, child := range node.Content {
+		setFlow(child)
+	}
+}
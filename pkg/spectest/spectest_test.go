@@ -0,0 +1,162 @@
+package spectest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/harihs-330/gospec-cli"
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+	"github.com/spf13/cobra"
+)
+
+// recorder is a testing.TB recording failures instead of failing the test
+type recorder struct {
+	testing.TB
+	mu     sync.Mutex
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Logf(format string, args ...interface{}) {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	r.fatal = true
+	panic(r)
+}
+
+// record runs f with a recorder and returns it
+func record(t *testing.T, f func(tb testing.TB)) *recorder {
+	r := &recorder{TB: t}
+	func() {
+		defer func() {
+			if v := recover(); v != nil && v != r {
+				panic(v)
+			}
+		}()
+		f(r)
+	}()
+	return r
+}
+
+// newRoot returns a small CLI; role is the default of --role
+func newRoot(role string) *cobra.Command {
+	root := &cobra.Command{Use: "app", Short: "App CLI"}
+	root.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+	create := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a user",
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+	create.Flags().String("role", role, "role of the user")
+	create.Flags().Int("quota", 10, "storage quota")
+	root.AddCommand(create)
+	return root
+}
+
+// setUpdate sets the -spectest.update flag for the duration of the test
+func setUpdate(t *testing.T, update bool) {
+	t.Helper()
+	previous := flag.Lookup(UpdateFlag).Value.String()
+	if err := flag.Set(UpdateFlag, fmt.Sprint(update)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = flag.Set(UpdateFlag, previous) })
+}
+
+func TestAssertGolden(t *testing.T) {
+	AssertGolden(t, newRoot("member"), filepath.Join("testdata", "app.golden.yaml"))
+}
+
+func TestAssertGolden_Differences(t *testing.T) {
+	r := record(t, func(tb testing.TB) {
+		AssertGolden(tb, newRoot("admin"), filepath.Join("testdata", "app.golden.yaml"))
+	})
+	if len(r.errors) != 1 || r.fatal {
+		t.Fatalf("Expected one error, got %v", r.errors)
+	}
+	for _, want := range []string{
+		"specification differs from testdata/app.golden.yaml (-golden +got)",
+		"- commands./app/create.parameters[role].schema.default: member\n+ commands./app/create.parameters[role].schema.default: admin",
+		"Run the tests with -spectest.update",
+	} {
+		if !strings.Contains(r.errors[0], want) {
+			t.Errorf("Expected error to contain %q, got:\n%s", want, r.errors[0])
+		}
+	}
+}
+
+func TestAssertGolden_Update(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "cli.golden.yaml")
+
+	r := record(t, func(tb testing.TB) { AssertGolden(tb, newRoot("member"), path) })
+	if !r.fatal || !strings.Contains(r.errors[0], "does not exist; run the tests with -spectest.update") {
+		t.Fatalf("Expected a missing golden file error, got %v", r.errors)
+	}
+
+	setUpdate(t, true)
+	AssertGolden(t, newRoot("member"), path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected golden file to be written: %v", err)
+	}
+	if !strings.HasPrefix(string(data), goldenHeader) {
+		t.Errorf("Expected golden file to start with a header, got:\n%s", data)
+	}
+
+	setUpdate(t, false)
+	AssertGolden(t, newRoot("member"), path)
+
+	t.Setenv(UpdateEnv, "1")
+	if !updating() {
+		t.Errorf("Expected %s=1 to rewrite golden files", UpdateEnv)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	a, _ := gospecSpec(t, newRoot("member"))
+	b, _ := gospecSpec(t, newRoot("member"))
+	// Reverse the parameters and aliases of every command
+	for key, cmd := range b.Commands {
+		for i, j := 0, len(cmd.Parameters)-1; i < j; i, j = i+1, j-1 {
+			cmd.Parameters[i], cmd.Parameters[j] = cmd.Parameters[j], cmd.Parameters[i]
+		}
+		cmd.Aliases = []string{"z", "a"}
+		b.Commands[key] = cmd
+	}
+	for key, cmd := range a.Commands {
+		cmd.Aliases = []string{"a", "z"}
+		a.Commands[key] = cmd
+	}
+
+	differences, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(differences) != 0 {
+		t.Errorf("Expected no differences after canonicalization, got %v", differences)
+	}
+}
+
+func gospecSpec(t *testing.T, root *cobra.Command) (*spec.OpenCLISpec, error) {
+	t.Helper()
+	s, err := gospec.New().Convert(root, gospec.DefaultOptions())
+	if err != nil {
+		t.Fatalf("Failed to convert: %v", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,241 @@
+package validate
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaJSON is the OpenCLI JSON Schema documents are validated against
+//
+//go:embed opencli.schema.json
+var SchemaJSON []byte
+
+// openCLISchema is the compiled form of SchemaJSON
+var openCLISchema = mustCompileSchema(SchemaJSON)
+
+// jsonSchema is the subset of JSON Schema used by the OpenCLI schema: type,
+// enum, minimum, required, properties, patternProperties,
+// additionalProperties, items and local $ref into $defs.
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 string                 `json:"type"`
+	Enum                 []interface{}          `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	PatternProperties    map[string]*jsonSchema `json:"patternProperties"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+
+	// Compiled from the raw keywords above
+	patterns   map[*regexp.Regexp]*jsonSchema
+	additional *jsonSchema
+	closed     bool
+}
+
+// mustCompileSchema parses a schema and resolves its keywords. The schema is
+// embedded, so any error is a programming error.
+func mustCompileSchema(data []byte) *jsonSchema {
+	var root jsonSchema
+	if err := json.Unmarshal(data, &root); err != nil {
+		panic(fmt.Sprintf("validate: invalid embedded schema: %v", err))
+	}
+	if err := root.compile(&root); err != nil {
+		panic(fmt.Sprintf("validate: invalid embedded schema: %v", err))
+	}
+	return &root
+}
+
+func (s *jsonSchema) compile(root *jsonSchema) error {
+	if s.Ref != "" && !strings.HasPrefix(s.Ref, "#/$defs/") {
+		return fmt.Errorf("unsupported $ref %q", s.Ref)
+	}
+
+	s.patterns = make(map[*regexp.Regexp]*jsonSchema, len(s.PatternProperties))
+	for pattern, sub := range s.PatternProperties {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+		s.patterns[re] = sub
+	}
+
+	switch raw := strings.TrimSpace(string(s.AdditionalProperties)); raw {
+	case "", "true":
+	case "false":
+		s.closed = true
+	default:
+		s.additional = &jsonSchema{}
+		if err := json.Unmarshal(s.AdditionalProperties, s.additional); err != nil {
+			return err
+		}
+	}
+
+	children := []*jsonSchema{s.Items, s.additional}
+	for _, group := range []map[string]*jsonSchema{s.Properties, s.PatternProperties, s.Defs} {
+		for _, sub := range group {
+			children = append(children, sub)
+		}
+	}
+	for _, sub := range children {
+		if sub == nil {
+			continue
+		}
+		if err := sub.compile(root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSchema checks the document against the OpenCLI schema
+func (d *document) validateSchema() []*Error {
+	v := &schemaValidator{root: openCLISchema}
+	v.validate(openCLISchema, d.root, "")
+	return v.errors
+}
+
+// schemaValidator walks a YAML node tree alongside a schema
+type schemaValidator struct {
+	root   *jsonSchema
+	errors []*Error
+}
+
+func (v *schemaValidator) errorf(node *yaml.Node, pointer, format string, args ...interface{}) {
+	v.errors = append(v.errors, nodeError(node, pointer, format, args...))
+}
+
+func (v *schemaValidator) validate(s *jsonSchema, node *yaml.Node, pointer string) {
+	node = resolve(node)
+	if s.Ref != "" {
+		s = v.root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+	}
+
+	// Reference objects stand in for a component and are checked semantically
+	if node.Kind == yaml.MappingNode && s.Type == "object" && hasKey(node, "$ref") {
+		return
+	}
+
+	if s.Type != "" && !hasType(node, s.Type) {
+		v.errorf(node, pointer, "expected %s, got %s", s.Type, nodeType(node))
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(node, s.Enum) {
+		v.errorf(node, pointer, "must be one of %s", formatEnum(s.Enum))
+	}
+	if s.Minimum != nil && node.Kind == yaml.ScalarNode {
+		if f, err := strconv.ParseFloat(node.Value, 64); err == nil && f < *s.Minimum {
+			v.errorf(node, pointer, "must be >= %v", *s.Minimum)
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateObject(s, node, pointer)
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range node.Content {
+				v.validate(s.Items, item, pointer+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(s *jsonSchema, node *yaml.Node, pointer string) {
+	for _, name := range s.Required {
+		if !hasKey(node, name) {
+			v.errorf(node, pointer, "missing required property %q", name)
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		childPointer := pointer + "/" + escape(key.Value)
+
+		if sub, ok := s.Properties[key.Value]; ok {
+			v.validate(sub, value, childPointer)
+			continue
+		}
+		matched := false
+		for re, sub := range s.patterns {
+			if re.MatchString(key.Value) {
+				matched = true
+				v.validate(sub, value, childPointer)
+			}
+		}
+		switch {
+		case matched:
+		case s.additional != nil:
+			v.validate(s.additional, value, childPointer)
+		case s.closed:
+			v.errorf(key, childPointer, "unknown property %q", key.Value)
+		}
+	}
+}
+
+// hasKey reports whether a mapping node contains key
+func hasKey(node *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeType returns the JSON type of a node
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch node.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+// hasType reports whether a node is an instance of a JSON type. Integers are
+// also numbers.
+func hasType(node *yaml.Node, want string) bool {
+	got := nodeType(node)
+	return got == want || (want == "number" && got == "integer")
+}
+
+// inEnum reports whether a scalar node equals one of the enum values
+func inEnum(node *yaml.Node, enum []interface{}) bool {
+	if node.Kind != yaml.ScalarNode {
+		return false
+	}
+	for _, value := range enum {
+		if fmt.Sprint(value) == node.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// formatEnum lists enum values for error messages
+func formatEnum(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, value := range enum {
+		values[i] = fmt.Sprintf("%q", fmt.Sprint(value))
+	}
+	return strings.Join(values, ", ")
+}
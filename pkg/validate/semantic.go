@@ -0,0 +1,253 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// checkSemantics runs the checks that cannot be expressed in the schema
+func (d *document) checkSemantics(s *spec.OpenCLISpec) []*Error {
+	errs := make([]*Error, 0)
+	errs = append(errs, d.checkSiblingAliases(s)...)
+	errs = append(errs, d.checkTags(s)...)
+	errs = append(errs, d.checkRefs()...)
+
+	for _, key := range sortedKeys(s.Commands) {
+		pointer := "/commands/" + escape(key)
+		params := s.Commands[key].Parameters
+		for i := range params {
+			errs = append(errs, d.checkParameter(&params[i], pointer+"/parameters/"+strconv.Itoa(i))...)
+		}
+		errs = append(errs, d.checkPositions(params, pointer)...)
+		errs = append(errs, d.checkFlagGroups(s, key, pointer)...)
+	}
+
+	if s.Components != nil {
+		for _, name := range sortedKeys(s.Components.Parameters) {
+			if param := s.Components.Parameters[name]; param != nil {
+				errs = append(errs, d.checkParameter(param, "/components/parameters/"+escape(name))...)
+			}
+		}
+		for _, name := range sortedKeys(s.Components.Schemas) {
+			errs = append(errs, d.checkSchema(s.Components.Schemas[name], "/components/schemas/"+escape(name))...)
+		}
+	}
+
+	return errs
+}
+
+// checkSiblingAliases reports names and aliases that are claimed by more than
+// one command below the same parent. Command keys are paths such as
+// "/app/user/create", so siblings share everything up to the last segment.
+func (d *document) checkSiblingAliases(s *spec.OpenCLISpec) []*Error {
+	errs := make([]*Error, 0)
+	claimed := make(map[string]string) // parent + "\x00" + name -> command key
+
+	claim := func(key, parent, name, pointer string) {
+		id := parent + "\x00" + name
+		if owner, ok := claimed[id]; ok && owner != key {
+			errs = append(errs, d.errorf(pointer, "%q is already used by sibling command %q", name, owner))
+			return
+		}
+		claimed[id] = key
+	}
+
+	// Names are claimed before aliases so the alias is reported, not the command
+	keys := sortedKeys(s.Commands)
+	for _, key := range keys {
+		parent, name := splitCommandKey(key)
+		claim(key, parent, name, "/commands/"+escape(key))
+	}
+	for _, key := range keys {
+		parent, _ := splitCommandKey(key)
+		for i, alias := range s.Commands[key].Aliases {
+			claim(key, parent, alias, fmt.Sprintf("/commands/%s/aliases/%d", escape(key), i))
+		}
+	}
+	return errs
+}
+
+// splitCommandKey returns the parent path and name of a command key
+func splitCommandKey(key string) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return "", key
+}
+
+// checkTags reports command tags missing from the top-level tag list
+func (d *document) checkTags(s *spec.OpenCLISpec) []*Error {
+	declared := make(map[string]bool, len(s.Tags))
+	for _, tag := range s.Tags {
+		declared[tag.Name] = true
+	}
+
+	errs := make([]*Error, 0)
+	for _, key := range sortedKeys(s.Commands) {
+		for i, tag := range s.Commands[key].Tags {
+			if !declared[tag] {
+				errs = append(errs, d.errorf(fmt.Sprintf("/commands/%s/tags/%d", escape(key), i),
+					"tag %q is not declared in the top-level tags", tag))
+			}
+		}
+	}
+	return errs
+}
+
+// checkParameter validates the arity and default of a parameter
+func (d *document) checkParameter(param *spec.Parameter, pointer string) []*Error {
+	errs := make([]*Error, 0)
+	if param.Arity != nil && param.Arity.Max != nil && param.Arity.Min > *param.Arity.Max {
+		errs = append(errs, d.errorf(pointer+"/arity",
+			"arity min %d is greater than max %d", param.Arity.Min, *param.Arity.Max))
+	}
+	return append(errs, d.checkSchema(param.Schema, pointer+"/schema")...)
+}
+
+// checkSchema reports defaults that are not among a schema's enum values
+func (d *document) checkSchema(schema *spec.Schema, pointer string) []*Error {
+	errs := make([]*Error, 0)
+	if schema == nil {
+		return errs
+	}
+	if len(schema.Enum) > 0 && schema.Default != nil && !containsValue(schema.Enum, schema.Default) {
+		errs = append(errs, d.errorf(pointer+"/default",
+			"default %v is not one of the enum values", schema.Default))
+	}
+	errs = append(errs, d.checkSchema(schema.Items, pointer+"/items")...)
+	for _, name := range sortedKeys(schema.Properties) {
+		errs = append(errs, d.checkSchema(schema.Properties[name], pointer+"/properties/"+escape(name))...)
+	}
+	return errs
+}
+
+// containsValue compares values by their string form, since YAML decodes
+// "8080" and 8080 to different Go types
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if fmt.Sprint(v) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPositions reports positional arguments whose positions are not
+// 1, 2, 3, ... without gaps or duplicates. Arguments without a position are
+// ignored.
+func (d *document) checkPositions(params []spec.Parameter, pointer string) []*Error {
+	type positional struct {
+		index    int
+		position int
+	}
+	args := make([]positional, 0)
+	for i, param := range params {
+		if param.In == "argument" && param.Position > 0 {
+			args = append(args, positional{i, param.Position})
+		}
+	}
+	sort.SliceStable(args, func(i, j int) bool { return args[i].position < args[j].position })
+
+	errs := make([]*Error, 0)
+	expected := 1
+	for i, arg := range args {
+		argPointer := fmt.Sprintf("%s/parameters/%d/position", pointer, arg.index)
+		switch {
+		case i > 0 && arg.position == args[i-1].position:
+			errs = append(errs, d.errorf(argPointer,
+				"position %d is also used by %q", arg.position, params[args[i-1].index].Name))
+			continue
+		case arg.position != expected:
+			errs = append(errs, d.errorf(argPointer,
+				"position %d leaves a gap, expected %d", arg.position, expected))
+		}
+		expected = arg.position + 1
+	}
+	return errs
+}
+
+// checkFlagGroups reports flag groups too small to constrain anything and
+// group members that are neither flags of the command nor inherited from
+// one of its ancestors
+func (d *document) checkFlagGroups(s *spec.OpenCLISpec, key, pointer string) []*Error {
+	errs := make([]*Error, 0)
+	groups := s.Commands[key].FlagGroups
+	if len(groups) == 0 {
+		return errs
+	}
+
+	// Parameters given by $ref have no name here; their flags are unknown
+	declared := make(map[string]bool)
+	unresolved := false
+	for _, p := range s.Commands[key].Parameters {
+		unresolved = unresolved || p.Name == ""
+		if p.In != "argument" {
+			declared[p.Name] = true
+		}
+	}
+	byPath := make(map[string]spec.Command, len(s.Commands))
+	for k, cmd := range s.Commands {
+		byPath[strings.Trim(k, "/")] = cmd
+	}
+	words := strings.Split(strings.Trim(key, "/"), "/")
+	for i := len(words) - 1; i > 0; i-- {
+		for _, p := range byPath[strings.Join(words[:i], "/")].Parameters {
+			unresolved = unresolved || p.Name == ""
+			if p.In != "argument" && (p.Scope == "inherited" || p.Scope == "global") {
+				declared[p.Name] = true
+			}
+		}
+	}
+
+	for i, group := range groups {
+		groupPointer := fmt.Sprintf("%s/flagGroups/%d", pointer, i)
+		switch {
+		case len(group.Flags) == 0:
+			errs = append(errs, d.errorf(groupPointer+"/flags", "a flag group needs at least one flag"))
+		case len(group.Flags) == 1 && group.Kind != spec.FlagGroupOneRequired:
+			errs = append(errs, d.errorf(groupPointer+"/flags", "a %s group needs at least two flags", group.Kind))
+		}
+		for j, name := range group.Flags {
+			if !unresolved && !declared[name] {
+				errs = append(errs, d.errorf(fmt.Sprintf("%s/flags/%d", groupPointer, j),
+					"flag %q is not a flag of the command", name))
+			}
+		}
+	}
+	return errs
+}
+
+// checkRefs reports $ref values that do not resolve to a component
+func (d *document) checkRefs() []*Error {
+	errs := make([]*Error, 0)
+	for _, pointer := range sortedKeys(d.nodes) {
+		if !strings.HasSuffix(pointer, "/$ref") {
+			continue
+		}
+		node := d.nodes[pointer]
+		if node.Kind != yaml.ScalarNode || !strings.HasPrefix(node.Value, "#/components/") {
+			errs = append(errs, nodeError(node, pointer, "$ref must point into #/components"))
+			continue
+		}
+		if _, ok := d.nodes[strings.TrimPrefix(node.Value, "#")]; !ok {
+			errs = append(errs, nodeError(node, pointer, "$ref %q does not resolve", node.Value))
+		}
+	}
+	return errs
+}
+
+// sortedKeys returns the keys of a map in a stable order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
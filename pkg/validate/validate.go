@@ -0,0 +1,169 @@
+// Package validate checks OpenCLI specification documents.
+//
+// A document is first validated against the embedded OpenCLI JSON Schema and
+// then decoded into spec.OpenCLISpec for semantic checks that a schema cannot
+// express, such as alias conflicts between sibling commands or references to
+// undeclared tags. Every problem is reported with a JSON pointer into the
+// document and the line and column it was found at.
+package validate
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harihs-330/gospec-cli/pkg/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// Error describes a single problem found in a specification
+type Error struct {
+	// Pointer is the RFC 6901 JSON pointer of the offending value
+	Pointer string
+	// Line and Column locate the value in the source, starting at 1
+	Line   int
+	Column int
+	// Message describes the problem
+	Message string
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, pointer, e.Message)
+}
+
+// Result holds the outcome of validating a document
+type Result struct {
+	// Spec is the decoded specification, nil if the document could not be decoded
+	Spec *spec.OpenCLISpec
+	// Errors lists every problem ordered by position in the document
+	Errors []*Error
+}
+
+// Valid reports whether no problems were found
+func (r *Result) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// File validates the YAML or JSON specification at path
+func File(path string) (*Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec file: %w", err)
+	}
+	return Bytes(data)
+}
+
+// Bytes validates a YAML or JSON specification. JSON is parsed as YAML, of
+// which it is a subset, so both formats report positions the same way. An
+// error is only returned if the document cannot be parsed at all.
+func Bytes(data []byte) (*Result, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return &Result{Errors: []*Error{{Line: 1, Column: 1, Message: "document is empty"}}}, nil
+	}
+
+	d := newDocument(doc.Content[0])
+	result := &Result{}
+	result.Errors = append(result.Errors, d.validateSchema()...)
+
+	var s spec.OpenCLISpec
+	if err := d.root.Decode(&s); err != nil {
+		// Type mismatches are already reported by the schema
+		if len(result.Errors) == 0 {
+			result.Errors = append(result.Errors, d.errorf("", "%v", err))
+		}
+	} else {
+		result.Spec = &s
+		result.Errors = append(result.Errors, d.checkSemantics(&s)...)
+	}
+
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		a, b := result.Errors[i], result.Errors[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return result, nil
+}
+
+// document indexes the nodes of a parsed specification by JSON pointer
+type document struct {
+	root  *yaml.Node
+	nodes map[string]*yaml.Node
+}
+
+// newDocument indexes every value below root
+func newDocument(root *yaml.Node) *document {
+	d := &document{root: resolve(root), nodes: make(map[string]*yaml.Node)}
+	d.index("", d.root)
+	return d
+}
+
+func (d *document) index(pointer string, node *yaml.Node) {
+	node = resolve(node)
+	d.nodes[pointer] = node
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			d.index(pointer+"/"+escape(node.Content[i].Value), node.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			d.index(pointer+"/"+strconv.Itoa(i), item)
+		}
+	}
+}
+
+// lookup returns the node at pointer or, if it does not exist, its closest
+// existing ancestor
+func (d *document) lookup(pointer string) *yaml.Node {
+	for {
+		if node, ok := d.nodes[pointer]; ok {
+			return node
+		}
+		i := strings.LastIndex(pointer, "/")
+		if i < 0 {
+			return d.root
+		}
+		pointer = pointer[:i]
+	}
+}
+
+// errorf creates an error located at the node pointer refers to
+func (d *document) errorf(pointer, format string, args ...interface{}) *Error {
+	return nodeError(d.lookup(pointer), pointer, format, args...)
+}
+
+// nodeError creates an error located at node
+func nodeError(node *yaml.Node, pointer, format string, args ...interface{}) *Error {
+	return &Error{
+		Pointer: pointer,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// resolve follows YAML aliases to the node they refer to
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+// escape encodes a key as a JSON pointer reference token
+func escape(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
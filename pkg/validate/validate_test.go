@@ -0,0 +1,173 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFile_Valid(t *testing.T) {
+	result, err := File("testdata/valid.yaml")
+	if err != nil {
+		t.Fatalf("Failed to validate: %v", err)
+	}
+	for _, e := range result.Errors {
+		t.Errorf("Unexpected error: %v", e)
+	}
+	if result.Spec == nil || result.Spec.Info.Title != "Deploy Tool" {
+		t.Errorf("Expected decoded spec, got %+v", result.Spec)
+	}
+}
+
+func TestFile_Invalid(t *testing.T) {
+	result, err := File("testdata/invalid.yaml")
+	if err != nil {
+		t.Fatalf("Failed to validate: %v", err)
+	}
+	if result.Valid() {
+		t.Fatal("Expected invalid spec")
+	}
+
+	tests := []struct {
+		pointer string
+		message string
+		line    int
+		column  int
+	}{
+		{"/info", `missing required property "version"`, 3, 3},
+		{"/commands/~1deploy~1release/tags/0", `tag "releases" is not declared`, 13, 9},
+		{"/commands/~1deploy~1release/parameters/0/in", `must be one of "argument", "flag", "option"`, 16, 13},
+		{"/commands/~1deploy~1release/parameters/0/schema/default", "default nightly is not one of the enum values", 20, 20},
+		{"/commands/~1deploy~1release/parameters/1/arity", "arity min 2 is greater than max 1", 25, 11},
+		{"/commands/~1deploy~1release/parameters/2/position", "position 3 leaves a gap, expected 2", 29, 19},
+		{"/commands/~1deploy~1release/parameters/3/$ref", `$ref "#/components/parameters/missing" does not resolve`, 30, 15},
+		{"/commands/~1deploy~1rollback/aliases/0", `"rb" is already used by sibling command "/deploy/release"`, 33, 9},
+		{"/unknown", `unknown property "unknown"`, 34, 1},
+	}
+
+	if len(result.Errors) != len(tests) {
+		for _, e := range result.Errors {
+			t.Log(e)
+		}
+		t.Fatalf("Expected %d errors, got %d", len(tests), len(result.Errors))
+	}
+	for i, tt := range tests {
+		e := result.Errors[i]
+		if e.Pointer != tt.pointer {
+			t.Errorf("Error %d: expected pointer %q, got %q", i, tt.pointer, e.Pointer)
+		}
+		if !strings.Contains(e.Message, tt.message) {
+			t.Errorf("Error %d: expected message %q, got %q", i, tt.message, e.Message)
+		}
+		if e.Line != tt.line || e.Column != tt.column {
+			t.Errorf("Error %d: expected %d:%d, got %d:%d", i, tt.line, tt.column, e.Line, e.Column)
+		}
+	}
+}
+
+func TestBytes_JSON(t *testing.T) {
+	data := `{
+  "opencli": "1.0.0",
+  "info": {"title": "app", "version": "1.0.0"},
+  "commands": {
+    "app": {
+      "parameters": [
+        {"name": "level", "in": "flag", "arity": {"min": -1}}
+      ]
+    }
+  }
+}`
+	result, err := Bytes([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to validate: %v", err)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %v", result.Errors)
+	}
+	got := result.Errors[0].Error()
+	want := "7:58: /commands/app/parameters/0/arity/min: must be >= 0"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestBytes_TypeMismatch(t *testing.T) {
+	data := "opencli: 1.0.0\ninfo:\n  title: app\n  version: 1.0.0\ncommands: []\n"
+	result, err := Bytes([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to validate: %v", err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Message != "expected object, got array" {
+		t.Errorf("Expected type error, got %v", result.Errors)
+	}
+	if result.Spec != nil {
+		t.Error("Expected no spec for undecodable document")
+	}
+}
+
+func TestBytes_FlagGroups(t *testing.T) {
+	data := []byte(`opencli: 1.0.0
+info:
+  title: App
+  version: 1.0.0
+commands:
+  app:
+    parameters:
+      - name: verbose
+        in: flag
+        scope: inherited
+  /app/run:
+    parameters:
+      - name: json
+        in: flag
+      - name: yaml
+        in: flag
+    flagGroups:
+      - kind: mutually-exclusive
+        flags: [json, yaml, verbose]
+      - kind: required-together
+        flags: [json]
+      - kind: one-required
+        flags: [json, xml]
+      - kind: exclusive
+        flags: [json, yaml]
+`)
+	result, err := Bytes(data)
+	if err != nil {
+		t.Fatalf("Failed to validate: %v", err)
+	}
+
+	tests := []struct {
+		pointer string
+		message string
+	}{
+		{"/commands/~1app~1run/flagGroups/1/flags", "a required-together group needs at least two flags"},
+		{"/commands/~1app~1run/flagGroups/2/flags/1", `flag "xml" is not a flag of the command`},
+		{"/commands/~1app~1run/flagGroups/3/kind", "must be one of"},
+	}
+	if len(result.Errors) != len(tests) {
+		for _, e := range result.Errors {
+			t.Log(e)
+		}
+		t.Fatalf("Expected %d errors, got %d", len(tests), len(result.Errors))
+	}
+	for i, tt := range tests {
+		e := result.Errors[i]
+		if e.Pointer != tt.pointer || !strings.Contains(e.Message, tt.message) {
+			t.Errorf("Error %d: expected %s: %s, got %s: %s", i, tt.pointer, tt.message, e.Pointer, e.Message)
+		}
+	}
+}
+
+func TestBytes_Errors(t *testing.T) {
+	if _, err := Bytes([]byte("opencli: [1.0.0")); err == nil {
+		t.Error("Expected error for malformed YAML")
+	}
+
+	result, err := Bytes(nil)
+	if err != nil {
+		t.Fatalf("Failed to validate: %v", err)
+	}
+	if result.Valid() {
+		t.Error("Expected empty document to be invalid")
+	}
+}